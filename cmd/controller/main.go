@@ -7,10 +7,34 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/tommy351/pullup/cmd"
+	"github.com/tommy351/pullup/internal/githubapp"
 )
 
+type GitHubConfig struct {
+	App githubapp.Config `mapstructure:"app"`
+}
+
+type Config struct {
+	cmd.Config `mapstructure:",squash"`
+
+	GitHub GitHubConfig `mapstructure:"github"`
+}
+
+func NewConfig(conf Config) cmd.Config {
+	return conf.Config
+}
+
+func NewGitHubAppClient(conf Config) (*githubapp.Client, error) {
+	client, err := githubapp.NewClient(conf.GitHub.App)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GitHub App client: %w", err)
+	}
+
+	return client, nil
+}
+
 func run(_ *cobra.Command, _ []string) error {
-	var conf cmd.Config
+	var conf Config
 
 	if err := viper.Unmarshal(&conf); err != nil {
 		return fmt.Errorf("failed to unmarshal config: %w", err)
@@ -25,6 +49,23 @@ func main() {
 		RunE: run,
 	})
 
+	f := cmd.Flags()
+
+	f.Int64("github-app-id", 0, "GitHub App ID")
+	_ = viper.BindPFlag("github.app.id", f.Lookup("github-app-id"))
+
+	f.String("github-app-private-key", "", "GitHub App private key")
+	_ = viper.BindPFlag("github.app.privateKey", f.Lookup("github-app-private-key"))
+	_ = viper.BindEnv("github.app.privateKey", "GITHUB_APP_PRIVATE_KEY")
+
+	f.String("github-app-private-key-file", "", "path to GitHub App private key")
+	_ = viper.BindPFlag("github.app.privateKeyFile", f.Lookup("github-app-private-key-file"))
+	_ = viper.BindEnv("github.app.privateKeyFile", "GITHUB_APP_PRIVATE_KEY_FILE")
+
+	f.String("github-api-url", "", "GitHub API URL")
+	_ = viper.BindPFlag("github.app.baseUrl", f.Lookup("github-api-url"))
+	_ = viper.BindEnv("github.app.baseUrl", "GITHUB_API_URL")
+
 	if err := cmd.Execute(); err != nil {
 		os.Exit(1)
 	}
@@ -5,8 +5,11 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/tommy351/pullup/cmd"
+	"github.com/tommy351/pullup/internal/controller/gitpoll"
+	"github.com/tommy351/pullup/internal/controller/kubernetesevent"
 	"github.com/tommy351/pullup/internal/controller/resourceset"
 	"github.com/tommy351/pullup/internal/controller/resourcetemplate"
+	"github.com/tommy351/pullup/internal/controller/schedule"
 	"github.com/tommy351/pullup/internal/controller/trigger"
 	"github.com/tommy351/pullup/internal/controller/webhook"
 	"github.com/tommy351/pullup/pkg/apis/pullup/v1alpha1"
@@ -44,6 +47,9 @@ func NewManager(
 	hook *webhook.Reconciler,
 	rt *resourcetemplate.Reconciler,
 	trigger *trigger.Reconciler,
+	schedule *schedule.Reconciler,
+	kubeEvent *kubernetesevent.Reconciler,
+	gitPoll *gitpoll.Reconciler,
 ) (*Manager, error) {
 	err := builder.
 		ControllerManagedBy(mgr).
@@ -80,5 +86,36 @@ func NewManager(
 		return nil, fmt.Errorf("failed to build Trigger controller: %w", err)
 	}
 
+	err = builder.
+		ControllerManagedBy(mgr).
+		For(&v1beta1.ScheduleSource{}).
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		Complete(schedule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build ScheduleSource controller: %w", err)
+	}
+
+	err = builder.
+		ControllerManagedBy(mgr).
+		For(&v1beta1.KubernetesEventSource{}).
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		Complete(kubeEvent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build KubernetesEventSource controller: %w", err)
+	}
+
+	if err := mgr.Add(kubeEvent); err != nil {
+		return nil, fmt.Errorf("failed to add KubernetesEventSource queue: %w", err)
+	}
+
+	err = builder.
+		ControllerManagedBy(mgr).
+		For(&v1beta1.GitPollSource{}).
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		Complete(gitPoll)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build GitPollSource controller: %w", err)
+	}
+
 	return &Manager{Manager: mgr}, nil
 }
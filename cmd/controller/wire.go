@@ -6,16 +6,21 @@ import (
 	"github.com/google/wire"
 	"github.com/tommy351/pullup/cmd"
 	"github.com/tommy351/pullup/internal/controller"
+	"github.com/tommy351/pullup/internal/controller/gitpoll"
+	"github.com/tommy351/pullup/internal/controller/kubernetesevent"
 	"github.com/tommy351/pullup/internal/controller/resourceset"
 	"github.com/tommy351/pullup/internal/controller/resourcetemplate"
+	"github.com/tommy351/pullup/internal/controller/schedule"
 	"github.com/tommy351/pullup/internal/controller/trigger"
 	"github.com/tommy351/pullup/internal/controller/webhook"
 	"github.com/tommy351/pullup/internal/k8s"
 	"github.com/tommy351/pullup/internal/log"
 )
 
-func InitializeManager(conf cmd.Config) (*Manager, func(), error) {
+func InitializeManager(conf Config) (*Manager, func(), error) {
 	wire.Build(
+		NewConfig,
+		NewGitHubAppClient,
 		cmd.ConfigSet,
 		log.LoggerSet,
 		k8s.Set,
@@ -27,6 +32,9 @@ func InitializeManager(conf cmd.Config) (*Manager, func(), error) {
 		webhook.ReconcilerSet,
 		trigger.ReconcilerSet,
 		resourcetemplate.ReconcilerSet,
+		schedule.ReconcilerSet,
+		kubernetesevent.ReconcilerSet,
+		gitpoll.ReconcilerSet,
 		NewManager,
 	)
 
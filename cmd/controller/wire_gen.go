@@ -8,18 +8,23 @@ package main
 import (
 	"github.com/tommy351/pullup/cmd"
 	"github.com/tommy351/pullup/internal/controller"
+	"github.com/tommy351/pullup/internal/controller/gitpoll"
+	"github.com/tommy351/pullup/internal/controller/kubernetesevent"
 	"github.com/tommy351/pullup/internal/controller/resourceset"
 	"github.com/tommy351/pullup/internal/controller/resourcetemplate"
+	"github.com/tommy351/pullup/internal/controller/schedule"
 	"github.com/tommy351/pullup/internal/controller/trigger"
 	"github.com/tommy351/pullup/internal/controller/webhook"
 	"github.com/tommy351/pullup/internal/k8s"
 	"github.com/tommy351/pullup/internal/log"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
 )
 
 // Injectors from wire.go:
 
-func InitializeManager(conf cmd.Config) (*Manager, func(), error) {
-	config := cmd.NewKubernetesConfig(conf)
+func InitializeManager(conf Config) (*Manager, func(), error) {
+	cmdConfig := NewConfig(conf)
+	config := cmd.NewKubernetesConfig(cmdConfig)
 	restConfig, err := k8s.LoadConfig(config)
 	if err != nil {
 		return nil, nil, err
@@ -28,13 +33,13 @@ func InitializeManager(conf cmd.Config) (*Manager, func(), error) {
 	if err != nil {
 		return nil, nil, err
 	}
-	logConfig := cmd.NewLogConfig(conf)
+	logConfig := cmd.NewLogConfig(cmdConfig)
 	levelEnabler, err := log.NewZapLevelEnabler(logConfig)
 	if err != nil {
 		return nil, nil, err
 	}
 	logger := log.NewLogger(logConfig, levelEnabler)
-	manager, err := NewControllerManager(restConfig, scheme, conf, logger)
+	manager, err := NewControllerManager(restConfig, scheme, cmdConfig, logger)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -49,10 +54,15 @@ func InitializeManager(conf cmd.Config) (*Manager, func(), error) {
 		Recorder: eventRecorder,
 	}
 	reader := controller.NewAPIReader(manager)
+	githubappClient, err := NewGitHubAppClient(conf)
+	if err != nil {
+		return nil, nil, err
+	}
 	resourcetemplateReconciler := &resourcetemplate.Reconciler{
 		Client:    client,
 		Recorder:  eventRecorder,
 		APIReader: reader,
+		GitHub:    githubappClient,
 	}
 	reconcilerConfig := trigger.ReconcilerConfig{
 		Client:   client,
@@ -62,7 +72,29 @@ func InitializeManager(conf cmd.Config) (*Manager, func(), error) {
 	if err != nil {
 		return nil, nil, err
 	}
-	mainManager, err := NewManager(manager, reconciler, webhookReconciler, resourcetemplateReconciler, triggerReconciler)
+	triggerHandler := hookutil.TriggerHandler{
+		Client:   client,
+		Recorder: eventRecorder,
+	}
+	scheduleReconcilerConfig := schedule.ReconcilerConfig{
+		Client:         client,
+		Recorder:       eventRecorder,
+		TriggerHandler: triggerHandler,
+	}
+	scheduleReconciler := schedule.NewReconciler(scheduleReconcilerConfig)
+	kuberneteseventReconcilerConfig := kubernetesevent.ReconcilerConfig{
+		Client:         client,
+		Recorder:       eventRecorder,
+		TriggerHandler: triggerHandler,
+	}
+	kuberneteseventReconciler := kubernetesevent.NewReconciler(kuberneteseventReconcilerConfig, manager)
+	gitpollReconcilerConfig := gitpoll.ReconcilerConfig{
+		Client:         client,
+		Recorder:       eventRecorder,
+		TriggerHandler: triggerHandler,
+	}
+	gitpollReconciler := gitpoll.NewReconciler(gitpollReconcilerConfig)
+	mainManager, err := NewManager(manager, reconciler, webhookReconciler, resourcetemplateReconciler, triggerReconciler, scheduleReconciler, kuberneteseventReconciler, gitpollReconciler)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -44,9 +45,60 @@ func main() {
 	_ = viper.BindPFlag("webhook.address", f.Lookup("address"))
 	viper.SetDefault("webhook.address", ":8080")
 
+	f.Bool("queue", false, "handle webhooks asynchronously in a queue")
+	_ = viper.BindPFlag("webhook.queue.enabled", f.Lookup("queue"))
+
+	f.Int("queue-workers", 4, "number of workers handling queued webhooks")
+	_ = viper.BindPFlag("webhook.queue.workers", f.Lookup("queue-workers"))
+
+	f.Int("queue-max-attempts", 5, "max attempts of handling a queued webhook")
+	_ = viper.BindPFlag("webhook.queue.maxAttempts", f.Lookup("queue-max-attempts"))
+
+	f.Duration("queue-min-backoff", time.Second, "min delay before retrying a queued webhook")
+	_ = viper.BindPFlag("webhook.queue.minBackoff", f.Lookup("queue-min-backoff"))
+
+	f.Duration("queue-max-backoff", 5*time.Minute, "max delay before retrying a queued webhook")
+	_ = viper.BindPFlag("webhook.queue.maxBackoff", f.Lookup("queue-max-backoff"))
+
+	f.Duration("queue-ttl", 24*time.Hour, "how long completed webhook deliveries are kept, 0 to delete them on completion")
+	_ = viper.BindPFlag("webhook.queue.ttl", f.Lookup("queue-ttl"))
+
+	f.Duration("idempotency-window", 24*time.Hour, "how long delivery IDs are recorded, 0 to disable")
+	_ = viper.BindPFlag("webhook.idempotency.window", f.Lookup("idempotency-window"))
+
+	f.Int("history-limit", 0, "max number of event records of each event source, 0 to disable")
+	_ = viper.BindPFlag("webhook.history.limit", f.Lookup("history-limit"))
+
+	f.Duration("history-ttl", 7*24*time.Hour, "how long event records are kept, 0 to keep until the limit is reached")
+	_ = viper.BindPFlag("webhook.history.ttl", f.Lookup("history-ttl"))
+
+	f.Int("history-max-payload-size", 64*1024, "max size of event payloads in bytes, 0 for unlimited")
+	_ = viper.BindPFlag("webhook.history.maxPayloadSize", f.Lookup("history-max-payload-size"))
+
 	f.String("github-secret", "", "GitHub secret")
 	_ = viper.BindPFlag("github.secret", f.Lookup("github-secret"))
 
+	f.Int64("github-app-id", 0, "GitHub App ID")
+	_ = viper.BindPFlag("github.app.id", f.Lookup("github-app-id"))
+
+	f.String("github-app-private-key", "", "GitHub App private key")
+	_ = viper.BindPFlag("github.app.privateKey", f.Lookup("github-app-private-key"))
+	_ = viper.BindEnv("github.app.privateKey", "GITHUB_APP_PRIVATE_KEY")
+
+	f.String("github-app-private-key-file", "", "path to GitHub App private key")
+	_ = viper.BindPFlag("github.app.privateKeyFile", f.Lookup("github-app-private-key-file"))
+	_ = viper.BindEnv("github.app.privateKeyFile", "GITHUB_APP_PRIVATE_KEY_FILE")
+
+	f.String("github-app-webhook-secret", "", "GitHub App webhook secret")
+	_ = viper.BindPFlag("github.app.webhookSecret", f.Lookup("github-app-webhook-secret"))
+	_ = viper.BindEnv("github.app.webhookSecret", "GITHUB_APP_WEBHOOK_SECRET")
+
+	f.String("github-api-url", "", "GitHub API URL")
+	_ = viper.BindPFlag("github.app.baseUrl", f.Lookup("github-api-url"))
+	_ = viper.BindEnv("github.app.baseUrl", "GITHUB_API_URL")
+
+	cmd.AddCommand(newReplayCommand())
+
 	if err := cmd.Execute(); err != nil {
 		os.Exit(1)
 	}
@@ -6,6 +6,9 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/tommy351/pullup/cmd"
 	"github.com/tommy351/pullup/internal/webhook"
+	"github.com/tommy351/pullup/internal/webhook/history"
+	"github.com/tommy351/pullup/internal/webhook/idempotency"
+	"github.com/tommy351/pullup/internal/webhook/queue"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -24,10 +27,28 @@ func NewControllerManager(restConf *rest.Config, scheme *runtime.Scheme, conf cm
 	})
 }
 
-func NewManager(mgr manager.Manager, webhookServer *webhook.Server) (*Manager, error) {
+func NewManager(mgr manager.Manager, webhookServer *webhook.Server, webhookQueue *queue.Queue, receipts *idempotency.Store, records *history.Store) (*Manager, error) {
 	if err := mgr.Add(webhookServer); err != nil {
 		return nil, fmt.Errorf("failed to register the webhook server: %w", err)
 	}
 
+	if webhookQueue.Config.Enabled {
+		if err := mgr.Add(webhookQueue); err != nil {
+			return nil, fmt.Errorf("failed to register the webhook queue: %w", err)
+		}
+	}
+
+	if receipts.Enabled() {
+		if err := mgr.Add(receipts); err != nil {
+			return nil, fmt.Errorf("failed to register the webhook receipt store: %w", err)
+		}
+	}
+
+	if records.Enabled() {
+		if err := mgr.Add(records); err != nil {
+			return nil, fmt.Errorf("failed to register the event record store: %w", err)
+		}
+	}
+
 	return &Manager{Manager: mgr}, nil
 }
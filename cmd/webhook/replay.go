@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tommy351/pullup/internal/k8s"
+	"github.com/tommy351/pullup/internal/webhook/history"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func NewReplayClient(restConf *rest.Config, scheme *runtime.Scheme) (client.Client, error) {
+	return client.New(restConf, client.Options{Scheme: scheme})
+}
+
+// NewReplayEventRecorder returns an event recorder which sends events to the API
+// server in background. Events which are not sent before the command exits may
+// be dropped.
+func NewReplayEventRecorder(restConf *rest.Config, scheme *runtime.Scheme) (record.EventRecorder, func(), error) {
+	clientset, err := kubernetes.NewForConfig(restConf)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create the kubernetes client: %w", err)
+	}
+
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{
+		Interface: clientset.CoreV1().Events(""),
+	})
+
+	recorder := broadcaster.NewRecorder(scheme, corev1.EventSource{Component: "pullup-webhook"})
+
+	return recorder, broadcaster.Shutdown, nil
+}
+
+// outcomePrinter prints outcomes of replayed triggers.
+type outcomePrinter struct {
+	w io.Writer
+}
+
+func (p *outcomePrinter) Record(_ context.Context, _ *hookutil.TriggerOptions, action string, outcomes []hookutil.TriggerOutcome) {
+	for _, o := range outcomes {
+		_, _ = fmt.Fprintf(p.w, "%s %s: %s: %s\n", action, o.TriggerRef.NamespacedName(), o.Reason, o.Message)
+	}
+}
+
+func parseTrigger(value, namespace string) *v1beta1.EventSourceTrigger {
+	if value == "" {
+		return nil
+	}
+
+	trigger := &v1beta1.EventSourceTrigger{Namespace: namespace, Name: value}
+
+	if i := strings.Index(value, "/"); i >= 0 {
+		trigger.Namespace = value[:i]
+		trigger.Name = value[i+1:]
+	}
+
+	return trigger
+}
+
+func runReplay(cmd *cobra.Command, args []string) error {
+	f := cmd.Flags()
+	namespace, _ := f.GetString("namespace")
+	kubeconfig, _ := f.GetString("kubeconfig")
+	trigger, _ := f.GetString("trigger")
+
+	if kubeconfig == "" {
+		kubeconfig = os.Getenv("KUBECONFIG")
+	}
+
+	replayer, cleanup, err := InitializeReplayer(k8s.Config{
+		Namespace: namespace,
+		Config:    kubeconfig,
+	})
+	if err != nil {
+		return err
+	}
+
+	defer cleanup()
+
+	ctx := cmd.Context()
+	eventRecord := new(v1beta1.EventRecord)
+	key := types.NamespacedName{Namespace: namespace, Name: args[0]}
+
+	if err := replayer.Client.Get(ctx, key, eventRecord); err != nil {
+		return fmt.Errorf("failed to get the event record: %w", err)
+	}
+
+	replayer.TriggerHandler.History = &outcomePrinter{w: cmd.OutOrStdout()}
+
+	return replayer.Replay(ctx, eventRecord, history.ReplayOptions{
+		Trigger: parseTrigger(trigger, eventRecord.Spec.SourceRef.Namespace),
+	})
+}
+
+func newReplayCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replay RECORD",
+		Short: "Replay an event record",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runReplay,
+	}
+
+	f := cmd.Flags()
+	f.StringP("namespace", "n", corev1.NamespaceDefault, "namespace of the event record")
+	f.String("kubeconfig", "", "kubernetes config path")
+	f.String("trigger", "", "replay against this trigger instead, in the form of [namespace/]name")
+
+	return cmd
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/tommy351/pullup/internal/k8s"
 	"github.com/tommy351/pullup/internal/log"
 	"github.com/tommy351/pullup/internal/webhook"
+	"github.com/tommy351/pullup/internal/webhook/history"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
 )
 
 func InitializeManager(conf Config) (*Manager, func(), error) {
@@ -24,3 +26,15 @@ func InitializeManager(conf Config) (*Manager, func(), error) {
 
 	return nil, nil, nil
 }
+
+func InitializeReplayer(conf k8s.Config) (*history.Replayer, func(), error) {
+	wire.Build(
+		k8s.Set,
+		NewReplayClient,
+		NewReplayEventRecorder,
+		hookutil.TriggerHandlerSet,
+		history.ReplayerSet,
+	)
+
+	return nil, nil, nil
+}
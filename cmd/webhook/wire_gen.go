@@ -11,9 +11,17 @@ import (
 	"github.com/tommy351/pullup/internal/k8s"
 	"github.com/tommy351/pullup/internal/log"
 	"github.com/tommy351/pullup/internal/webhook"
+	"github.com/tommy351/pullup/internal/webhook/bitbucket"
+	"github.com/tommy351/pullup/internal/webhook/cloudevents"
+	"github.com/tommy351/pullup/internal/webhook/gitea"
 	"github.com/tommy351/pullup/internal/webhook/github"
+	"github.com/tommy351/pullup/internal/webhook/gitlab"
+	"github.com/tommy351/pullup/internal/webhook/history"
 	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/internal/webhook/idempotency"
 	"github.com/tommy351/pullup/internal/webhook/http"
+	"github.com/tommy351/pullup/internal/webhook/queue"
+	"github.com/tommy351/pullup/internal/webhook/registry"
 )
 
 // Injectors from wire.go:
@@ -43,12 +51,51 @@ func InitializeManager(conf Config) (*Manager, func(), error) {
 	githubConfig := conf.GitHub
 	client := controller.NewClient(manager)
 	eventRecorder := hookutil.NewEventRecorder(manager)
+	historyConfig := webhookConfig.History
+	reader := controller.NewAPIReader(manager)
+	historyStoreConfig := history.StoreConfig{
+		Config: historyConfig,
+		Client: client,
+		Reader: reader,
+		Logger: logger,
+	}
+	historyStore := history.NewStore(historyStoreConfig)
 	triggerHandler := hookutil.TriggerHandler{
 		Client:   client,
 		Recorder: eventRecorder,
+		History:  historyStore,
+	}
+	bitbucketHandlerConfig := bitbucket.HandlerConfig{
+		Client:         client,
+		TriggerHandler: triggerHandler,
+	}
+	bitbucketHandler, err := bitbucket.NewHandler(bitbucketHandlerConfig, manager)
+	if err != nil {
+		return nil, nil, err
+	}
+	cloudeventsHandlerConfig := cloudevents.HandlerConfig{
+		Client:         client,
+		TriggerHandler: triggerHandler,
+	}
+	cloudeventsHandler, err := cloudevents.NewHandler(cloudeventsHandlerConfig, manager)
+	if err != nil {
+		return nil, nil, err
+	}
+	giteaHandlerConfig := gitea.HandlerConfig{
+		Client:         client,
+		TriggerHandler: triggerHandler,
+	}
+	giteaHandler, err := gitea.NewHandler(giteaHandlerConfig, manager)
+	if err != nil {
+		return nil, nil, err
+	}
+	githubappClient, err := github.NewAppClient(githubConfig)
+	if err != nil {
+		return nil, nil, err
 	}
 	handlerConfig := github.HandlerConfig{
 		Config:         githubConfig,
+		App:            githubappClient,
 		Client:         client,
 		Recorder:       eventRecorder,
 		TriggerHandler: triggerHandler,
@@ -57,20 +104,92 @@ func InitializeManager(conf Config) (*Manager, func(), error) {
 	if err != nil {
 		return nil, nil, err
 	}
+	gitlabHandlerConfig := gitlab.HandlerConfig{
+		Client:         client,
+		TriggerHandler: triggerHandler,
+	}
+	gitlabHandler, err := gitlab.NewHandler(gitlabHandlerConfig, manager)
+	if err != nil {
+		return nil, nil, err
+	}
 	httpHandler := &http.Handler{
 		Client:         client,
 		TriggerHandler: triggerHandler,
 	}
+	registryHandlerConfig := registry.HandlerConfig{
+		Client:         client,
+		TriggerHandler: triggerHandler,
+	}
+	registryHandler, err := registry.NewHandler(registryHandlerConfig, manager)
+	if err != nil {
+		return nil, nil, err
+	}
+	queueConfig := webhookConfig.Queue
+	queueQueueConfig := queue.QueueConfig{
+		Config:           queueConfig,
+		KubernetesConfig: k8sConfig,
+		Client:           client,
+		Reader:           reader,
+		Logger:           logger,
+		TriggerHandler:   triggerHandler,
+	}
+	queueQueue := queue.NewQueue(queueQueueConfig)
+	idempotencyConfig := webhookConfig.Idempotency
+	storeConfig := idempotency.StoreConfig{
+		Config:           idempotencyConfig,
+		KubernetesConfig: k8sConfig,
+		Client:           client,
+		Reader:           reader,
+		Logger:           logger,
+	}
+	store := idempotency.NewStore(storeConfig)
 	server := &webhook.Server{
-		Config:        webhookConfig,
-		Logger:        logger,
-		GithubHandler: handler,
-		HTTPHandler:   httpHandler,
+		Config:             webhookConfig,
+		Logger:             logger,
+		BitbucketHandler:   bitbucketHandler,
+		CloudEventsHandler: cloudeventsHandler,
+		GiteaHandler:       giteaHandler,
+		GithubHandler:      handler,
+		GitLabHandler:      gitlabHandler,
+		HTTPHandler:        httpHandler,
+		RegistryHandler:    registryHandler,
+		Queue:              queueQueue,
+		Receipts:           store,
 	}
-	mainManager, err := NewManager(manager, server)
+	mainManager, err := NewManager(manager, server, queueQueue, store, historyStore)
 	if err != nil {
 		return nil, nil, err
 	}
 	return mainManager, func() {
 	}, nil
 }
+
+func InitializeReplayer(conf k8s.Config) (*history.Replayer, func(), error) {
+	config, err := k8s.LoadConfig(conf)
+	if err != nil {
+		return nil, nil, err
+	}
+	scheme, err := k8s.NewScheme()
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := NewReplayClient(config, scheme)
+	if err != nil {
+		return nil, nil, err
+	}
+	eventRecorder, cleanup, err := NewReplayEventRecorder(config, scheme)
+	if err != nil {
+		return nil, nil, err
+	}
+	triggerHandler := hookutil.TriggerHandler{
+		Client:   client,
+		Recorder: eventRecorder,
+	}
+	replayer := &history.Replayer{
+		Client:         client,
+		TriggerHandler: triggerHandler,
+	}
+	return replayer, func() {
+		cleanup()
+	}, nil
+}
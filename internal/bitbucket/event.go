@@ -0,0 +1,211 @@
+package bitbucket
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+const (
+	EventKeyHeader  = "X-Event-Key"
+	SignatureHeader = "X-Hub-Signature"
+)
+
+// Bitbucket Cloud events.
+const (
+	PullRequestCreated   = "pullrequest:created"
+	PullRequestUpdated   = "pullrequest:updated"
+	PullRequestFulfilled = "pullrequest:fulfilled"
+	PullRequestRejected  = "pullrequest:rejected"
+	RepoPush             = "repo:push"
+)
+
+// Bitbucket Server events.
+const (
+	ServerPullRequestOpened         = "pr:opened"
+	ServerPullRequestModified       = "pr:modified"
+	ServerPullRequestFromRefUpdated = "pr:from_ref_updated"
+	ServerPullRequestMerged         = "pr:merged"
+	ServerPullRequestDeclined       = "pr:declined"
+	ServerPullRequestDeleted        = "pr:deleted"
+	ServerRepoRefsChanged           = "repo:refs_changed"
+)
+
+var ErrUnknownEventKey = errors.New("unknown event key")
+
+type Repository struct {
+	UUID     string `json:"uuid,omitempty"`
+	Name     string `json:"name,omitempty"`
+	FullName string `json:"full_name"`
+	Type     string `json:"type,omitempty"`
+}
+
+type Account struct {
+	UUID        string `json:"uuid,omitempty"`
+	AccountID   string `json:"account_id,omitempty"`
+	Nickname    string `json:"nickname,omitempty"`
+	DisplayName string `json:"display_name,omitempty"`
+}
+
+type Commit struct {
+	Hash    string `json:"hash"`
+	Message string `json:"message,omitempty"`
+	Date    string `json:"date,omitempty"`
+}
+
+type Branch struct {
+	Name string `json:"name"`
+}
+
+type PullRequestEndpoint struct {
+	Branch     *Branch     `json:"branch,omitempty"`
+	Commit     *Commit     `json:"commit,omitempty"`
+	Repository *Repository `json:"repository,omitempty"`
+}
+
+type PullRequest struct {
+	ID          int64                `json:"id"`
+	Title       string               `json:"title,omitempty"`
+	Description string               `json:"description,omitempty"`
+	State       string               `json:"state,omitempty"`
+	Author      *Account             `json:"author,omitempty"`
+	Source      *PullRequestEndpoint `json:"source,omitempty"`
+	Destination *PullRequestEndpoint `json:"destination,omitempty"`
+	MergeCommit *Commit              `json:"merge_commit,omitempty"`
+}
+
+// PullRequestEvent is a pull request event of Bitbucket Cloud.
+type PullRequestEvent struct {
+	Actor       *Account     `json:"actor,omitempty"`
+	PullRequest *PullRequest `json:"pullrequest"`
+	Repository  *Repository  `json:"repository"`
+}
+
+type PushRef struct {
+	Type   string  `json:"type"`
+	Name   string  `json:"name"`
+	Target *Commit `json:"target,omitempty"`
+}
+
+type PushChange struct {
+	New     *PushRef  `json:"new"`
+	Old     *PushRef  `json:"old"`
+	Created bool      `json:"created"`
+	Closed  bool      `json:"closed"`
+	Forced  bool      `json:"forced"`
+	Commits []*Commit `json:"commits,omitempty"`
+}
+
+type Push struct {
+	Changes []*PushChange `json:"changes"`
+}
+
+// PushEvent is a repo:push event of Bitbucket Cloud.
+type PushEvent struct {
+	Actor      *Account    `json:"actor,omitempty"`
+	Repository *Repository `json:"repository"`
+	Push       *Push       `json:"push"`
+}
+
+type ServerProject struct {
+	ID   int64  `json:"id,omitempty"`
+	Key  string `json:"key"`
+	Name string `json:"name,omitempty"`
+}
+
+type ServerRepository struct {
+	ID      int64          `json:"id,omitempty"`
+	Slug    string         `json:"slug"`
+	Name    string         `json:"name,omitempty"`
+	Project *ServerProject `json:"project"`
+}
+
+// FullName returns the name of the repository in "PROJECT/slug" form.
+func (r *ServerRepository) FullName() string {
+	if r.Project == nil {
+		return r.Slug
+	}
+
+	return r.Project.Key + "/" + r.Slug
+}
+
+type ServerUser struct {
+	ID          int64  `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Slug        string `json:"slug,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+type ServerRef struct {
+	ID           string            `json:"id"`
+	DisplayID    string            `json:"displayId"`
+	Type         string            `json:"type,omitempty"`
+	LatestCommit string            `json:"latestCommit,omitempty"`
+	Repository   *ServerRepository `json:"repository,omitempty"`
+}
+
+type ServerPullRequest struct {
+	ID          int64      `json:"id"`
+	Version     int64      `json:"version,omitempty"`
+	Title       string     `json:"title,omitempty"`
+	Description string     `json:"description,omitempty"`
+	State       string     `json:"state,omitempty"`
+	FromRef     *ServerRef `json:"fromRef"`
+	ToRef       *ServerRef `json:"toRef"`
+}
+
+// ServerPullRequestEvent is a pull request event of Bitbucket Server.
+type ServerPullRequestEvent struct {
+	EventKey    string             `json:"eventKey"`
+	Date        string             `json:"date,omitempty"`
+	Actor       *ServerUser        `json:"actor,omitempty"`
+	PullRequest *ServerPullRequest `json:"pullRequest"`
+}
+
+type ServerRefChange struct {
+	Ref      *ServerRef `json:"ref"`
+	RefID    string     `json:"refId"`
+	FromHash string     `json:"fromHash"`
+	ToHash   string     `json:"toHash"`
+	Type     string     `json:"type"`
+}
+
+// ServerRefsChangedEvent is a repo:refs_changed event of Bitbucket Server.
+type ServerRefsChangedEvent struct {
+	EventKey   string             `json:"eventKey"`
+	Date       string             `json:"date,omitempty"`
+	Actor      *ServerUser        `json:"actor,omitempty"`
+	Repository *ServerRepository  `json:"repository"`
+	Changes    []*ServerRefChange `json:"changes"`
+}
+
+// WebhookType returns the event key of the webhook request.
+func WebhookType(r *http.Request) string {
+	return r.Header.Get(EventKeyHeader)
+}
+
+// ParseWebhook parses the payload into a struct based on the event key.
+func ParseWebhook(eventKey string, payload []byte) (interface{}, error) {
+	var event interface{}
+
+	switch eventKey {
+	case PullRequestCreated, PullRequestUpdated, PullRequestFulfilled, PullRequestRejected:
+		event = new(PullRequestEvent)
+	case RepoPush:
+		event = new(PushEvent)
+	case ServerPullRequestOpened, ServerPullRequestModified, ServerPullRequestFromRefUpdated,
+		ServerPullRequestMerged, ServerPullRequestDeclined, ServerPullRequestDeleted:
+		event = new(ServerPullRequestEvent)
+	case ServerRepoRefsChanged:
+		event = new(ServerRefsChangedEvent)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownEventKey, eventKey)
+	}
+
+	if err := json.Unmarshal(payload, event); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	return event, nil
+}
@@ -0,0 +1,49 @@
+package gitpoll
+
+import (
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/tommy351/pullup/internal/gitutil"
+)
+
+// PushEvent is synthesized when a ref is created, updated or deleted. Fields
+// are similar to push events of GitHub.
+type PushEvent struct {
+	Ref        string          `json:"ref"`
+	RefType    gitutil.RefType `json:"refType"`
+	RefName    string          `json:"refName"`
+	Before     string          `json:"before"`
+	After      string          `json:"after"`
+	Created    bool            `json:"created"`
+	Deleted    bool            `json:"deleted"`
+	Repository PushRepository  `json:"repository"`
+}
+
+type PushRepository struct {
+	URL string `json:"url"`
+}
+
+func newPushEvent(url, ref, before, after string) *PushEvent {
+	parsed, _ := gitutil.ParseRef(ref)
+	event := &PushEvent{
+		Ref:     ref,
+		RefType: parsed.Type,
+		RefName: parsed.Name,
+		Before:  before,
+		After:   after,
+		Created: before == "",
+		Deleted: after == "",
+		Repository: PushRepository{
+			URL: url,
+		},
+	}
+
+	if event.Created {
+		event.Before = plumbing.ZeroHash.String()
+	}
+
+	if event.Deleted {
+		event.After = plumbing.ZeroHash.String()
+	}
+
+	return event
+}
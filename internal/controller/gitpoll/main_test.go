@@ -0,0 +1,11 @@
+package gitpoll
+
+import (
+	"testing"
+
+	"github.com/tommy351/pullup/internal/testenv"
+)
+
+func Test(t *testing.T) {
+	testenv.RunSpecsInEnvironment(t, "controller/gitpoll")
+}
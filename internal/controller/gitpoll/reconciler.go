@@ -0,0 +1,235 @@
+package gitpoll
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-logr/logr"
+	"github.com/google/wire"
+	"github.com/tommy351/pullup/internal/controller"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// +kubebuilder:rbac:groups=pullup.dev,resources=gitpollsources,verbs=get;list;watch
+// +kubebuilder:rbac:groups=pullup.dev,resources=gitpollsources/status,verbs=get;update;patch
+
+const (
+	ReasonPollFailed    = "PollFailed"
+	ReasonTriggerFailed = "TriggerFailed"
+	ReasonFailed        = "Failed"
+)
+
+const defaultInterval = 5 * time.Minute
+
+// ReconcilerConfigSet provides a ReconcilerConfig.
+// nolint: gochecknoglobals
+var ReconcilerConfigSet = wire.NewSet(
+	hookutil.TriggerHandlerSet,
+	wire.Struct(new(ReconcilerConfig), "*"),
+)
+
+// ReconcilerSet provides a Reconciler.
+// nolint: gochecknoglobals
+var ReconcilerSet = wire.NewSet(
+	ReconcilerConfigSet,
+	NewReconciler,
+)
+
+type ReconcilerConfig struct {
+	Client         client.Client
+	Recorder       record.EventRecorder
+	TriggerHandler hookutil.TriggerHandler
+}
+
+type Reconciler struct {
+	ReconcilerConfig
+
+	now         func() time.Time
+	validateURL func(url string) error
+}
+
+func NewReconciler(conf ReconcilerConfig) *Reconciler {
+	return &Reconciler{
+		ReconcilerConfig: conf,
+		now:              time.Now,
+		validateURL:      validateURL,
+	}
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	source := new(v1beta1.GitPollSource)
+
+	if err := r.Client.Get(ctx, req.NamespacedName, source); err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, fmt.Errorf("failed to get git poll source: %w", err)
+	}
+
+	logger := logr.FromContextOrDiscard(ctx).WithValues("gitPollSource", source)
+	ctx = logr.NewContext(ctx, logger)
+
+	now := r.now()
+	interval := getInterval(source)
+
+	// Wait until the next poll when the source is reconciled before that,
+	// e.g. when the controller is restarted.
+	if last := source.Status.LastPollTime; last != nil && !isRemoteChanged(source) {
+		if next := last.Add(interval); now.Before(next) {
+			return reconcile.Result{RequeueAfter: next.Sub(now)}, nil
+		}
+	}
+
+	if err := r.poll(ctx, source); err != nil {
+		_, _ = r.handleResult(ctx, source, controller.Result{
+			Error:  err,
+			Reason: ReasonPollFailed,
+		})
+
+		return reconcile.Result{RequeueAfter: interval}, nil
+	}
+
+	source.Status.LastPollTime = &metav1.Time{Time: now}
+
+	if err := r.Client.Status().Update(ctx, source); err != nil {
+		return r.handleResult(ctx, source, controller.Result{
+			Error:  fmt.Errorf("failed to update status: %w", err),
+			Reason: ReasonFailed,
+		})
+	}
+
+	return reconcile.Result{RequeueAfter: interval}, nil
+}
+
+// poll lists refs of the remote and triggers events of changed refs. Refs in
+// status are updated only when events are triggered successfully, so failed
+// events are retried in the next poll.
+func (r *Reconciler) poll(ctx context.Context, source *v1beta1.GitPollSource) error {
+	logger := logr.FromContextOrDiscard(ctx)
+
+	if err := r.validateURL(source.Spec.URL); err != nil {
+		return err
+	}
+
+	auth, err := r.getAuth(ctx, source)
+	if err != nil {
+		return err
+	}
+
+	refs, err := listRefs(ctx, source.Spec.URL, auth)
+	if err != nil {
+		return err
+	}
+
+	refs = filterRefs(refs, &source.Spec)
+
+	// Refs are not compared in the first poll or when the URL or filters are
+	// changed, otherwise all existing refs are considered as created, and
+	// refs excluded by previous filters are considered as deleted.
+	if isRemoteChanged(source) || source.Status.LastPollTime == nil {
+		logger.Info("Initialized refs", "count", len(refs))
+		source.Status.URL = source.Spec.URL
+		source.Status.Branches = source.Spec.Branches.DeepCopy()
+		source.Status.Tags = source.Spec.Tags.DeepCopy()
+		source.Status.Refs = refs
+
+		return nil
+	}
+
+	state := map[string]string{}
+
+	for k, v := range source.Status.Refs {
+		state[k] = v
+	}
+
+	for _, event := range diffRefs(source.Spec.URL, source.Status.Refs, refs) {
+		options := &hookutil.TriggerOptions{
+			Action:        source.Spec.Action,
+			DefaultAction: v1beta1.ActionApply,
+			Event:         event,
+			Source:        source,
+			Triggers:      source.Spec.Triggers,
+		}
+
+		if event.Deleted {
+			options.DefaultAction = v1beta1.ActionDelete
+		}
+
+		ctx := logr.NewContext(ctx, logger.WithValues("ref", event.Ref))
+
+		if err := r.TriggerHandler.Handle(ctx, options); err != nil {
+			_, _ = r.handleResult(ctx, source, controller.Result{
+				Error:  fmt.Errorf("failed to trigger %s: %w", event.Ref, err),
+				Reason: ReasonTriggerFailed,
+			})
+
+			continue
+		}
+
+		if event.Deleted {
+			delete(state, event.Ref)
+		} else {
+			state[event.Ref] = event.After
+		}
+	}
+
+	source.Status.Refs = state
+
+	return nil
+}
+
+func (r *Reconciler) getAuth(ctx context.Context, source *v1beta1.GitPollSource) (transport.AuthMethod, error) {
+	if source.Spec.Password == nil {
+		return nil, nil
+	}
+
+	password, err := hookutil.GetSecretValue(ctx, r.Client, source.Namespace, source.Spec.Password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get password: %w", err)
+	}
+
+	return &githttp.BasicAuth{
+		Username: source.Spec.Username,
+		Password: string(password),
+	}, nil
+}
+
+func (r *Reconciler) handleResult(ctx context.Context, source *v1beta1.GitPollSource, result controller.Result) (reconcile.Result, error) {
+	logger := logr.FromContextOrDiscard(ctx)
+	result.RecordEvent(r.Recorder, source)
+
+	if err := result.Error; err != nil {
+		logger.Error(result.Error, result.GetMessage())
+	} else {
+		logger.Info(result.GetMessage())
+	}
+
+	return reconcile.Result{Requeue: result.Requeue}, result.Error
+}
+
+// isRemoteChanged returns true when refs in status are not polled with the
+// current URL and filters.
+func isRemoteChanged(source *v1beta1.GitPollSource) bool {
+	return source.Status.URL != source.Spec.URL ||
+		!equality.Semantic.DeepEqual(source.Status.Branches, source.Spec.Branches) ||
+		!equality.Semantic.DeepEqual(source.Status.Tags, source.Spec.Tags)
+}
+
+func getInterval(source *v1beta1.GitPollSource) time.Duration {
+	if i := source.Spec.Interval; i != nil && i.Duration > 0 {
+		return i.Duration
+	}
+
+	return defaultInterval
+}
@@ -0,0 +1,325 @@
+package gitpoll
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tommy351/pullup/internal/k8s"
+	"github.com/tommy351/pullup/internal/random"
+	"github.com/tommy351/pullup/internal/testenv"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Reconciler", func() {
+	var (
+		reconciler   *Reconciler
+		mgr          *testenv.Manager
+		namespaceMap *random.NamespaceMap
+		repo         *testRepo
+		data         []client.Object
+		now          time.Time
+	)
+
+	interval := time.Minute
+
+	getKey := func() types.NamespacedName {
+		return types.NamespacedName{
+			Namespace: namespaceMap.GetRandom("test"),
+			Name:      "foobar",
+		}
+	}
+
+	reconcileSource := func() (reconcile.Result, error) {
+		return reconciler.Reconcile(context.TODO(), reconcile.Request{
+			NamespacedName: getKey(),
+		})
+	}
+
+	getSource := func() *v1beta1.GitPollSource {
+		source := new(v1beta1.GitPollSource)
+		Expect(reconciler.Client.Get(context.Background(), getKey(), source)).To(Succeed())
+
+		return source
+	}
+
+	// waitForPoll waits until the cache is updated, otherwise the next
+	// reconciliation may get the stale status.
+	waitForPoll := func() {
+		Eventually(func() bool {
+			t := getSource().Status.LastPollTime
+
+			return t != nil && t.Time.Equal(now)
+		}).Should(BeTrue())
+	}
+
+	createSource := func(url string) {
+		source := &v1beta1.GitPollSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespaceMap.GetRandom("test"),
+				Name:      "foobar",
+			},
+			Spec: v1beta1.GitPollSourceSpec{
+				EventSourceSpec: v1beta1.EventSourceSpec{
+					Triggers: []v1beta1.EventSourceTrigger{
+						{Name: "foobar"},
+					},
+				},
+				URL:      url,
+				Interval: &metav1.Duration{Duration: interval},
+			},
+		}
+
+		Expect(testenv.CreateObjects([]client.Object{source})).To(Succeed())
+		data = append(data, source)
+	}
+
+	BeforeEach(func() {
+		var err error
+		mgr, err = testenv.NewManager()
+		Expect(err).NotTo(HaveOccurred())
+
+		reconciler = NewReconciler(NewReconcilerConfig(mgr))
+		reconciler.now = func() time.Time {
+			return now
+		}
+		// Test repositories are local paths.
+		reconciler.validateURL = func(string) error {
+			return nil
+		}
+
+		Expect(mgr.Initialize()).To(Succeed())
+
+		namespaceMap = random.NewNamespaceMap()
+		now = time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+		repo = newTestRepo()
+
+		data, err = k8s.LoadObjects(testenv.GetScheme(), "testdata/trigger.yml")
+		Expect(err).NotTo(HaveOccurred())
+
+		data, err = k8s.MapObjects(data, namespaceMap.SetObject)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testenv.CreateObjects(data)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(testenv.DeleteObjects(data)).To(Succeed())
+		repo.Remove()
+		mgr.Stop()
+	})
+
+	When("source does not exist", func() {
+		It("should not requeue", func() {
+			result, err := reconcileSource()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{}))
+		})
+	})
+
+	When("source is polled at the first time", func() {
+		var (
+			result reconcile.Result
+			err    error
+			hash   string
+		)
+
+		BeforeEach(func() {
+			hash = repo.Commit("first")
+			repo.SetRef("refs/heads/main", hash)
+			createSource(repo.Path)
+			result, err = reconcileSource()
+			waitForPoll()
+		})
+
+		It("should requeue after the interval", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{RequeueAfter: interval}))
+		})
+
+		It("should store refs in status", func() {
+			source := getSource()
+			Expect(source.Status.URL).To(Equal(repo.Path))
+			Expect(source.Status.LastPollTime.Time).To(BeTemporally("==", now))
+			Expect(source.Status.Refs).To(Equal(map[string]string{
+				"refs/heads/main": hash,
+			}))
+		})
+
+		It("should not trigger existing refs", func() {
+			list := new(v1beta1.ResourceTemplateList)
+			Expect(reconciler.Client.List(context.Background(), list, client.InNamespace(getKey().Namespace))).To(Succeed())
+			Expect(list.Items).To(BeEmpty())
+		})
+
+		When("it is reconciled before the interval", func() {
+			BeforeEach(func() {
+				repo.SetRef("refs/heads/foo", hash)
+				now = now.Add(interval / 2)
+				result, err = reconcileSource()
+			})
+
+			It("should requeue after the rest of the interval", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(reconcile.Result{RequeueAfter: interval / 2}))
+			})
+
+			It("should not poll refs", func() {
+				Expect(getSource().Status.Refs).To(Equal(map[string]string{
+					"refs/heads/main": hash,
+				}))
+			})
+		})
+
+		When("filters are changed", func() {
+			BeforeEach(func() {
+				source := getSource()
+				source.Spec.Branches = &v1beta1.EventSourceFilter{Include: []string{"foo"}}
+				Expect(reconciler.Client.Update(context.Background(), source)).To(Succeed())
+				Eventually(func() *v1beta1.EventSourceFilter {
+					return getSource().Spec.Branches
+				}).ShouldNot(BeNil())
+
+				repo.SetRef("refs/heads/foo", hash)
+				now = now.Add(interval / 2)
+				result, err = reconcileSource()
+				waitForPoll()
+			})
+
+			It("should poll refs immediately", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(reconcile.Result{RequeueAfter: interval}))
+			})
+
+			It("should reset refs in status", func() {
+				source := getSource()
+				Expect(source.Status.Branches).To(Equal(source.Spec.Branches))
+				Expect(source.Status.Refs).To(Equal(map[string]string{
+					"refs/heads/foo": hash,
+				}))
+			})
+
+			It("should not trigger refs", func() {
+				list := new(v1beta1.ResourceTemplateList)
+				Expect(reconciler.Client.List(context.Background(), list, client.InNamespace(getKey().Namespace))).To(Succeed())
+				Expect(list.Items).To(BeEmpty())
+			})
+		})
+
+		When("refs are changed", func() {
+			var next string
+
+			BeforeEach(func() {
+				next = repo.Commit("second")
+				repo.SetRef("refs/heads/main", next)
+				repo.SetRef("refs/heads/foo", hash)
+				now = now.Add(interval)
+				result, err = reconcileSource()
+				waitForPoll()
+			})
+
+			It("should requeue after the interval", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(reconcile.Result{RequeueAfter: interval}))
+			})
+
+			It("should trigger created refs", func() {
+				Expect(mgr.WaitForEvent(testenv.EventData{
+					Type:    corev1.EventTypeNormal,
+					Reason:  hookutil.ReasonCreated,
+					Message: "Created resource template: foobar-foo",
+				})).To(BeTrue())
+			})
+
+			It("should trigger updated refs", func() {
+				Expect(mgr.WaitForEvent(testenv.EventData{
+					Type:    corev1.EventTypeNormal,
+					Reason:  hookutil.ReasonCreated,
+					Message: "Created resource template: foobar-main",
+				})).To(BeTrue())
+			})
+
+			It("should update refs in status", func() {
+				source := getSource()
+				Expect(source.Status.LastPollTime.Time).To(BeTemporally("==", now))
+				Expect(source.Status.Refs).To(Equal(map[string]string{
+					"refs/heads/main": next,
+					"refs/heads/foo":  hash,
+				}))
+			})
+
+			When("refs are deleted", func() {
+				BeforeEach(func() {
+					repo.DeleteRef("refs/heads/foo")
+					now = now.Add(interval)
+					result, err = reconcileSource()
+					waitForPoll()
+				})
+
+				It("should delete the resource template", func() {
+					Expect(mgr.WaitForEvent(testenv.EventData{
+						Type:    corev1.EventTypeNormal,
+						Reason:  hookutil.ReasonDeleted,
+						Message: "Deleted resource template: foobar-foo",
+					})).To(BeTrue())
+				})
+
+				It("should remove refs from status", func() {
+					Expect(getSource().Status.Refs).To(Equal(map[string]string{
+						"refs/heads/main": next,
+					}))
+				})
+			})
+		})
+	})
+
+	When("URL is not allowed", func() {
+		BeforeEach(func() {
+			reconciler.validateURL = validateURL
+			createSource("file://" + repo.Path)
+		})
+
+		It("should record PollFailed event", func() {
+			_, err := reconcileSource()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mgr.WaitForEvent(testenv.EventData{
+				Type:    corev1.EventTypeWarning,
+				Reason:  ReasonPollFailed,
+				Message: `unsupported protocol "file"`,
+			})).To(BeTrue())
+		})
+	})
+
+	When("repository does not exist", func() {
+		var (
+			result reconcile.Result
+			err    error
+		)
+
+		BeforeEach(func() {
+			createSource(repo.Path + "-not-exist")
+			result, err = reconcileSource()
+		})
+
+		It("should requeue after the interval", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{RequeueAfter: interval}))
+		})
+
+		It("should record PollFailed event", func() {
+			Expect(mgr.WaitForEvent(WithTransform(func(e testenv.EventData) string {
+				return e.Type + "/" + e.Reason
+			}, Equal(corev1.EventTypeWarning+"/"+ReasonPollFailed)))).To(BeTrue())
+		})
+
+		It("should not update status", func() {
+			Expect(getSource().Status.LastPollTime).To(BeNil())
+		})
+	})
+})
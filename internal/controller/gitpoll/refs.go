@@ -0,0 +1,237 @@
+package gitpoll
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/protocol/packp"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/tommy351/pullup/internal/gitutil"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+)
+
+const listTimeout = time.Minute
+
+// nolint: gochecknoglobals
+var (
+	// Only HTTP(S) is allowed because the dialer of git protocol can not be
+	// replaced to check addresses after host names are resolved.
+	allowedProtocols = map[string]bool{
+		"http":  true,
+		"https": true,
+	}
+
+	// httpTransport checks addresses after host names are resolved, so host
+	// names which are resolved to internal addresses are rejected as well.
+	httpTransport = githttp.NewClient(&http.Client{
+		Timeout: listTimeout,
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout: 30 * time.Second,
+				Control: checkDialAddress,
+			}).DialContext,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+	})
+)
+
+// validateURL returns an error when the remote is not allowed to be polled.
+// Local files and internal hosts, e.g. localhost or the metadata server of
+// the cloud provider, can not be polled.
+func validateURL(url string) error {
+	ep, err := transport.NewEndpoint(url)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+
+	if !allowedProtocols[ep.Protocol] {
+		return fmt.Errorf("unsupported protocol %q", ep.Protocol)
+	}
+
+	if isInternalHost(ep.Host) {
+		return fmt.Errorf("host %q is not allowed", ep.Host)
+	}
+
+	return nil
+}
+
+func isInternalHost(host string) bool {
+	host = strings.ToLower(strings.Trim(host, "[]"))
+
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && isInternalIP(ip)
+}
+
+func isInternalIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsUnspecified() || ip.IsMulticast()
+}
+
+func checkDialAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	if ip := net.ParseIP(host); ip != nil && isInternalIP(ip) {
+		return fmt.Errorf("address %s is not allowed", address)
+	}
+
+	return nil
+}
+
+// listRefs returns commit hashes of branches and tags in the remote.
+func listRefs(ctx context.Context, url string, auth transport.AuthMethod) (map[string]string, error) {
+	ep, err := transport.NewEndpoint(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL: %w", err)
+	}
+
+	var tr transport.Transport
+
+	switch ep.Protocol {
+	case "http", "https":
+		tr = httpTransport
+	case "file":
+		if tr, err = client.NewClient(ep); err != nil {
+			return nil, fmt.Errorf("failed to create git client: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported protocol %q", ep.Protocol)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+
+	type listResult struct {
+		refs *packp.AdvRefs
+		err  error
+	}
+
+	ch := make(chan listResult, 1)
+
+	// Sessions do not support contexts, so the session is abandoned when the
+	// context is done and closed when it returns.
+	go func() {
+		s, err := tr.NewUploadPackSession(ep, auth)
+		if err != nil {
+			ch <- listResult{err: err}
+
+			return
+		}
+
+		defer s.Close()
+
+		refs, err := s.AdvertisedReferences()
+		ch <- listResult{refs: refs, err: err}
+	}()
+
+	var res listResult
+
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("failed to list refs: %w", ctx.Err())
+	case res = <-ch:
+	}
+
+	if res.err != nil {
+		if errors.Is(res.err, transport.ErrEmptyRemoteRepository) {
+			return map[string]string{}, nil
+		}
+
+		return nil, fmt.Errorf("failed to list refs: %w", res.err)
+	}
+
+	refs, err := res.refs.AllReferences()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list refs: %w", err)
+	}
+
+	result := map[string]string{}
+
+	for name, ref := range refs {
+		if ref.Type() != plumbing.HashReference {
+			continue
+		}
+
+		if _, ok := gitutil.ParseRef(name.String()); ok {
+			result[name.String()] = ref.Hash().String()
+		}
+	}
+
+	return result, nil
+}
+
+// filterRefs returns refs which match branch and tag filters. Branches are
+// matched when only tags filter is not set, the same as push event filters
+// of webhooks.
+func filterRefs(refs map[string]string, spec *v1beta1.GitPollSourceSpec) map[string]string {
+	result := map[string]string{}
+
+	for name, hash := range refs {
+		ref, ok := gitutil.ParseRef(name)
+		if !ok {
+			continue
+		}
+
+		switch ref.Type {
+		case gitutil.RefTypeBranch:
+			if spec.Branches == nil && spec.Tags != nil {
+				continue
+			}
+
+			if !hookutil.FilterWebhook(spec.Branches, []string{ref.Name}) {
+				continue
+			}
+
+		case gitutil.RefTypeTag:
+			if spec.Tags == nil || !hookutil.FilterWebhook(spec.Tags, []string{ref.Name}) {
+				continue
+			}
+		}
+
+		result[name] = hash
+	}
+
+	return result
+}
+
+// diffRefs returns push events of refs which are created, updated or deleted.
+// Events are sorted by ref names.
+func diffRefs(url string, prev, next map[string]string) []*PushEvent {
+	var events []*PushEvent
+
+	for name, after := range next {
+		if before := prev[name]; before != after {
+			events = append(events, newPushEvent(url, name, before, after))
+		}
+	}
+
+	for name, before := range prev {
+		if _, ok := next[name]; !ok {
+			events = append(events, newPushEvent(url, name, before, ""))
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Ref < events[j].Ref
+	})
+
+	return events
+}
@@ -0,0 +1,183 @@
+package gitpoll
+
+import (
+	"context"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/tommy351/pullup/internal/gitutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+)
+
+var _ = Describe("listRefs", func() {
+	var repo *testRepo
+
+	BeforeEach(func() {
+		repo = newTestRepo()
+	})
+
+	AfterEach(func() {
+		repo.Remove()
+	})
+
+	When("repository is empty", func() {
+		It("should return an empty map", func() {
+			Expect(listRefs(context.Background(), repo.Path, nil)).To(BeEmpty())
+		})
+	})
+
+	When("repository has branches and tags", func() {
+		var first, second string
+
+		BeforeEach(func() {
+			first = repo.Commit("first")
+			second = repo.Commit("second")
+			repo.SetRef("refs/heads/main", first)
+			repo.SetRef("refs/heads/feat/foo", second)
+			repo.SetRef("refs/tags/v1.0.0", first)
+			repo.SetRef("refs/pull/1/head", second)
+		})
+
+		It("should return branches and tags", func() {
+			Expect(listRefs(context.Background(), repo.Path, nil)).To(Equal(map[string]string{
+				"refs/heads/main":     first,
+				"refs/heads/feat/foo": second,
+				"refs/tags/v1.0.0":    first,
+			}))
+		})
+	})
+
+	When("repository does not exist", func() {
+		It("should return the error", func() {
+			_, err := listRefs(context.Background(), repo.Path+"-not-exist", nil)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	When("protocol is git", func() {
+		It("should return the error without dialing", func() {
+			// localtest.me is resolved to 127.0.0.1.
+			_, err := listRefs(context.Background(), "git://localtest.me/foo.git", nil)
+			Expect(err).To(MatchError(`unsupported protocol "git"`))
+		})
+	})
+})
+
+var _ = DescribeTable("validateURL", func(url string, valid bool) {
+	err := validateURL(url)
+
+	if valid {
+		Expect(err).NotTo(HaveOccurred())
+	} else {
+		Expect(err).To(HaveOccurred())
+	}
+},
+	Entry("https", "https://github.com/foo/bar.git", true),
+	Entry("http", "http://git.example.com/foo/bar.git", true),
+	Entry("ssh", "ssh://git@github.com/foo/bar.git", false),
+	Entry("git", "git://github.com/foo/bar.git", false),
+	Entry("git host name resolving to loopback", "git://localtest.me/foo.git", false),
+	Entry("file", "file:///tmp/foo", false),
+	Entry("local path", "/tmp/foo", false),
+	Entry("localhost", "http://localhost/foo.git", false),
+	Entry("loopback", "https://127.0.0.1/foo.git", false),
+	Entry("IPv6 loopback", "https://[::1]/foo.git", false),
+	Entry("metadata server", "http://169.254.169.254/foo.git", false),
+	Entry("unspecified", "git://0.0.0.0/foo.git", false),
+)
+
+var _ = Describe("filterRefs", func() {
+	refs := map[string]string{
+		"refs/heads/main":    "a",
+		"refs/heads/feat/x":  "b",
+		"refs/tags/v1.0.0":   "c",
+		"refs/tags/v2.0.0-a": "d",
+	}
+
+	It("should return only branches by default", func() {
+		Expect(filterRefs(refs, &v1beta1.GitPollSourceSpec{})).To(Equal(map[string]string{
+			"refs/heads/main":   "a",
+			"refs/heads/feat/x": "b",
+		}))
+	})
+
+	It("should filter branches", func() {
+		Expect(filterRefs(refs, &v1beta1.GitPollSourceSpec{
+			Branches: &v1beta1.EventSourceFilter{Include: []string{"main"}},
+		})).To(Equal(map[string]string{
+			"refs/heads/main": "a",
+		}))
+	})
+
+	It("should return only tags when only tags filter is set", func() {
+		Expect(filterRefs(refs, &v1beta1.GitPollSourceSpec{
+			Tags: &v1beta1.EventSourceFilter{Exclude: []string{"/-a$/"}},
+		})).To(Equal(map[string]string{
+			"refs/tags/v1.0.0": "c",
+		}))
+	})
+
+	It("should return branches and tags when both filters are set", func() {
+		Expect(filterRefs(refs, &v1beta1.GitPollSourceSpec{
+			Branches: &v1beta1.EventSourceFilter{Include: []string{"/^feat\\//"}},
+			Tags:     &v1beta1.EventSourceFilter{},
+		})).To(Equal(map[string]string{
+			"refs/heads/feat/x":  "b",
+			"refs/tags/v1.0.0":   "c",
+			"refs/tags/v2.0.0-a": "d",
+		}))
+	})
+})
+
+var _ = Describe("diffRefs", func() {
+	const url = "https://example.com/foo.git"
+
+	zero := plumbing.ZeroHash.String()
+
+	It("should return created, updated and deleted events", func() {
+		events := diffRefs(url, map[string]string{
+			"refs/heads/a": "1",
+			"refs/heads/b": "2",
+			"refs/heads/c": "3",
+		}, map[string]string{
+			"refs/heads/a": "1",
+			"refs/heads/b": "4",
+			"refs/tags/d":  "5",
+		})
+
+		Expect(events).To(Equal([]*PushEvent{
+			{
+				Ref:        "refs/heads/b",
+				RefType:    gitutil.RefTypeBranch,
+				RefName:    "b",
+				Before:     "2",
+				After:      "4",
+				Repository: PushRepository{URL: url},
+			},
+			{
+				Ref:        "refs/heads/c",
+				RefType:    gitutil.RefTypeBranch,
+				RefName:    "c",
+				Before:     "3",
+				After:      zero,
+				Deleted:    true,
+				Repository: PushRepository{URL: url},
+			},
+			{
+				Ref:        "refs/tags/d",
+				RefType:    gitutil.RefTypeTag,
+				RefName:    "d",
+				Before:     zero,
+				After:      "5",
+				Created:    true,
+				Repository: PushRepository{URL: url},
+			},
+		}))
+	})
+
+	It("should return nothing when refs are not changed", func() {
+		Expect(diffRefs(url, map[string]string{"refs/heads/a": "1"}, map[string]string{"refs/heads/a": "1"})).To(BeEmpty())
+	})
+})
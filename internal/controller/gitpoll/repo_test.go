@@ -0,0 +1,68 @@
+package gitpoll
+
+import (
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	. "github.com/onsi/gomega"
+)
+
+// testRepo is a local bare repository.
+type testRepo struct {
+	*git.Repository
+
+	Path string
+}
+
+func newTestRepo() *testRepo {
+	dir, err := ioutil.TempDir("", "pullup-gitpoll")
+	Expect(err).NotTo(HaveOccurred())
+
+	repo, err := git.PlainInit(dir, true)
+	Expect(err).NotTo(HaveOccurred())
+
+	return &testRepo{Repository: repo, Path: dir}
+}
+
+func (t *testRepo) Remove() {
+	Expect(os.RemoveAll(t.Path)).To(Succeed())
+}
+
+// Commit creates an empty commit and returns its hash.
+func (t *testRepo) Commit(message string) string {
+	treeObj := t.Storer.NewEncodedObject()
+	Expect((&object.Tree{}).Encode(treeObj)).To(Succeed())
+
+	treeHash, err := t.Storer.SetEncodedObject(treeObj)
+	Expect(err).NotTo(HaveOccurred())
+
+	sig := object.Signature{
+		Name:  "Pullup",
+		Email: "pullup@example.com",
+		When:  time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC),
+	}
+	commitObj := t.Storer.NewEncodedObject()
+	Expect((&object.Commit{
+		Author:    sig,
+		Committer: sig,
+		Message:   message,
+		TreeHash:  treeHash,
+	}).Encode(commitObj)).To(Succeed())
+
+	hash, err := t.Storer.SetEncodedObject(commitObj)
+	Expect(err).NotTo(HaveOccurred())
+
+	return hash.String()
+}
+
+func (t *testRepo) SetRef(name, hash string) {
+	Expect(t.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(name), plumbing.NewHash(hash)))).To(Succeed())
+}
+
+func (t *testRepo) DeleteRef(name string) {
+	Expect(t.Storer.RemoveReference(plumbing.ReferenceName(name))).To(Succeed())
+}
@@ -0,0 +1,83 @@
+package kubernetesevent
+
+import (
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	toolscache "k8s.io/client-go/tools/cache"
+)
+
+var _ toolscache.ResourceEventHandler = (*eventHandler)(nil)
+
+// eventHandler converts events of an informer to KubernetesEventSource event
+// types.
+type eventHandler struct {
+	GroupVersionKind schema.GroupVersionKind
+	Handle           func(gvk schema.GroupVersionKind, eventType string, obj *unstructured.Unstructured)
+}
+
+func (e *eventHandler) OnAdd(obj interface{}) {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		e.Handle(e.GroupVersionKind, v1beta1.KubernetesEventAdded, u)
+	}
+}
+
+func (e *eventHandler) OnUpdate(oldObj, newObj interface{}) {
+	oldU, ok := oldObj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+
+	newU, ok := newObj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+
+	if !isModified(oldU, newU) {
+		return
+	}
+
+	e.Handle(e.GroupVersionKind, v1beta1.KubernetesEventModified, newU)
+}
+
+func (e *eventHandler) OnDelete(obj interface{}) {
+	// The object is the last known state when the deletion is missed.
+	if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		e.Handle(e.GroupVersionKind, v1beta1.KubernetesEventDeleted, u)
+	}
+}
+
+// isModified returns true when the object is modified by users. Periodic
+// resyncs send update events of unchanged objects, and status updates do not
+// change the generation. The generation is compared when it is tracked by the
+// kind, otherwise objects are compared without metadata which is changed on
+// every update. Labels are always compared because event sources select
+// objects by labels.
+func isModified(oldObj, newObj *unstructured.Unstructured) bool {
+	if oldObj.GetResourceVersion() == newObj.GetResourceVersion() {
+		return false
+	}
+
+	if !equality.Semantic.DeepEqual(oldObj.GetLabels(), newObj.GetLabels()) {
+		return true
+	}
+
+	if newObj.GetGeneration() != 0 {
+		return oldObj.GetGeneration() != newObj.GetGeneration()
+	}
+
+	return !equality.Semantic.DeepEqual(withoutVolatileMetadata(oldObj), withoutVolatileMetadata(newObj))
+}
+
+func withoutVolatileMetadata(obj *unstructured.Unstructured) map[string]interface{} {
+	obj = obj.DeepCopy()
+	obj.SetResourceVersion("")
+	obj.SetManagedFields(nil)
+
+	return obj.Object
+}
@@ -0,0 +1,48 @@
+package kubernetesevent
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ = Describe("isModified", func() {
+	newObject := func(resourceVersion string, generation int64, labels map[string]string, data map[string]interface{}) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: data}
+		obj.SetResourceVersion(resourceVersion)
+		obj.SetGeneration(generation)
+		obj.SetLabels(labels)
+
+		return obj
+	}
+
+	DescribeTable("objects", func(oldObj, newObj *unstructured.Unstructured, expected bool) {
+		Expect(isModified(oldObj, newObj)).To(Equal(expected))
+	},
+		Entry("resource version is not changed",
+			newObject("1", 0, nil, map[string]interface{}{"data": "a"}),
+			newObject("1", 0, nil, map[string]interface{}{"data": "a"}),
+			false),
+		Entry("generation is changed",
+			newObject("1", 1, nil, map[string]interface{}{"spec": "a"}),
+			newObject("2", 2, nil, map[string]interface{}{"spec": "b"}),
+			true),
+		Entry("generation is not changed",
+			newObject("1", 1, nil, map[string]interface{}{"status": "a"}),
+			newObject("2", 1, nil, map[string]interface{}{"status": "b"}),
+			false),
+		Entry("labels are changed",
+			newObject("1", 1, map[string]string{"app": "a"}, map[string]interface{}{}),
+			newObject("2", 1, map[string]string{"app": "b"}, map[string]interface{}{}),
+			true),
+		Entry("generation is not tracked and data is changed",
+			newObject("1", 0, nil, map[string]interface{}{"data": "a"}),
+			newObject("2", 0, nil, map[string]interface{}{"data": "b"}),
+			true),
+		Entry("generation is not tracked and data is not changed",
+			newObject("1", 0, nil, map[string]interface{}{"data": "a"}),
+			newObject("2", 0, nil, map[string]interface{}{"data": "a"}),
+			false),
+	)
+})
@@ -0,0 +1,329 @@
+package kubernetesevent
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/wire"
+	"github.com/tommy351/pullup/internal/controller"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// +kubebuilder:rbac:groups=pullup.dev,resources=kuberneteseventsources,verbs=get;list;watch
+
+const (
+	ReasonInvalidSource = "InvalidSource"
+	ReasonWatchFailed   = "WatchFailed"
+)
+
+const (
+	// informerTimeout is how long to wait for an informer to be synced. It
+	// never happens when objects of the kind can not be listed, e.g. RBAC
+	// rules are missing.
+	informerTimeout = 30 * time.Second
+
+	maxEventRetries = 5
+)
+
+// ReconcilerConfigSet provides a ReconcilerConfig.
+// nolint: gochecknoglobals
+var ReconcilerConfigSet = wire.NewSet(
+	hookutil.TriggerHandlerSet,
+	wire.Struct(new(ReconcilerConfig), "*"),
+)
+
+// ReconcilerSet provides a Reconciler.
+// nolint: gochecknoglobals
+var ReconcilerSet = wire.NewSet(
+	ReconcilerConfigSet,
+	NewReconciler,
+)
+
+type ReconcilerConfig struct {
+	Client         client.Client
+	Recorder       record.EventRecorder
+	TriggerHandler hookutil.TriggerHandler
+}
+
+// Reconciler keeps watched objects of KubernetesEventSources up to date.
+// Informers are shared by event sources of the same kind, and events of
+// informers are dispatched to matched event sources. Events are handled in a
+// queue, so informers are not blocked and failed events are retried.
+type Reconciler struct {
+	ReconcilerConfig
+
+	cache  cache.Informers
+	logger logr.Logger
+	queue  workqueue.RateLimitingInterface
+
+	mu      sync.RWMutex
+	sources map[types.NamespacedName]*watchedSource
+
+	informerMu sync.Mutex
+	informers  map[schema.GroupVersionKind]bool
+}
+
+func NewReconciler(conf ReconcilerConfig, mgr manager.Manager) *Reconciler {
+	return &Reconciler{
+		ReconcilerConfig: conf,
+		cache:            mgr.GetCache(),
+		logger:           mgr.GetLogger().WithName("kubernetesevent"),
+		queue:            workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "kubernetes-event"),
+		sources:          map[types.NamespacedName]*watchedSource{},
+		informers:        map[schema.GroupVersionKind]bool{},
+	}
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	source := new(v1beta1.KubernetesEventSource)
+
+	if err := r.Client.Get(ctx, req.NamespacedName, source); err != nil {
+		if errors.IsNotFound(err) {
+			r.removeSource(req.NamespacedName)
+
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, fmt.Errorf("failed to get kubernetes event source: %w", err)
+	}
+
+	if source.DeletionTimestamp != nil {
+		r.removeSource(req.NamespacedName)
+
+		return reconcile.Result{}, nil
+	}
+
+	logger := logr.FromContextOrDiscard(ctx).WithValues("kubernetesEventSource", source)
+	ctx = logr.NewContext(ctx, logger)
+
+	ws, err := newWatchedSource(source, r.Client.RESTMapper())
+	if err != nil {
+		r.removeSource(req.NamespacedName)
+
+		result := controller.Result{
+			Error:  err,
+			Reason: ReasonInvalidSource,
+		}
+
+		// The kind may be available later, e.g. CRD is not installed yet.
+		if meta.IsNoMatchError(err) {
+			return r.handleResult(ctx, source, result)
+		}
+
+		_, _ = r.handleResult(ctx, source, result)
+
+		return reconcile.Result{}, nil
+	}
+
+	if err := r.watch(ctx, ws.GroupVersionKind); err != nil {
+		return r.handleResult(ctx, source, controller.Result{
+			Error:  err,
+			Reason: ReasonWatchFailed,
+		})
+	}
+
+	r.setSource(req.NamespacedName, ws)
+
+	return reconcile.Result{}, nil
+}
+
+func (r *Reconciler) handleResult(ctx context.Context, source *v1beta1.KubernetesEventSource, result controller.Result) (reconcile.Result, error) {
+	logger := logr.FromContextOrDiscard(ctx)
+	result.RecordEvent(r.Recorder, source)
+
+	if err := result.Error; err != nil {
+		logger.Error(result.Error, result.GetMessage())
+	} else {
+		logger.Info(result.GetMessage())
+	}
+
+	return reconcile.Result{Requeue: result.Requeue}, result.Error
+}
+
+func (r *Reconciler) setSource(key types.NamespacedName, ws *watchedSource) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Keep the start time, so objects created before the spec is changed are
+	// not considered as added.
+	if prev, ok := r.sources[key]; ok && prev.GroupVersionKind == ws.GroupVersionKind {
+		ws.StartTime = prev.StartTime
+	}
+
+	r.sources[key] = ws
+}
+
+func (r *Reconciler) removeSource(key types.NamespacedName) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.sources, key)
+}
+
+func (r *Reconciler) getSource(key types.NamespacedName) *watchedSource {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.sources[key]
+}
+
+func (r *Reconciler) getSources(gvk schema.GroupVersionKind) []*watchedSource {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*watchedSource
+
+	for _, ws := range r.sources {
+		if ws.GroupVersionKind == gvk {
+			result = append(result, ws)
+		}
+	}
+
+	return result
+}
+
+// watch registers event handlers to the informer of the kind. Informers can
+// not be stopped, so handlers are registered once for each kind and ignore
+// events when no event sources are watching the kind.
+func (r *Reconciler) watch(ctx context.Context, gvk schema.GroupVersionKind) error {
+	if r.isWatching(gvk) {
+		return nil
+	}
+
+	obj := new(unstructured.Unstructured)
+	obj.SetGroupVersionKind(gvk)
+
+	// The lock is not held here, so other kinds can be watched while waiting
+	// for the informer to be synced.
+	ctx, cancel := context.WithTimeout(ctx, informerTimeout)
+	defer cancel()
+
+	informer, err := r.cache.GetInformer(ctx, obj)
+	if err != nil {
+		return fmt.Errorf("failed to get informer of %s: %w", gvk, err)
+	}
+
+	r.informerMu.Lock()
+	defer r.informerMu.Unlock()
+
+	// The kind may be watched by another source in the meantime.
+	if r.informers[gvk] {
+		return nil
+	}
+
+	informer.AddEventHandler(&eventHandler{
+		GroupVersionKind: gvk,
+		Handle:           r.enqueueEvent,
+	})
+
+	r.informers[gvk] = true
+
+	return nil
+}
+
+func (r *Reconciler) isWatching(gvk schema.GroupVersionKind) bool {
+	r.informerMu.Lock()
+	defer r.informerMu.Unlock()
+
+	return r.informers[gvk]
+}
+
+// event is an event of an object which is matched by an event source.
+type event struct {
+	SourceKey types.NamespacedName
+	Type      string
+	Object    *unstructured.Unstructured
+}
+
+// enqueueEvent adds events for event sources which match the object. It is
+// called by informers, so triggers are not handled here.
+func (r *Reconciler) enqueueEvent(gvk schema.GroupVersionKind, eventType string, obj *unstructured.Unstructured) {
+	for _, ws := range r.getSources(gvk) {
+		if !ws.Match(eventType, obj) {
+			continue
+		}
+
+		r.queue.Add(&event{
+			SourceKey: types.NamespacedName{Namespace: ws.Source.Namespace, Name: ws.Source.Name},
+			Type:      eventType,
+			Object:    obj,
+		})
+	}
+}
+
+// Start handles queued events until the context is done. Events are handled
+// by a single worker, so events of the same object are handled in order.
+func (r *Reconciler) Start(ctx context.Context) error {
+	defer r.queue.ShutDown()
+
+	go func() {
+		for r.processNextEvent(ctx) {
+		}
+	}()
+
+	<-ctx.Done()
+
+	return nil
+}
+
+func (r *Reconciler) processNextEvent(ctx context.Context) bool {
+	item, shutdown := r.queue.Get()
+	if shutdown {
+		return false
+	}
+
+	defer r.queue.Done(item)
+
+	e := item.(*event)
+	logger := r.logger.WithValues(
+		"kubernetesEventSource", e.SourceKey,
+		"object", types.NamespacedName{Namespace: e.Object.GetNamespace(), Name: e.Object.GetName()},
+		"eventType", e.Type,
+	)
+
+	if err := r.handleEvent(logr.NewContext(ctx, logger), e); err != nil {
+		if r.queue.NumRequeues(item) < maxEventRetries {
+			logger.Error(err, "Failed to handle the event, retrying")
+			r.queue.AddRateLimited(item)
+
+			return true
+		}
+
+		logger.Error(err, "Failed to handle the event")
+	}
+
+	r.queue.Forget(item)
+
+	return true
+}
+
+func (r *Reconciler) handleEvent(ctx context.Context, e *event) error {
+	// The source may be deleted after the event is queued.
+	ws := r.getSource(e.SourceKey)
+	if ws == nil {
+		return nil
+	}
+
+	return r.TriggerHandler.Handle(ctx, &hookutil.TriggerOptions{
+		Action:        ws.Source.Spec.Action,
+		DefaultAction: ws.GetAction(e.Type),
+		Event:         e.Object.Object,
+		Source:        ws.Source,
+		Triggers:      ws.Source.Spec.Triggers,
+	})
+}
@@ -0,0 +1,247 @@
+package kubernetesevent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tommy351/pullup/internal/k8s"
+	"github.com/tommy351/pullup/internal/random"
+	"github.com/tommy351/pullup/internal/testenv"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Reconciler", func() {
+	var (
+		reconciler   *Reconciler
+		mgr          *testenv.Manager
+		result       reconcile.Result
+		err          error
+		namespaceMap *random.NamespaceMap
+	)
+
+	getChanges := func() []testenv.Change {
+		return testenv.GetChanges(reconciler.Client)
+	}
+
+	loadData := func(name string) {
+		var data []client.Object
+
+		BeforeEach(func() {
+			data, err = k8s.LoadObjects(testenv.GetScheme(), fmt.Sprintf("testdata/%s.yml", name))
+			Expect(err).NotTo(HaveOccurred())
+
+			data, err = k8s.MapObjects(data, namespaceMap.SetObject)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(testenv.CreateObjects(data)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(testenv.DeleteObjects(data)).To(Succeed())
+		})
+	}
+
+	waitForEvent := func(expected testenv.EventData) {
+		Eventually(func() bool {
+			return mgr.WaitForEvent(expected)
+		}).Should(BeTrue())
+	}
+
+	newConfigMap := func(name string, labels map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespaceMap.GetRandom("test"),
+				Name:      name,
+				Labels:    labels,
+			},
+			Data: map[string]string{
+				"foo": "bar",
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		mgr, err = testenv.NewManager()
+		Expect(err).NotTo(HaveOccurred())
+
+		reconciler = NewReconciler(NewReconcilerConfig(mgr), mgr)
+		Expect(mgr.Add(reconciler)).To(Succeed())
+		Expect(mgr.Initialize()).To(Succeed())
+
+		namespaceMap = random.NewNamespaceMap()
+	})
+
+	JustBeforeEach(func() {
+		result, err = reconciler.Reconcile(context.TODO(), reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      "foobar",
+				Namespace: namespaceMap.GetRandom("test"),
+			},
+		})
+	})
+
+	AfterEach(func() {
+		mgr.Stop()
+	})
+
+	When("source does not exist", func() {
+		It("should not requeue", func() {
+			Expect(result).To(Equal(reconcile.Result{}))
+		})
+
+		It("should not return the error", func() {
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should not change anything", func() {
+			Expect(getChanges()).To(BeEmpty())
+		})
+	})
+
+	When("source is valid", func() {
+		loadData("source")
+
+		It("should not requeue", func() {
+			Expect(result).To(Equal(reconcile.Result{}))
+		})
+
+		It("should not return the error", func() {
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should register the source", func() {
+			Expect(reconciler.getSources(corev1.SchemeGroupVersion.WithKind("ConfigMap"))).To(HaveLen(1))
+		})
+
+		It("should trigger when a matched object is added", func() {
+			cm := newConfigMap("foo", map[string]string{"app": "foo"})
+			Expect(reconciler.Client.Create(context.Background(), cm)).To(Succeed())
+
+			waitForEvent(testenv.EventData{
+				Type:    corev1.EventTypeNormal,
+				Reason:  hookutil.ReasonCreated,
+				Message: "Created resource template: foobar-foo",
+			})
+
+			rt := new(v1beta1.ResourceTemplate)
+			Expect(reconciler.Client.Get(context.Background(), types.NamespacedName{
+				Namespace: cm.Namespace,
+				Name:      "foobar-foo",
+			}, rt)).To(Succeed())
+
+			var data struct {
+				Event corev1.ConfigMap `json:"event"`
+			}
+
+			Expect(json.Unmarshal(rt.Spec.Data.Raw, &data)).To(Succeed())
+			Expect(data.Event.Kind).To(Equal("ConfigMap"))
+			Expect(data.Event.Name).To(Equal("foo"))
+			Expect(data.Event.Data).To(Equal(map[string]string{"foo": "bar"}))
+		})
+
+		It("should trigger when a matched object is modified", func() {
+			cm := newConfigMap("foo", map[string]string{"app": "foo"})
+			Expect(reconciler.Client.Create(context.Background(), cm)).To(Succeed())
+
+			cm.Data["foo"] = "baz"
+			Expect(reconciler.Client.Update(context.Background(), cm)).To(Succeed())
+
+			waitForEvent(testenv.EventData{
+				Type:    corev1.EventTypeNormal,
+				Reason:  hookutil.ReasonUpdated,
+				Message: "Updated resource template: foobar-foo",
+			})
+		})
+
+		It("should delete the resource template when the object is deleted", func() {
+			cm := newConfigMap("foo", map[string]string{"app": "foo"})
+			Expect(reconciler.Client.Create(context.Background(), cm)).To(Succeed())
+
+			waitForEvent(testenv.EventData{
+				Type:    corev1.EventTypeNormal,
+				Reason:  hookutil.ReasonCreated,
+				Message: "Created resource template: foobar-foo",
+			})
+
+			Expect(reconciler.Client.Delete(context.Background(), cm)).To(Succeed())
+
+			waitForEvent(testenv.EventData{
+				Type:    corev1.EventTypeNormal,
+				Reason:  hookutil.ReasonDeleted,
+				Message: "Deleted resource template: foobar-foo",
+			})
+		})
+
+		It("should not trigger when labels do not match", func() {
+			cm := newConfigMap("bar", map[string]string{"app": "bar"})
+			Expect(reconciler.Client.Create(context.Background(), cm)).To(Succeed())
+
+			Consistently(func() bool {
+				return mgr.WaitForEvent(testenv.EventData{
+					Type:    corev1.EventTypeNormal,
+					Reason:  hookutil.ReasonCreated,
+					Message: "Created resource template: foobar-bar",
+				})
+			}).Should(BeFalse())
+		})
+
+		It("should unregister the source when it is deleted", func() {
+			key := types.NamespacedName{
+				Namespace: namespaceMap.GetRandom("test"),
+				Name:      "foobar",
+			}
+
+			Expect(reconciler.Client.Delete(context.Background(), &v1beta1.KubernetesEventSource{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: key.Namespace,
+					Name:      key.Name,
+				},
+			})).To(Succeed())
+
+			_, err := reconciler.Reconcile(context.Background(), reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reconciler.getSources(corev1.SchemeGroupVersion.WithKind("ConfigMap"))).To(BeEmpty())
+		})
+	})
+
+	When("selector is invalid", func() {
+		loadData("invalid-selector")
+
+		It("should not requeue", func() {
+			Expect(result).To(Equal(reconcile.Result{}))
+		})
+
+		It("should not return the error", func() {
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should record InvalidSource event", func() {
+			Expect(mgr.WaitForEvent(testenv.EventData{
+				Type:    corev1.EventTypeWarning,
+				Reason:  ReasonInvalidSource,
+				Message: "invalid field selector: invalid selector: 'metadata.name in (foo)'; can't understand 'metadata.name in (foo)'",
+			})).To(BeTrue())
+		})
+	})
+
+	When("kind does not exist", func() {
+		loadData("unknown-kind")
+
+		It("should return the error", func() {
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should not register the source", func() {
+			Expect(reconciler.getSources(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Unknown"})).To(BeEmpty())
+		})
+	})
+})
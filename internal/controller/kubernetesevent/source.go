@@ -0,0 +1,150 @@
+package kubernetesevent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// nolint: gochecknoglobals
+var defaultEventTypes = []v1beta1.KubernetesEventType{
+	{Type: v1beta1.KubernetesEventAdded},
+	{Type: v1beta1.KubernetesEventModified},
+	{Type: v1beta1.KubernetesEventDeleted},
+}
+
+type watchedSource struct {
+	schema.GroupVersionKind
+
+	Source        *v1beta1.KubernetesEventSource
+	Namespaced    bool
+	LabelSelector labels.Selector
+	FieldSelector fields.Selector
+
+	// StartTime is the time when the event source is started. Added events
+	// of objects created before it are ignored, because informers send added
+	// events of all existing objects when they are started.
+	StartTime time.Time
+}
+
+func newWatchedSource(source *v1beta1.KubernetesEventSource, mapper meta.RESTMapper) (*watchedSource, error) {
+	gv, err := schema.ParseGroupVersion(source.Spec.APIVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid apiVersion: %w", err)
+	}
+
+	gvk := gv.WithKind(source.Spec.Kind)
+
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get REST mapping of %s: %w", gvk, err)
+	}
+
+	labelSelector := labels.Everything()
+
+	if source.Spec.LabelSelector != nil {
+		if labelSelector, err = metav1.LabelSelectorAsSelector(source.Spec.LabelSelector); err != nil {
+			return nil, fmt.Errorf("invalid label selector: %w", err)
+		}
+	}
+
+	fieldSelector, err := fields.ParseSelector(source.Spec.FieldSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid field selector: %w", err)
+	}
+
+	return &watchedSource{
+		GroupVersionKind: gvk,
+		Source:           source,
+		Namespaced:       mapping.Scope.Name() == meta.RESTScopeNameNamespace,
+		LabelSelector:    labelSelector,
+		FieldSelector:    fieldSelector,
+		StartTime:        time.Now(),
+	}, nil
+}
+
+func (w *watchedSource) Match(eventType string, obj *unstructured.Unstructured) bool {
+	if w.Namespaced && obj.GetNamespace() != w.Source.Namespace {
+		return false
+	}
+
+	if w.getEventType(eventType) == nil {
+		return false
+	}
+
+	if eventType == v1beta1.KubernetesEventAdded && obj.GetCreationTimestamp().Time.Before(w.StartTime.Truncate(time.Second)) {
+		return false
+	}
+
+	if !w.LabelSelector.Matches(labels.Set(obj.GetLabels())) {
+		return false
+	}
+
+	return matchFields(w.FieldSelector, obj)
+}
+
+func (w *watchedSource) GetAction(eventType string) string {
+	if t := w.getEventType(eventType); t != nil && t.Action != "" {
+		return t.Action
+	}
+
+	if eventType == v1beta1.KubernetesEventDeleted {
+		return v1beta1.ActionDelete
+	}
+
+	return v1beta1.ActionApply
+}
+
+func (w *watchedSource) getEventType(eventType string) *v1beta1.KubernetesEventType {
+	eventTypes := w.Source.Spec.EventTypes
+
+	if len(eventTypes) == 0 {
+		eventTypes = defaultEventTypes
+	}
+
+	for i, t := range eventTypes {
+		if t.Type == eventType {
+			return &eventTypes[i]
+		}
+	}
+
+	return nil
+}
+
+// matchFields matches field selectors against any fields of the object, e.g.
+// "metadata.name" or "status.phase". Missing fields are considered as empty
+// strings.
+func matchFields(selector fields.Selector, obj *unstructured.Unstructured) bool {
+	for _, req := range selector.Requirements() {
+		value, _, _ := unstructured.NestedFieldNoCopy(obj.Object, strings.Split(req.Field, ".")...)
+		str := ""
+
+		if value != nil {
+			str = fmt.Sprint(value)
+		}
+
+		switch req.Operator {
+		case selection.Equals, selection.DoubleEquals:
+			if str != req.Value {
+				return false
+			}
+		case selection.NotEquals:
+			if str == req.Value {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,130 @@
+package kubernetesevent
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+var _ = Describe("watchedSource", func() {
+	var (
+		ws  *watchedSource
+		obj *unstructured.Unstructured
+	)
+
+	startTime := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	BeforeEach(func() {
+		ws = &watchedSource{
+			Source: &v1beta1.KubernetesEventSource{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test",
+					Name:      "foobar",
+				},
+			},
+			Namespaced:    true,
+			LabelSelector: labels.Everything(),
+			FieldSelector: fields.Everything(),
+			StartTime:     startTime,
+		}
+
+		obj = &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Pod",
+				"metadata": map[string]interface{}{
+					"namespace":         "test",
+					"name":              "foo",
+					"creationTimestamp": startTime.Add(time.Minute).Format(time.RFC3339),
+					"labels": map[string]interface{}{
+						"app": "foo",
+					},
+				},
+				"status": map[string]interface{}{
+					"phase": "Running",
+				},
+			},
+		}
+	})
+
+	Describe("Match", func() {
+		It("should match all event types by default", func() {
+			Expect(ws.Match(v1beta1.KubernetesEventAdded, obj)).To(BeTrue())
+			Expect(ws.Match(v1beta1.KubernetesEventModified, obj)).To(BeTrue())
+			Expect(ws.Match(v1beta1.KubernetesEventDeleted, obj)).To(BeTrue())
+		})
+
+		It("should not match objects in other namespaces", func() {
+			obj.SetNamespace("other")
+			Expect(ws.Match(v1beta1.KubernetesEventModified, obj)).To(BeFalse())
+		})
+
+		It("should match cluster-scoped objects", func() {
+			ws.Namespaced = false
+			obj.SetNamespace("")
+			Expect(ws.Match(v1beta1.KubernetesEventModified, obj)).To(BeTrue())
+		})
+
+		It("should not match event types which are not set", func() {
+			ws.Source.Spec.EventTypes = []v1beta1.KubernetesEventType{
+				{Type: v1beta1.KubernetesEventAdded},
+			}
+			Expect(ws.Match(v1beta1.KubernetesEventAdded, obj)).To(BeTrue())
+			Expect(ws.Match(v1beta1.KubernetesEventModified, obj)).To(BeFalse())
+		})
+
+		It("should not match added events of objects created before the start time", func() {
+			obj.SetCreationTimestamp(metav1.NewTime(startTime.Add(-time.Minute)))
+			Expect(ws.Match(v1beta1.KubernetesEventAdded, obj)).To(BeFalse())
+			Expect(ws.Match(v1beta1.KubernetesEventModified, obj)).To(BeTrue())
+		})
+
+		It("should filter by the label selector", func() {
+			ws.LabelSelector = labels.SelectorFromSet(labels.Set{"app": "bar"})
+			Expect(ws.Match(v1beta1.KubernetesEventModified, obj)).To(BeFalse())
+
+			ws.LabelSelector = labels.SelectorFromSet(labels.Set{"app": "foo"})
+			Expect(ws.Match(v1beta1.KubernetesEventModified, obj)).To(BeTrue())
+		})
+
+		DescribeTable("field selector", func(selector string, expected bool) {
+			ws.FieldSelector = fields.ParseSelectorOrDie(selector)
+			Expect(ws.Match(v1beta1.KubernetesEventModified, obj)).To(Equal(expected))
+		},
+			Entry("equals", "metadata.name=foo", true),
+			Entry("double equals", "metadata.name==foo", true),
+			Entry("not equals", "metadata.name!=foo", false),
+			Entry("nested field", "status.phase=Running", true),
+			Entry("mismatch", "status.phase=Pending", false),
+			Entry("missing field", "spec.nodeName=", true),
+			Entry("multiple requirements", "metadata.name=foo,status.phase=Running", true),
+		)
+	})
+
+	Describe("GetAction", func() {
+		It("should return apply by default", func() {
+			Expect(ws.GetAction(v1beta1.KubernetesEventAdded)).To(Equal(v1beta1.ActionApply))
+			Expect(ws.GetAction(v1beta1.KubernetesEventModified)).To(Equal(v1beta1.ActionApply))
+		})
+
+		It("should return delete for deleted events by default", func() {
+			Expect(ws.GetAction(v1beta1.KubernetesEventDeleted)).To(Equal(v1beta1.ActionDelete))
+		})
+
+		It("should return the action of the event type", func() {
+			ws.Source.Spec.EventTypes = []v1beta1.KubernetesEventType{
+				{Type: v1beta1.KubernetesEventAdded, Action: v1beta1.ActionCreate},
+				{Type: v1beta1.KubernetesEventDeleted},
+			}
+			Expect(ws.GetAction(v1beta1.KubernetesEventAdded)).To(Equal(v1beta1.ActionCreate))
+			Expect(ws.GetAction(v1beta1.KubernetesEventDeleted)).To(Equal(v1beta1.ActionDelete))
+		})
+	})
+})
@@ -0,0 +1,268 @@
+package resourcetemplate
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v32/github"
+	"github.com/tommy351/pullup/internal/controller"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const commentPageSize = 100
+
+type commentTarget struct {
+	Owner  string
+	Repo   string
+	Number int
+}
+
+func getCommentTarget(rt *v1beta1.ResourceTemplate) *commentTarget {
+	annotations := rt.GetAnnotations()
+	number, err := strconv.Atoi(annotations[v1beta1.AnnotationGitHubPullRequest])
+	parts := strings.SplitN(annotations[v1beta1.AnnotationGitHubRepository], "/", 2)
+
+	if err != nil || number <= 0 || len(parts) != 2 {
+		return nil
+	}
+
+	return &commentTarget{
+		Owner:  parts[0],
+		Repo:   parts[1],
+		Number: number,
+	}
+}
+
+// getCommentMarker returns a hidden marker which is used to find the comment
+// of a resource template.
+func getCommentMarker(rt *v1beta1.ResourceTemplate) string {
+	return fmt.Sprintf("<!-- pullup:%s/%s -->", rt.Namespace, rt.Name)
+}
+
+func renderComment(rt *v1beta1.ResourceTemplate, env environment, results []controller.Result) string {
+	var (
+		b        strings.Builder
+		failures []controller.Result
+	)
+
+	for _, result := range results {
+		if result.GetEventType() == corev1.EventTypeWarning {
+			failures = append(failures, result)
+		}
+	}
+
+	fmt.Fprintln(&b, getCommentMarker(rt))
+	fmt.Fprintf(&b, "### Pullup: `%s`\n\n", rt.Name)
+
+	switch {
+	case rt.DeletionTimestamp != nil:
+		fmt.Fprintln(&b, "**Status:** Torn down")
+	case len(failures) > 0:
+		fmt.Fprintln(&b, "**Status:** Failed")
+	default:
+		fmt.Fprintln(&b, "**Status:** Active")
+	}
+
+	if env.URL != "" && rt.DeletionTimestamp == nil {
+		fmt.Fprintf(&b, "**Preview:** %s\n", env.URL)
+	}
+
+	if t := getCommentUpdateTime(rt); !t.IsZero() {
+		fmt.Fprintf(&b, "**Last update:** %s\n", t.UTC().Format(time.RFC3339))
+	}
+
+	if rt.DeletionTimestamp != nil {
+		return b.String()
+	}
+
+	if len(rt.Status.Active) > 0 {
+		fmt.Fprint(&b, "\n#### Resources\n\n")
+
+		for _, ref := range rt.Status.Active {
+			fmt.Fprintf(&b, "- `%s/%s %s`\n", ref.APIVersion, ref.Kind, ref.Name)
+		}
+	}
+
+	if len(failures) > 0 {
+		fmt.Fprint(&b, "\n#### Failures\n\n")
+
+		for _, result := range failures {
+			fmt.Fprintf(&b, "- **%s**: %s\n", result.Reason, result.GetMessage())
+		}
+	}
+
+	return b.String()
+}
+
+func getCommentUpdateTime(rt *v1beta1.ResourceTemplate) time.Time {
+	if t := rt.DeletionTimestamp; t != nil {
+		return t.Time
+	}
+
+	if t := rt.Status.LastUpdateTime; t != nil {
+		return t.Time
+	}
+
+	return time.Time{}
+}
+
+// updateComment creates or edits the pull request comment of the resource
+// template.
+func (r *Reconciler) updateComment(ctx context.Context, rt *v1beta1.ResourceTemplate, env environment, results []controller.Result) error {
+	target := getCommentTarget(rt)
+
+	if r.GitHub == nil || target == nil {
+		return nil
+	}
+
+	gh, err := r.GitHub.RepositoryClient(ctx, target.Owner, target.Repo)
+	if err != nil {
+		return err
+	}
+
+	body := renderComment(rt, env, results)
+
+	id, err := r.editComment(ctx, gh, rt, target, body)
+	if err != nil {
+		return err
+	}
+
+	if id == 0 {
+		comment, _, err := gh.Issues.CreateComment(ctx, target.Owner, target.Repo, target.Number, &github.IssueComment{
+			Body: github.String(body),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create pull request comment: %w", err)
+		}
+
+		id = comment.GetID()
+	}
+
+	if err := r.addFinalizer(ctx, rt, v1beta1.FinalizerGitHubComment); err != nil {
+		return err
+	}
+
+	if status := rt.Status.GitHubComment; status != nil && status.ID == id {
+		return nil
+	}
+
+	rt.Status.GitHubComment = &v1beta1.GitHubCommentStatus{ID: id}
+
+	if err := r.Client.Status().Update(ctx, rt); err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+
+	return nil
+}
+
+// finalizeComment marks the pull request comment as torn down and removes the
+// finalizer.
+func (r *Reconciler) finalizeComment(ctx context.Context, rt *v1beta1.ResourceTemplate) controller.Result {
+	var result controller.Result
+
+	if err := r.tearDownComment(ctx, rt); err != nil {
+		result = controller.Result{
+			Error:  err,
+			Reason: ReasonCommentFailed,
+		}
+	} else {
+		result = controller.Result{
+			Message: fmt.Sprintf("Updated pull request comment: %s", rt.Name),
+			Reason:  ReasonCommentUpdated,
+		}
+	}
+
+	if err := r.removeFinalizer(ctx, rt, v1beta1.FinalizerGitHubComment); err != nil {
+		return controller.Result{
+			Error:   err,
+			Reason:  ReasonFailed,
+			Requeue: true,
+		}
+	}
+
+	return result
+}
+
+func (r *Reconciler) tearDownComment(ctx context.Context, rt *v1beta1.ResourceTemplate) error {
+	target := getCommentTarget(rt)
+
+	if r.GitHub == nil || target == nil {
+		return nil
+	}
+
+	gh, err := r.GitHub.RepositoryClient(ctx, target.Owner, target.Repo)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.editComment(ctx, gh, rt, target, renderComment(rt, environment{}, nil))
+
+	return err
+}
+
+// editComment edits the comment of the resource template. The comment is found
+// by ID in the status first, and then by the hidden marker. It returns 0 when
+// the comment does not exist.
+func (r *Reconciler) editComment(ctx context.Context, gh *github.Client, rt *v1beta1.ResourceTemplate, target *commentTarget, body string) (int64, error) {
+	input := &github.IssueComment{Body: github.String(body)}
+
+	if status := rt.Status.GitHubComment; status != nil {
+		_, res, err := gh.Issues.EditComment(ctx, target.Owner, target.Repo, status.ID, input)
+		if err == nil {
+			return status.ID, nil
+		}
+
+		if res == nil || res.StatusCode != http.StatusNotFound {
+			return 0, fmt.Errorf("failed to edit pull request comment: %w", err)
+		}
+	}
+
+	login, err := r.GitHub.BotLogin(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	comment, err := findComment(ctx, gh, target, login, getCommentMarker(rt))
+	if err != nil || comment == nil {
+		return 0, err
+	}
+
+	if _, _, err := gh.Issues.EditComment(ctx, target.Owner, target.Repo, comment.GetID(), input); err != nil {
+		return 0, fmt.Errorf("failed to edit pull request comment: %w", err)
+	}
+
+	return comment.GetID(), nil
+}
+
+// findComment returns the comment which is created by the App and starts with
+// the marker. Comments of other users are ignored even if they contain the
+// marker.
+func findComment(ctx context.Context, gh *github.Client, target *commentTarget, login, marker string) (*github.IssueComment, error) {
+	opts := &github.IssueListCommentsOptions{
+		ListOptions: github.ListOptions{PerPage: commentPageSize},
+	}
+
+	for {
+		comments, res, err := gh.Issues.ListComments(ctx, target.Owner, target.Repo, target.Number, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pull request comments: %w", err)
+		}
+
+		for _, comment := range comments {
+			if comment.GetUser().GetLogin() == login && strings.HasPrefix(comment.GetBody(), marker) {
+				return comment, nil
+			}
+		}
+
+		if res.NextPage == 0 {
+			return nil, nil
+		}
+
+		opts.Page = res.NextPage
+	}
+}
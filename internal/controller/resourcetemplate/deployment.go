@@ -0,0 +1,288 @@
+package resourcetemplate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v32/github"
+	"github.com/tommy351/pullup/internal/controller"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	deploymentTask     = "deploy"
+	deploymentPageSize = 100
+
+	deploymentStateInProgress = "in_progress"
+	deploymentStateSuccess    = "success"
+	deploymentStateFailure    = "failure"
+	deploymentStateInactive   = "inactive"
+)
+
+type deploymentTarget struct {
+	Owner string
+	Repo  string
+	SHA   string
+}
+
+func getDeploymentTarget(rt *v1beta1.ResourceTemplate) *deploymentTarget {
+	annotations := rt.GetAnnotations()
+	sha := annotations[v1beta1.AnnotationGitHubSHA]
+	parts := strings.SplitN(annotations[v1beta1.AnnotationGitHubRepository], "/", 2)
+
+	if sha == "" || len(parts) != 2 {
+		return nil
+	}
+
+	return &deploymentTarget{
+		Owner: parts[0],
+		Repo:  parts[1],
+		SHA:   sha,
+	}
+}
+
+func getDeploymentState(results []controller.Result) string {
+	for _, result := range results {
+		if result.GetEventType() == corev1.EventTypeWarning {
+			return deploymentStateFailure
+		}
+	}
+
+	return deploymentStateSuccess
+}
+
+// startDeployment creates a GitHub deployment when the commit SHA of the
+// resource template is changed.
+func (r *Reconciler) startDeployment(ctx context.Context, rt *v1beta1.ResourceTemplate, env environment) error {
+	target := getDeploymentTarget(rt)
+
+	if r.GitHub == nil || target == nil {
+		return nil
+	}
+
+	if status := rt.Status.GitHubDeployment; status != nil && status.SHA == target.SHA {
+		return nil
+	}
+
+	gh, err := r.GitHub.RepositoryClient(ctx, target.Owner, target.Repo)
+	if err != nil {
+		return err
+	}
+
+	// The finalizer is added before the deployment is created, so the
+	// deployment is always deactivated when the resource template is deleted.
+	if err := r.addFinalizer(ctx, rt, v1beta1.FinalizerGitHubDeployment); err != nil {
+		return err
+	}
+
+	payload := newDeploymentPayload(rt)
+
+	// The deployment may have been created in a previous reconciliation which
+	// failed to update the status.
+	deployment, err := findDeployment(ctx, gh, target, env.Name, payload)
+	if err != nil {
+		return err
+	}
+
+	if deployment == nil {
+		deployment, _, err = gh.Repositories.CreateDeployment(ctx, target.Owner, target.Repo, &github.DeploymentRequest{
+			Ref:                  github.String(target.SHA),
+			Task:                 github.String(deploymentTask),
+			AutoMerge:            github.Bool(false),
+			RequiredContexts:     &[]string{},
+			Payload:              payload,
+			Environment:          github.String(env.Name),
+			Description:          github.String(fmt.Sprintf("Deployed by pullup: %s", rt.Name)),
+			TransientEnvironment: github.Bool(true),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create GitHub deployment: %w", err)
+		}
+	}
+
+	rt.Status.GitHubDeployment = &v1beta1.GitHubDeploymentStatus{
+		ID:          deployment.GetID(),
+		SHA:         target.SHA,
+		Environment: env.Name,
+	}
+
+	return r.updateDeployment(ctx, rt, env, deploymentStateInProgress)
+}
+
+// deploymentPayload is the payload of GitHub deployments, which is used to find
+// deployments of a resource template.
+type deploymentPayload struct {
+	ResourceTemplate string `json:"pullupResourceTemplate"`
+}
+
+func newDeploymentPayload(rt *v1beta1.ResourceTemplate) *deploymentPayload {
+	return &deploymentPayload{
+		ResourceTemplate: rt.Namespace + "/" + rt.Name,
+	}
+}
+
+// findDeployment returns the deployment of the commit and the environment with
+// the payload. It returns nil when the deployment does not exist.
+func findDeployment(ctx context.Context, gh *github.Client, target *deploymentTarget, env string, payload *deploymentPayload) (*github.Deployment, error) {
+	opts := &github.DeploymentsListOptions{
+		SHA:         target.SHA,
+		Task:        deploymentTask,
+		Environment: env,
+		ListOptions: github.ListOptions{PerPage: deploymentPageSize},
+	}
+
+	for {
+		deployments, res, err := gh.Repositories.ListDeployments(ctx, target.Owner, target.Repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list GitHub deployments: %w", err)
+		}
+
+		for _, deployment := range deployments {
+			var p deploymentPayload
+
+			if err := json.Unmarshal(deployment.Payload, &p); err == nil && p == *payload {
+				return deployment, nil
+			}
+		}
+
+		if res.NextPage == 0 {
+			return nil, nil
+		}
+
+		opts.Page = res.NextPage
+	}
+}
+
+// updateDeployment creates a deployment status when the state or the
+// environment URL is changed.
+func (r *Reconciler) updateDeployment(ctx context.Context, rt *v1beta1.ResourceTemplate, env environment, state string) error {
+	target := getDeploymentTarget(rt)
+	status := rt.Status.GitHubDeployment
+
+	if r.GitHub == nil || target == nil || status == nil {
+		return nil
+	}
+
+	if status.State == state && status.EnvironmentURL == env.URL {
+		return nil
+	}
+
+	gh, err := r.GitHub.RepositoryClient(ctx, target.Owner, target.Repo)
+	if err != nil {
+		return err
+	}
+
+	input := &github.DeploymentStatusRequest{
+		State:        github.String(state),
+		Environment:  github.String(status.Environment),
+		AutoInactive: github.Bool(true),
+	}
+
+	if env.URL != "" {
+		input.EnvironmentURL = github.String(env.URL)
+	}
+
+	if err := createDeploymentStatus(ctx, gh, target, status.ID, input); err != nil {
+		return err
+	}
+
+	status.State = state
+	status.EnvironmentURL = env.URL
+
+	if err := r.Client.Status().Update(ctx, rt); err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+
+	return nil
+}
+
+// finalizeDeployment marks the GitHub deployment as inactive and removes the
+// finalizer. The finalizer is always removed so a resource template can be
+// deleted even if GitHub is not available.
+func (r *Reconciler) finalizeDeployment(ctx context.Context, rt *v1beta1.ResourceTemplate) controller.Result {
+	var result controller.Result
+
+	if err := r.deactivateDeployment(ctx, rt); err != nil {
+		result = controller.Result{
+			Error:  err,
+			Reason: ReasonDeploymentFailed,
+		}
+	} else {
+		result = controller.Result{
+			Message: fmt.Sprintf("Deactivated GitHub deployment: %s", rt.Name),
+			Reason:  ReasonDeploymentUpdated,
+		}
+	}
+
+	if err := r.removeFinalizer(ctx, rt, v1beta1.FinalizerGitHubDeployment); err != nil {
+		return controller.Result{
+			Error:   err,
+			Reason:  ReasonFailed,
+			Requeue: true,
+		}
+	}
+
+	return result
+}
+
+func (r *Reconciler) deactivateDeployment(ctx context.Context, rt *v1beta1.ResourceTemplate) error {
+	target := getDeploymentTarget(rt)
+	status := rt.Status.GitHubDeployment
+
+	if r.GitHub == nil || target == nil || status == nil {
+		return nil
+	}
+
+	gh, err := r.GitHub.RepositoryClient(ctx, target.Owner, target.Repo)
+	if err != nil {
+		return err
+	}
+
+	return createDeploymentStatus(ctx, gh, target, status.ID, &github.DeploymentStatusRequest{
+		State:       github.String(deploymentStateInactive),
+		Environment: github.String(status.Environment),
+	})
+}
+
+func createDeploymentStatus(ctx context.Context, gh *github.Client, target *deploymentTarget, id int64, input *github.DeploymentStatusRequest) error {
+	if _, _, err := gh.Repositories.CreateDeploymentStatus(ctx, target.Owner, target.Repo, id, input); err != nil {
+		return fmt.Errorf("failed to create GitHub deployment status: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) addFinalizer(ctx context.Context, rt *v1beta1.ResourceTemplate, finalizer string) error {
+	if controllerutil.ContainsFinalizer(rt, finalizer) {
+		return nil
+	}
+
+	patch := client.MergeFrom(rt.DeepCopy())
+	controllerutil.AddFinalizer(rt, finalizer)
+
+	if err := r.Client.Patch(ctx, rt, patch); err != nil {
+		return fmt.Errorf("failed to add finalizer: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) removeFinalizer(ctx context.Context, rt *v1beta1.ResourceTemplate, finalizer string) error {
+	if !controllerutil.ContainsFinalizer(rt, finalizer) {
+		return nil
+	}
+
+	patch := client.MergeFrom(rt.DeepCopy())
+	controllerutil.RemoveFinalizer(rt, finalizer)
+
+	if err := r.Client.Patch(ctx, rt, patch); err != nil {
+		return fmt.Errorf("failed to remove finalizer: %w", err)
+	}
+
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/google/wire"
 	"github.com/tommy351/pullup/internal/controller"
+	"github.com/tommy351/pullup/internal/githubapp"
 	"github.com/tommy351/pullup/internal/k8s"
 	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
 	corev1 "k8s.io/api/core/v1"
@@ -24,7 +25,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
-// +kubebuilder:rbac:groups=pullup.dev,resources=resourcetemplates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=pullup.dev,resources=resourcetemplates,verbs=get;list;watch;patch
 // +kubebuilder:rbac:groups=pullup.dev,resources=resourcetemplates/status,verbs=get;update;patch
 
 const (
@@ -38,6 +39,11 @@ const (
 	ReasonInvalidPatch   = "InvalidPatch"
 	ReasonResourceExists = "ResourceExists"
 	ReasonUnchanged      = "Unchanged"
+
+	ReasonDeploymentUpdated = "DeploymentUpdated"
+	ReasonDeploymentFailed  = "DeploymentFailed"
+	ReasonCommentUpdated    = "CommentUpdated"
+	ReasonCommentFailed     = "CommentFailed"
 )
 
 // ReconcilerSet provides a reconciler.
@@ -50,6 +56,7 @@ type Reconciler struct {
 	Client    client.Client
 	Recorder  record.EventRecorder
 	APIReader client.Reader
+	GitHub    *githubapp.Client
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
@@ -82,22 +89,45 @@ func (r *Reconciler) handleResult(ctx context.Context, rt *v1beta1.ResourceTempl
 }
 
 func (r *Reconciler) handleResourceTemplate(ctx context.Context, rt *v1beta1.ResourceTemplate) (reconcile.Result, error) {
-	patches, err := r.renderTriggerPatches(ctx, rt)
+	if rt.DeletionTimestamp != nil {
+		return r.finalizeResourceTemplate(ctx, rt)
+	}
+
+	rendered, err := r.renderTemplate(ctx, rt)
 	if err != nil {
-		return r.handleResult(ctx, rt, controller.Result{
+		result := controller.Result{
 			Error:  err,
 			Reason: ReasonInvalidPatch,
+		}
+
+		r.report(ctx, rt, environment{Name: rt.Name}, []controller.Result{result})
+
+		return r.handleResult(ctx, rt, result)
+	}
+
+	// Resources are still applied when GitHub is not available.
+	deploymentStarted := true
+
+	if err := r.startDeployment(ctx, rt, rendered.Environment); err != nil {
+		deploymentStarted = false
+		_, _ = r.handleResult(ctx, rt, controller.Result{
+			Error:  err,
+			Reason: ReasonDeploymentFailed,
 		})
 	}
 
-	activity := getResourceActivity(rt, patches)
+	activity := getResourceActivity(rt, rendered.Patches)
+	applyResults := make([]controller.Result, 0, len(rendered.Patches))
 	updatedCount := 0
 
-	for _, patch := range patches {
+	for _, patch := range rendered.Patches {
 		patch := patch
 		applyResult := r.applyResource(ctx, rt, &patch)
+		applyResults = append(applyResults, applyResult)
 
 		if result, err := r.handleResult(ctx, rt, applyResult); err != nil {
+			r.report(ctx, rt, rendered.Environment, applyResults)
+
 			return result, err
 		}
 
@@ -118,9 +148,64 @@ func (r *Reconciler) handleResourceTemplate(ctx context.Context, rt *v1beta1.Res
 		}
 	}
 
+	if !r.report(ctx, rt, rendered.Environment, applyResults) || !deploymentStarted {
+		return reconcile.Result{Requeue: true}, nil
+	}
+
 	return reconcile.Result{}, nil
 }
 
+// report updates the GitHub deployment and the pull request comment with the
+// results of the reconciliation. It returns false when any of them failed.
+func (r *Reconciler) report(ctx context.Context, rt *v1beta1.ResourceTemplate, env environment, results []controller.Result) bool {
+	ok := true
+
+	if err := r.updateDeployment(ctx, rt, env, getDeploymentState(results)); err != nil {
+		ok = false
+		_, _ = r.handleResult(ctx, rt, controller.Result{
+			Error:  err,
+			Reason: ReasonDeploymentFailed,
+		})
+	}
+
+	if err := r.updateComment(ctx, rt, env, results); err != nil {
+		ok = false
+		_, _ = r.handleResult(ctx, rt, controller.Result{
+			Error:  err,
+			Reason: ReasonCommentFailed,
+		})
+	}
+
+	return ok
+}
+
+func (r *Reconciler) finalizeResourceTemplate(ctx context.Context, rt *v1beta1.ResourceTemplate) (reconcile.Result, error) {
+	finalizers := []struct {
+		Name     string
+		Finalize func(ctx context.Context, rt *v1beta1.ResourceTemplate) controller.Result
+	}{
+		{Name: v1beta1.FinalizerGitHubDeployment, Finalize: r.finalizeDeployment},
+		{Name: v1beta1.FinalizerGitHubComment, Finalize: r.finalizeComment},
+	}
+
+	var (
+		result reconcile.Result
+		err    error
+	)
+
+	for _, f := range finalizers {
+		if !controllerutil.ContainsFinalizer(rt, f.Name) {
+			continue
+		}
+
+		if res, e := r.handleResult(ctx, rt, f.Finalize(ctx, rt)); e != nil {
+			result, err = res, e
+		}
+	}
+
+	return result, err
+}
+
 func (r *Reconciler) updateStatus(ctx context.Context, rt *v1beta1.ResourceTemplate, active []v1beta1.ObjectReference) error {
 	now := metav1.Now()
 	rt.Status.LastUpdateTime = &now
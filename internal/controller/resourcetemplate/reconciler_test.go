@@ -2,10 +2,13 @@ package resourcetemplate
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
+	"github.com/google/go-github/v32/github"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/tommy351/pullup/internal/fakegithub"
 	"github.com/tommy351/pullup/internal/golden"
 	"github.com/tommy351/pullup/internal/k8s"
 	"github.com/tommy351/pullup/internal/random"
@@ -104,7 +107,7 @@ var _ = Describe("Reconciler", func() {
 		mgr, err = testenv.NewManager()
 		Expect(err).NotTo(HaveOccurred())
 
-		reconciler = NewReconciler(mgr)
+		reconciler = NewReconciler(mgr, nil)
 		Expect(mgr.Initialize()).To(Succeed())
 
 		namespaceMap = random.NewNamespaceMap()
@@ -362,4 +365,199 @@ var _ = Describe("Reconciler", func() {
 	When("updating invalid resource", func() {
 		testError("invalid-resource-update", false)
 	})
+
+	When("GitHub App is configured", func() {
+		var server *fakegithub.Server
+
+		getStates := func(id int64) []string {
+			var states []string
+
+			for _, status := range server.DeploymentStatuses(id) {
+				states = append(states, status.GetState())
+			}
+
+			return states
+		}
+
+		BeforeEach(func() {
+			server = fakegithub.NewServer()
+
+			app, err := server.NewAppClient()
+			Expect(err).NotTo(HaveOccurred())
+
+			reconciler.GitHub = app
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		When("resources are applied", func() {
+			testSuccess("github-deployment")
+
+			It("should create a deployment", func() {
+				deployments := server.Deployments()
+				Expect(deployments).To(HaveLen(1))
+				Expect(deployments[0].GetRef()).To(Equal("0ce4cf0450de14c6555c563fa9d36be67e69aa2f"))
+				Expect(deployments[0].GetEnvironment()).To(Equal("pr-46"))
+			})
+
+			It("should report success", func() {
+				Expect(getStates(1)).To(Equal([]string{"in_progress", "success"}))
+				Expect(server.DeploymentStatuses(1)[1].GetEnvironmentURL()).To(Equal("https://foo-rt.example.com"))
+			})
+
+			It("should add the finalizer", func() {
+				rt := new(v1beta1.ResourceTemplate)
+				Expect(reconciler.Client.Get(context.TODO(), types.NamespacedName{
+					Name:      "foo-rt",
+					Namespace: namespaceMap.GetRandom("test"),
+				}, rt)).To(Succeed())
+				Expect(rt.Finalizers).To(ContainElement(v1beta1.FinalizerGitHubDeployment))
+				Expect(rt.Status.GitHubDeployment).To(Equal(&v1beta1.GitHubDeploymentStatus{
+					ID:             1,
+					SHA:            "0ce4cf0450de14c6555c563fa9d36be67e69aa2f",
+					Environment:    "pr-46",
+					State:          "success",
+					EnvironmentURL: "https://foo-rt.example.com",
+				}))
+			})
+
+			When("the deployment was created but the status was not updated", func() {
+				addDeployment := func(name string) {
+					payload, err := json.Marshal(&deploymentPayload{
+						ResourceTemplate: namespaceMap.GetRandom("test") + "/" + name,
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					server.AddDeployment(&github.Deployment{
+						SHA:         github.String("0ce4cf0450de14c6555c563fa9d36be67e69aa2f"),
+						Task:        github.String("deploy"),
+						Environment: github.String("pr-46"),
+						Payload:     payload,
+					})
+				}
+
+				BeforeEach(func() {
+					addDeployment("other-rt")
+					addDeployment("foo-rt")
+				})
+
+				It("should reuse the deployment", func() {
+					Expect(server.Deployments()).To(HaveLen(2))
+					Expect(getStates(1)).To(BeEmpty())
+					Expect(getStates(2)).To(Equal([]string{"in_progress", "success"}))
+				})
+			})
+
+			When("resource template is deleted", func() {
+				JustBeforeEach(func() {
+					key := types.NamespacedName{
+						Name:      "foo-rt",
+						Namespace: namespaceMap.GetRandom("test"),
+					}
+					rt := new(v1beta1.ResourceTemplate)
+					Expect(reconciler.Client.Get(context.TODO(), key, rt)).To(Succeed())
+					Expect(reconciler.Client.Delete(context.TODO(), rt)).To(Succeed())
+
+					result, err = reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: key})
+				})
+
+				It("should mark the deployment as inactive", func() {
+					Expect(getStates(1)).To(Equal([]string{"in_progress", "success", "inactive"}))
+				})
+
+				testEvent(testenv.EventData{
+					Type:    corev1.EventTypeNormal,
+					Reason:  ReasonDeploymentUpdated,
+					Message: "Deactivated GitHub deployment: foo-rt",
+				})
+			})
+		})
+
+		When("resources are failed to apply", func() {
+			testSuccess("github-deployment-failed")
+
+			It("should report failure", func() {
+				Expect(getStates(1)).To(Equal([]string{"in_progress", "failure"}))
+			})
+		})
+
+		When("pull request comment is enabled", func() {
+			getComment := func() string {
+				comments := server.Comments(46)
+				Expect(comments).To(HaveLen(1))
+
+				return comments[0].GetBody()
+			}
+
+			When("resources are applied", func() {
+				testSuccess("github-comment")
+
+				It("should create a comment", func() {
+					body := getComment()
+					Expect(body).To(HavePrefix(fmt.Sprintf("<!-- pullup:%s/foo-rt -->", namespaceMap.GetRandom("test"))))
+					Expect(body).To(ContainSubstring("**Status:** Active"))
+					Expect(body).To(ContainSubstring("- `v1/Pod foo-rt`"))
+				})
+
+				When("the comment exists", func() {
+					BeforeEach(func() {
+						server.AddComment(46, fmt.Sprintf("<!-- pullup:%s/foo-rt -->", namespaceMap.GetRandom("test")))
+					})
+
+					It("should edit the comment", func() {
+						Expect(getComment()).To(ContainSubstring("**Status:** Active"))
+					})
+				})
+
+				When("another user posted the marker", func() {
+					var marker string
+
+					BeforeEach(func() {
+						marker = fmt.Sprintf("<!-- pullup:%s/foo-rt -->", namespaceMap.GetRandom("test"))
+						server.AddUserComment(46, "someone", marker)
+					})
+
+					It("should not edit the comment of the user", func() {
+						comments := server.Comments(46)
+						Expect(comments).To(HaveLen(2))
+						Expect(comments[0].GetBody()).To(Equal(marker))
+						Expect(comments[1].GetUser().GetLogin()).To(Equal(fakegithub.BotLogin))
+						Expect(comments[1].GetBody()).To(ContainSubstring("**Status:** Active"))
+					})
+				})
+
+				When("resource template is deleted", func() {
+					JustBeforeEach(func() {
+						key := types.NamespacedName{
+							Name:      "foo-rt",
+							Namespace: namespaceMap.GetRandom("test"),
+						}
+						rt := new(v1beta1.ResourceTemplate)
+						Expect(reconciler.Client.Get(context.TODO(), key, rt)).To(Succeed())
+						Expect(reconciler.Client.Delete(context.TODO(), rt)).To(Succeed())
+
+						result, err = reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: key})
+					})
+
+					It("should mark the comment as torn down", func() {
+						body := getComment()
+						Expect(body).To(ContainSubstring("**Status:** Torn down"))
+						Expect(body).NotTo(ContainSubstring("v1/Pod foo-rt"))
+					})
+				})
+			})
+
+			When("resources are failed to apply", func() {
+				testSuccess("github-comment-failed")
+
+				It("should list failures", func() {
+					body := getComment()
+					Expect(body).To(ContainSubstring("**Status:** Failed"))
+					Expect(body).To(ContainSubstring(fmt.Sprintf("- **%s**: ", ReasonResourceExists)))
+				})
+			})
+		})
+	})
 })
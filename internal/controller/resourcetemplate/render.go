@@ -13,16 +13,29 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func (r *Reconciler) renderTriggerPatches(ctx context.Context, rt *v1beta1.ResourceTemplate) ([]v1beta1.TriggerPatch, error) {
-	output := make([]v1beta1.TriggerPatch, len(rt.Spec.Patches))
+type renderedTemplate struct {
+	Patches     []v1beta1.TriggerPatch
+	Environment environment
+}
+
+type environment struct {
+	Name string
+	URL  string
+}
+
+func (r *Reconciler) renderTemplate(ctx context.Context, rt *v1beta1.ResourceTemplate) (*renderedTemplate, error) {
+	result := &renderedTemplate{
+		Patches: make([]v1beta1.TriggerPatch, len(rt.Spec.Patches)),
+	}
 	raw := rt.Spec.Data.Raw
 	if raw == nil {
 		raw = []byte("{}")
 	}
 
 	var (
-		err  error
-		data interface{}
+		err     error
+		data    interface{}
+		trigger client.Object
 	)
 
 	addedKeys := map[string]interface{}{
@@ -30,7 +43,7 @@ func (r *Reconciler) renderTriggerPatches(ctx context.Context, rt *v1beta1.Resou
 	}
 
 	if ref := rt.Spec.TriggerRef; ref != nil {
-		webhook, err := r.getObject(ctx, ref.GroupVersionKind(), types.NamespacedName{
+		trigger, err = r.getObject(ctx, ref.GroupVersionKind(), types.NamespacedName{
 			Namespace: rt.Namespace,
 			Name:      ref.Name,
 		})
@@ -38,8 +51,8 @@ func (r *Reconciler) renderTriggerPatches(ctx context.Context, rt *v1beta1.Resou
 			return nil, err
 		}
 
-		if webhook != nil {
-			addedKeys[v1beta1.DataKeyTrigger] = webhook
+		if trigger != nil {
+			addedKeys[v1beta1.DataKeyTrigger] = trigger
 		}
 	}
 
@@ -54,15 +67,42 @@ func (r *Reconciler) renderTriggerPatches(ctx context.Context, rt *v1beta1.Resou
 
 	for i, patch := range rt.Spec.Patches {
 		patch := patch
-		result, err := r.renderTriggerPatch(rt, &patch, data)
+		rendered, err := r.renderTriggerPatch(rt, &patch, data)
 		if err != nil {
 			return nil, err
 		}
 
-		output[i] = *result
+		result.Patches[i] = *rendered
 	}
 
-	return output, nil
+	if result.Environment, err = r.renderEnvironment(rt, trigger, data); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *Reconciler) renderEnvironment(rt *v1beta1.ResourceTemplate, trigger client.Object, data interface{}) (environment, error) {
+	var err error
+
+	result := environment{Name: rt.Name}
+
+	t, ok := trigger.(*v1beta1.Trigger)
+	if !ok || t.Spec.Environment == nil {
+		return result, nil
+	}
+
+	if name := t.Spec.Environment.Name; name != "" {
+		if result.Name, err = template.Render(name, data); err != nil {
+			return environment{}, fmt.Errorf("failed to render environment name: %w", err)
+		}
+	}
+
+	if result.URL, err = template.Render(t.Spec.Environment.URL, data); err != nil {
+		return environment{}, fmt.Errorf("failed to render environment url: %w", err)
+	}
+
+	return result, nil
 }
 
 func (r *Reconciler) renderTriggerPatch(rt *v1beta1.ResourceTemplate, patch *v1beta1.TriggerPatch, data interface{}) (*v1beta1.TriggerPatch, error) {
@@ -5,10 +5,11 @@ package resourcetemplate
 import (
 	"github.com/google/wire"
 	"github.com/tommy351/pullup/internal/controller"
+	"github.com/tommy351/pullup/internal/githubapp"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
-func NewReconciler(mgr manager.Manager) *Reconciler {
+func NewReconciler(mgr manager.Manager, app *githubapp.Client) *Reconciler {
 	wire.Build(
 		controller.NewClient,
 		controller.NewEventRecorder,
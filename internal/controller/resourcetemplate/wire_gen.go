@@ -7,12 +7,13 @@ package resourcetemplate
 
 import (
 	"github.com/tommy351/pullup/internal/controller"
+	"github.com/tommy351/pullup/internal/githubapp"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
 // Injectors from wire.go:
 
-func NewReconciler(mgr manager.Manager) *Reconciler {
+func NewReconciler(mgr manager.Manager, app *githubapp.Client) *Reconciler {
 	client := controller.NewClient(mgr)
 	eventRecorder := controller.NewEventRecorder(mgr)
 	reader := controller.NewAPIReader(mgr)
@@ -20,6 +21,7 @@ func NewReconciler(mgr manager.Manager) *Reconciler {
 		Client:    client,
 		Recorder:  eventRecorder,
 		APIReader: reader,
+		GitHub:    app,
 	}
 	return reconciler
 }
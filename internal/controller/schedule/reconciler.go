@@ -0,0 +1,243 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/wire"
+	"github.com/robfig/cron/v3"
+	"github.com/tommy351/pullup/internal/controller"
+	"github.com/tommy351/pullup/internal/log"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// +kubebuilder:rbac:groups=pullup.dev,resources=schedulesources,verbs=get;list;watch
+// +kubebuilder:rbac:groups=pullup.dev,resources=schedulesources/status,verbs=get;update;patch
+
+const (
+	ReasonInvalidSchedule   = "InvalidSchedule"
+	ReasonScheduleFailed    = "ScheduleFailed"
+	ReasonTooManyMissedRuns = "TooManyMissedRuns"
+	ReasonFailed            = "Failed"
+
+	// maxMissedRuns is the maximum number of missed runs which are walked
+	// through to find the most recent one, the same as CronJob.
+	maxMissedRuns = 100
+)
+
+// ReconcilerConfigSet provides a ReconcilerConfig.
+// nolint: gochecknoglobals
+var ReconcilerConfigSet = wire.NewSet(
+	hookutil.TriggerHandlerSet,
+	wire.Struct(new(ReconcilerConfig), "*"),
+)
+
+// ReconcilerSet provides a Reconciler.
+// nolint: gochecknoglobals
+var ReconcilerSet = wire.NewSet(
+	ReconcilerConfigSet,
+	NewReconciler,
+)
+
+type ReconcilerConfig struct {
+	Client         client.Client
+	Recorder       record.EventRecorder
+	TriggerHandler hookutil.TriggerHandler
+}
+
+type Reconciler struct {
+	ReconcilerConfig
+
+	now func() time.Time
+}
+
+func NewReconciler(conf ReconcilerConfig) *Reconciler {
+	return &Reconciler{
+		ReconcilerConfig: conf,
+		now:              time.Now,
+	}
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	source := new(v1beta1.ScheduleSource)
+
+	if err := r.Client.Get(ctx, req.NamespacedName, source); err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, fmt.Errorf("failed to get schedule source: %w", err)
+	}
+
+	logger := logr.FromContextOrDiscard(ctx).WithValues("scheduleSource", source)
+	ctx = logr.NewContext(ctx, logger)
+
+	sched, err := parseSchedule(source.Spec.Schedule, source.Spec.TimeZone)
+	if err != nil {
+		// The schedule can not be fixed without changing the spec, which
+		// triggers another reconciliation, so it is not requeued.
+		_, _ = r.handleResult(ctx, source, controller.Result{
+			Error:  err,
+			Reason: ReasonInvalidSchedule,
+		})
+
+		return reconcile.Result{}, nil
+	}
+
+	now := r.now()
+	lastTime := source.CreationTimestamp.Time
+
+	if t := source.Status.LastScheduleTime; t != nil {
+		lastTime = t.Time
+	}
+
+	if t := source.Status.LastSkipTime; t != nil && t.After(lastTime) {
+		lastTime = t.Time
+	}
+
+	scheduledTime, nextTime, skipped := getScheduleTimes(sched, lastTime, now)
+
+	if scheduledTime != nil {
+		source.Status.LastScheduleTime = &metav1.Time{Time: *scheduledTime}
+	}
+
+	// Missed runs are skipped and walked from now in the next reconciliation,
+	// e.g. the source was suspended for months.
+	if skipped {
+		source.Status.LastSkipTime = &metav1.Time{Time: now}
+	}
+
+	source.Status.NextScheduleTime = nil
+
+	if !nextTime.IsZero() {
+		source.Status.NextScheduleTime = &metav1.Time{Time: nextTime}
+	}
+
+	// The status is updated before the run is triggered, so the run is not
+	// triggered twice when the update fails, e.g. the source is reconciled
+	// concurrently.
+	if err := r.Client.Status().Update(ctx, source); err != nil {
+		return r.handleResult(ctx, source, controller.Result{
+			Error:  fmt.Errorf("failed to update status: %w", err),
+			Reason: ReasonFailed,
+		})
+	}
+
+	if skipped {
+		_, _ = r.handleResult(ctx, source, controller.Result{
+			Error:  fmt.Errorf("skipped more than %d missed runs since %s", maxMissedRuns, lastTime.Format(time.RFC3339)),
+			Reason: ReasonTooManyMissedRuns,
+		})
+	}
+
+	if scheduledTime != nil {
+		if err := r.trigger(ctx, source); err != nil {
+			// Missed runs are not retried, the same as CronJob.
+			_, _ = r.handleResult(ctx, source, controller.Result{
+				Error:  fmt.Errorf("failed to trigger the scheduled run at %s: %w", scheduledTime.Format(time.RFC3339), err),
+				Reason: ReasonScheduleFailed,
+			})
+		}
+	}
+
+	// The schedule never runs again, e.g. "0 0 30 2 *".
+	if nextTime.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	logger.V(log.Debug).Info("Next run is scheduled", "time", nextTime)
+
+	return reconcile.Result{RequeueAfter: nextTime.Sub(now)}, nil
+}
+
+func (r *Reconciler) trigger(ctx context.Context, source *v1beta1.ScheduleSource) error {
+	event := source.Spec.Data
+
+	if event == nil || event.Raw == nil {
+		event = &extv1.JSON{Raw: []byte("{}")}
+	}
+
+	return r.TriggerHandler.Handle(ctx, &hookutil.TriggerOptions{
+		Action:        source.Spec.Action,
+		DefaultAction: v1beta1.ActionApply,
+		Event:         event,
+		Source:        source,
+		Triggers:      source.Spec.Triggers,
+	})
+}
+
+func (r *Reconciler) handleResult(ctx context.Context, source *v1beta1.ScheduleSource, result controller.Result) (reconcile.Result, error) {
+	logger := logr.FromContextOrDiscard(ctx)
+	result.RecordEvent(r.Recorder, source)
+
+	if err := result.Error; err != nil {
+		logger.Error(result.Error, result.GetMessage())
+	} else {
+		logger.Info(result.GetMessage())
+	}
+
+	return reconcile.Result{Requeue: result.Requeue}, result.Error
+}
+
+func parseSchedule(spec, timeZone string) (cron.Schedule, error) {
+	sched, err := cron.ParseStandard(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", spec, err)
+	}
+
+	// The schedule is evaluated in UTC by default, instead of the local time
+	// zone of the controller.
+	loc := time.UTC
+
+	if timeZone != "" {
+		if loc, err = time.LoadLocation(timeZone); err != nil {
+			return nil, fmt.Errorf("invalid time zone %q: %w", timeZone, err)
+		}
+	}
+
+	return &locationSchedule{Schedule: sched, Location: loc}, nil
+}
+
+// locationSchedule evaluates a schedule in a time zone.
+type locationSchedule struct {
+	cron.Schedule
+	Location *time.Location
+}
+
+func (l *locationSchedule) Next(t time.Time) time.Time {
+	return l.Schedule.Next(t.In(l.Location))
+}
+
+// getScheduleTimes returns the most recent scheduled time after last which is
+// not later than now, and the next scheduled time after now. The former is nil
+// when no runs are missed. Missed runs are skipped without the scheduled time
+// when there are more than maxMissedRuns of them.
+func getScheduleTimes(sched cron.Schedule, last, now time.Time) (*time.Time, time.Time, bool) {
+	var (
+		scheduled *time.Time
+		missed    int
+	)
+
+	t := sched.Next(last)
+
+	for !t.IsZero() && !t.After(now) {
+		if missed++; missed > maxMissedRuns {
+			return nil, sched.Next(now), true
+		}
+
+		current := t
+		scheduled = &current
+		t = sched.Next(t)
+	}
+
+	return scheduled, t, false
+}
@@ -0,0 +1,263 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tommy351/pullup/internal/k8s"
+	"github.com/tommy351/pullup/internal/random"
+	"github.com/tommy351/pullup/internal/testenv"
+	"github.com/tommy351/pullup/internal/testutil"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Reconciler", func() {
+	var (
+		reconciler   *Reconciler
+		mgr          *testenv.Manager
+		result       reconcile.Result
+		err          error
+		namespaceMap *random.NamespaceMap
+		createdAt    time.Time
+		now          time.Time
+	)
+
+	getChanges := func() []testenv.Change {
+		return testenv.GetChanges(reconciler.Client)
+	}
+
+	getSource := func() *v1beta1.ScheduleSource {
+		source := new(v1beta1.ScheduleSource)
+		Expect(reconciler.Client.Get(context.Background(), types.NamespacedName{
+			Namespace: namespaceMap.GetRandom("test"),
+			Name:      "foobar",
+		}, source)).To(Succeed())
+
+		return source
+	}
+
+	loadData := func(name string) {
+		var data []client.Object
+
+		BeforeEach(func() {
+			data, err = k8s.LoadObjects(testenv.GetScheme(), fmt.Sprintf("testdata/%s.yml", name))
+			Expect(err).NotTo(HaveOccurred())
+
+			data, err = k8s.MapObjects(data, namespaceMap.SetObject)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(testenv.CreateObjects(data)).To(Succeed())
+
+			createdAt = getSource().CreationTimestamp.Time
+		})
+
+		AfterEach(func() {
+			Expect(testenv.DeleteObjects(data)).To(Succeed())
+		})
+	}
+
+	testInvalid := func(message string) {
+		It("should not requeue", func() {
+			Expect(result).To(Equal(reconcile.Result{}))
+		})
+
+		It("should not return the error", func() {
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should not change anything", func() {
+			Expect(getChanges()).To(BeEmpty())
+		})
+
+		It("should record InvalidSchedule event", func() {
+			Expect(mgr.WaitForEvent(testenv.EventData{
+				Type:    corev1.EventTypeWarning,
+				Reason:  ReasonInvalidSchedule,
+				Message: message,
+			})).To(BeTrue())
+		})
+	}
+
+	BeforeEach(func() {
+		mgr, err = testenv.NewManager()
+		Expect(err).NotTo(HaveOccurred())
+
+		reconciler = NewReconciler(NewReconcilerConfig(mgr))
+		reconciler.now = func() time.Time {
+			return now
+		}
+
+		Expect(mgr.Initialize()).To(Succeed())
+
+		namespaceMap = random.NewNamespaceMap()
+	})
+
+	JustBeforeEach(func() {
+		result, err = reconciler.Reconcile(context.TODO(), reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      "foobar",
+				Namespace: namespaceMap.GetRandom("test"),
+			},
+		})
+	})
+
+	AfterEach(func() {
+		mgr.Stop()
+	})
+
+	When("source does not exist", func() {
+		It("should not requeue", func() {
+			Expect(result).To(Equal(reconcile.Result{}))
+		})
+
+		It("should not return the error", func() {
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should not change anything", func() {
+			Expect(getChanges()).To(BeEmpty())
+		})
+	})
+
+	When("schedule is not due", func() {
+		loadData("source")
+
+		BeforeEach(func() {
+			now = createdAt
+		})
+
+		It("should not return the error", func() {
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should requeue at the next scheduled time", func() {
+			next := createdAt.Truncate(time.Hour).Add(time.Hour)
+			Expect(result).To(Equal(reconcile.Result{RequeueAfter: next.Sub(now)}))
+		})
+
+		It("should update the status", func() {
+			source := getSource()
+			Expect(source.Status.LastScheduleTime).To(BeNil())
+			Expect(source.Status.NextScheduleTime.Time).To(BeTemporally("==", createdAt.Truncate(time.Hour).Add(time.Hour)))
+		})
+
+		It("should not create any resource templates", func() {
+			Expect(getChanges()).To(Equal([]testenv.Change{
+				{
+					GroupVersionKind: v1beta1.GroupVersion.WithKind("ScheduleSource"),
+					NamespacedName: types.NamespacedName{
+						Namespace: namespaceMap.GetRandom("test"),
+						Name:      "foobar",
+					},
+					Type: "status_update",
+				},
+			}))
+		})
+	})
+
+	When("schedule is due", func() {
+		loadData("source")
+
+		BeforeEach(func() {
+			now = createdAt.Add(150 * time.Minute)
+		})
+
+		It("should not return the error", func() {
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should requeue at the next scheduled time", func() {
+			next := now.Truncate(time.Hour).Add(time.Hour)
+			Expect(result).To(Equal(reconcile.Result{RequeueAfter: next.Sub(now)}))
+		})
+
+		It("should record the last and next scheduled time", func() {
+			source := getSource()
+			Expect(source.Status.LastScheduleTime.Time).To(BeTemporally("==", now.Truncate(time.Hour)))
+			Expect(source.Status.NextScheduleTime.Time).To(BeTemporally("==", now.Truncate(time.Hour).Add(time.Hour)))
+		})
+
+		It("should create the resource template", func() {
+			Expect(mgr.WaitForEvent(testenv.EventData{
+				Type:    corev1.EventTypeNormal,
+				Reason:  hookutil.ReasonCreated,
+				Message: "Created resource template: foobar-nightly",
+			})).To(BeTrue())
+		})
+
+		It("should pass data as the event", func() {
+			rt := new(v1beta1.ResourceTemplate)
+			Expect(reconciler.Client.Get(context.Background(), types.NamespacedName{
+				Namespace: namespaceMap.GetRandom("test"),
+				Name:      "foobar-nightly",
+			}, rt)).To(Succeed())
+			Expect(rt.Spec.Data.Raw).To(MatchJSON(testutil.MustMarshalJSON(map[string]interface{}{
+				"event": map[string]interface{}{
+					"name": "nightly",
+				},
+			})))
+		})
+	})
+
+	When("too many runs are missed", func() {
+		loadData("source")
+
+		BeforeEach(func() {
+			now = createdAt.Add((maxMissedRuns + 1) * time.Hour)
+		})
+
+		It("should not return the error", func() {
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should requeue at the next scheduled time", func() {
+			next := now.Truncate(time.Hour).Add(time.Hour)
+			Expect(result).To(Equal(reconcile.Result{RequeueAfter: next.Sub(now)}))
+		})
+
+		It("should record the skip", func() {
+			source := getSource()
+			Expect(source.Status.LastScheduleTime).To(BeNil())
+			Expect(source.Status.LastSkipTime.Time).To(BeTemporally("==", now.Truncate(time.Second)))
+			Expect(source.Status.NextScheduleTime.Time).To(BeTemporally("==", now.Truncate(time.Hour).Add(time.Hour)))
+		})
+
+		It("should record TooManyMissedRuns event", func() {
+			Expect(mgr.WaitForEvent(testenv.EventData{
+				Type:    corev1.EventTypeWarning,
+				Reason:  ReasonTooManyMissedRuns,
+				Message: fmt.Sprintf("skipped more than %d missed runs since %s", maxMissedRuns, createdAt.Format(time.RFC3339)),
+			})).To(BeTrue())
+		})
+
+		It("should not create any resource templates", func() {
+			Expect(getChanges()).To(Equal([]testenv.Change{
+				{
+					GroupVersionKind: v1beta1.GroupVersion.WithKind("ScheduleSource"),
+					NamespacedName: types.NamespacedName{
+						Namespace: namespaceMap.GetRandom("test"),
+						Name:      "foobar",
+					},
+					Type: "status_update",
+				},
+			}))
+		})
+	})
+
+	When("schedule is invalid", func() {
+		loadData("invalid-schedule")
+		testInvalid(`invalid schedule "not a schedule": expected exactly 5 fields, found 3: [not a schedule]`)
+	})
+
+	When("time zone is invalid", func() {
+		loadData("invalid-time-zone")
+		testInvalid(`invalid time zone "Mars/Olympus_Mons": unknown time zone Mars/Olympus_Mons`)
+	})
+})
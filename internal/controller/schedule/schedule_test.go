@@ -0,0 +1,126 @@
+package schedule
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("getScheduleTimes", func() {
+	mustParse := func(spec, timeZone string) func(last, now time.Time) (*time.Time, time.Time, bool) {
+		sched, err := parseSchedule(spec, timeZone)
+		Expect(err).NotTo(HaveOccurred())
+
+		return func(last, now time.Time) (*time.Time, time.Time, bool) {
+			return getScheduleTimes(sched, last, now)
+		}
+	}
+
+	date := func(hour, min int) time.Time {
+		return time.Date(2020, 10, 1, hour, min, 0, 0, time.UTC)
+	}
+
+	When("no runs are missed", func() {
+		It("should return nil", func() {
+			scheduled, next, _ := mustParse("0 * * * *", "")(date(1, 0), date(1, 30))
+			Expect(scheduled).To(BeNil())
+			Expect(next).To(Equal(date(2, 0)))
+		})
+	})
+
+	When("a run is missed", func() {
+		It("should return the scheduled time", func() {
+			scheduled, next, _ := mustParse("0 * * * *", "")(date(1, 0), date(2, 30))
+			Expect(*scheduled).To(Equal(date(2, 0)))
+			Expect(next).To(Equal(date(3, 0)))
+		})
+	})
+
+	When("several runs are missed", func() {
+		It("should return the most recent scheduled time", func() {
+			scheduled, next, _ := mustParse("0 * * * *", "")(date(1, 0), date(5, 30))
+			Expect(*scheduled).To(Equal(date(5, 0)))
+			Expect(next).To(Equal(date(6, 0)))
+		})
+	})
+
+	When("too many runs are missed", func() {
+		It("should skip missed runs", func() {
+			scheduled, next, skipped := mustParse("* * * * *", "")(date(1, 0), date(1, 0).AddDate(0, 3, 0))
+			Expect(scheduled).To(BeNil())
+			Expect(next).To(Equal(date(1, 1).AddDate(0, 3, 0)))
+			Expect(skipped).To(BeTrue())
+		})
+	})
+
+	When("as many runs as the limit are missed", func() {
+		It("should return the most recent scheduled time", func() {
+			now := date(1, 0).Add(maxMissedRuns * time.Minute)
+			scheduled, next, skipped := mustParse("* * * * *", "")(date(1, 0), now)
+			Expect(*scheduled).To(Equal(now))
+			Expect(next).To(Equal(now.Add(time.Minute)))
+			Expect(skipped).To(BeFalse())
+		})
+	})
+
+	When("now is the scheduled time", func() {
+		It("should return the scheduled time", func() {
+			scheduled, next, _ := mustParse("0 * * * *", "")(date(1, 0), date(2, 0))
+			Expect(*scheduled).To(Equal(date(2, 0)))
+			Expect(next).To(Equal(date(3, 0)))
+		})
+	})
+
+	When("time zone is set", func() {
+		It("should evaluate the schedule in the time zone", func() {
+			scheduled, next, _ := mustParse("0 9 * * *", "Asia/Taipei")(date(0, 0), date(2, 0))
+			Expect(scheduled.Equal(date(1, 0))).To(BeTrue())
+			Expect(next.Equal(date(1, 0).AddDate(0, 0, 1))).To(BeTrue())
+		})
+	})
+
+	When("time zone is not set", func() {
+		var local *time.Location
+
+		BeforeEach(func() {
+			local = time.Local
+			time.Local = time.FixedZone("UTC+8", 8*60*60)
+		})
+
+		AfterEach(func() {
+			time.Local = local
+		})
+
+		It("should evaluate the schedule in UTC", func() {
+			scheduled, next, _ := mustParse("0 9 * * *", "")(date(0, 0).In(time.Local), date(10, 0).In(time.Local))
+			Expect(scheduled.Equal(date(9, 0))).To(BeTrue())
+			Expect(next.Equal(date(9, 0).AddDate(0, 0, 1))).To(BeTrue())
+		})
+	})
+
+	When("schedule never runs", func() {
+		It("should return zero time", func() {
+			scheduled, next, _ := mustParse("0 0 30 2 *", "")(date(0, 0), date(2, 0))
+			Expect(scheduled).To(BeNil())
+			Expect(next.IsZero()).To(BeTrue())
+		})
+	})
+})
+
+var _ = Describe("parseSchedule", func() {
+	It("should support predefined schedules", func() {
+		_, err := parseSchedule("@daily", "")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should return the error when schedule is invalid", func() {
+		_, err := parseSchedule("not a schedule", "")
+		Expect(err).To(MatchError(`invalid schedule "not a schedule": expected exactly 5 fields, found 3: [not a schedule]`))
+	})
+
+	It("should return the error when time zone is invalid", func() {
+		_, err := parseSchedule("0 * * * *", "Mars/Olympus_Mons")
+		Expect(err).To(MatchError(`invalid time zone "Mars/Olympus_Mons": unknown time zone Mars/Olympus_Mons`))
+	})
+})
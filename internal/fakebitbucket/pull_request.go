@@ -0,0 +1,75 @@
+package fakebitbucket
+
+import "github.com/tommy351/pullup/internal/bitbucket"
+
+type PullRequestEventModifier func(event *bitbucket.PullRequestEvent)
+
+func NewPullRequestEvent(modifiers ...PullRequestEventModifier) *bitbucket.PullRequestEvent {
+	event := &bitbucket.PullRequestEvent{
+		Repository: NewRepository(),
+		PullRequest: &bitbucket.PullRequest{
+			ID:    46,
+			Title: "Test pull request",
+			State: "OPEN",
+			Source: &bitbucket.PullRequestEndpoint{
+				Branch:     &bitbucket.Branch{Name: "test"},
+				Commit:     &bitbucket.Commit{Hash: "0ce4cf0450de"},
+				Repository: NewRepository(),
+			},
+			Destination: &bitbucket.PullRequestEndpoint{
+				Branch:     &bitbucket.Branch{Name: "base"},
+				Commit:     &bitbucket.Commit{Hash: "b436f6eb3356"},
+				Repository: NewRepository(),
+			},
+		},
+	}
+
+	for _, mod := range modifiers {
+		mod(event)
+	}
+
+	return event
+}
+
+func SetPullRequestEventBranch(branch string) PullRequestEventModifier {
+	return func(event *bitbucket.PullRequestEvent) {
+		event.PullRequest.Destination.Branch.Name = branch
+	}
+}
+
+type ServerPullRequestEventModifier func(event *bitbucket.ServerPullRequestEvent)
+
+func NewServerPullRequestEvent(modifiers ...ServerPullRequestEventModifier) *bitbucket.ServerPullRequestEvent {
+	event := &bitbucket.ServerPullRequestEvent{
+		EventKey: bitbucket.ServerPullRequestOpened,
+		PullRequest: &bitbucket.ServerPullRequest{
+			ID:    46,
+			Title: "Test pull request",
+			State: "OPEN",
+			FromRef: &bitbucket.ServerRef{
+				ID:           "refs/heads/test",
+				DisplayID:    "test",
+				LatestCommit: "0ce4cf0450de14c6555c563fa9d36be67e69aa2f",
+				Repository:   NewServerRepository(),
+			},
+			ToRef: &bitbucket.ServerRef{
+				ID:           "refs/heads/base",
+				DisplayID:    "base",
+				LatestCommit: "b436f6eb3356504235c0c9a8e74605c820d8d9cc",
+				Repository:   NewServerRepository(),
+			},
+		},
+	}
+
+	for _, mod := range modifiers {
+		mod(event)
+	}
+
+	return event
+}
+
+func SetServerPullRequestEventKey(key string) ServerPullRequestEventModifier {
+	return func(event *bitbucket.ServerPullRequestEvent) {
+		event.EventKey = key
+	}
+}
@@ -0,0 +1,110 @@
+package fakebitbucket
+
+import "github.com/tommy351/pullup/internal/bitbucket"
+
+type PushEventModifier func(event *bitbucket.PushEvent)
+
+func NewPushEvent(modifiers ...PushEventModifier) *bitbucket.PushEvent {
+	event := &bitbucket.PushEvent{
+		Repository: NewRepository(),
+		Push: &bitbucket.Push{
+			Changes: []*bitbucket.PushChange{
+				{
+					New: &bitbucket.PushRef{
+						Type:   "branch",
+						Name:   "master",
+						Target: &bitbucket.Commit{Hash: "0ce4cf0450de14c6555c563fa9d36be67e69aa2f"},
+					},
+					Old: &bitbucket.PushRef{
+						Type:   "branch",
+						Name:   "master",
+						Target: &bitbucket.Commit{Hash: "b436f6eb3356504235c0c9a8e74605c820d8d9cc"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, mod := range modifiers {
+		mod(event)
+	}
+
+	return event
+}
+
+func SetPushEventBranch(branch string) PushEventModifier {
+	return func(event *bitbucket.PushEvent) {
+		change := event.Push.Changes[0]
+		change.New.Type = "branch"
+		change.New.Name = branch
+		change.Old.Type = "branch"
+		change.Old.Name = branch
+	}
+}
+
+func SetPushEventTag(tag string) PushEventModifier {
+	return func(event *bitbucket.PushEvent) {
+		change := event.Push.Changes[0]
+		change.New.Type = "tag"
+		change.New.Name = tag
+		change.Old = nil
+		change.Created = true
+	}
+}
+
+func SetPushEventDeleted() PushEventModifier {
+	return func(event *bitbucket.PushEvent) {
+		change := event.Push.Changes[0]
+		change.New = nil
+		change.Closed = true
+	}
+}
+
+type ServerRefsChangedEventModifier func(event *bitbucket.ServerRefsChangedEvent)
+
+func NewServerRefsChangedEvent(modifiers ...ServerRefsChangedEventModifier) *bitbucket.ServerRefsChangedEvent {
+	event := &bitbucket.ServerRefsChangedEvent{
+		EventKey:   bitbucket.ServerRepoRefsChanged,
+		Repository: NewServerRepository(),
+		Changes: []*bitbucket.ServerRefChange{
+			{
+				Ref: &bitbucket.ServerRef{
+					ID:        "refs/heads/master",
+					DisplayID: "master",
+					Type:      "BRANCH",
+				},
+				RefID:    "refs/heads/master",
+				FromHash: "b436f6eb3356504235c0c9a8e74605c820d8d9cc",
+				ToHash:   "0ce4cf0450de14c6555c563fa9d36be67e69aa2f",
+				Type:     "UPDATE",
+			},
+		},
+	}
+
+	for _, mod := range modifiers {
+		mod(event)
+	}
+
+	return event
+}
+
+func SetServerRefsChangedEventBranch(branch string) ServerRefsChangedEventModifier {
+	return func(event *bitbucket.ServerRefsChangedEvent) {
+		change := event.Changes[0]
+		change.Ref.ID = "refs/heads/" + branch
+		change.Ref.DisplayID = branch
+		change.Ref.Type = "BRANCH"
+		change.RefID = change.Ref.ID
+	}
+}
+
+func SetServerRefsChangedEventTag(tag string) ServerRefsChangedEventModifier {
+	return func(event *bitbucket.ServerRefsChangedEvent) {
+		change := event.Changes[0]
+		change.Ref.ID = "refs/tags/" + tag
+		change.Ref.DisplayID = tag
+		change.Ref.Type = "TAG"
+		change.RefID = change.Ref.ID
+		change.Type = "ADD"
+	}
+}
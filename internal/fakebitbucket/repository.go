@@ -0,0 +1,25 @@
+package fakebitbucket
+
+import "github.com/tommy351/pullup/internal/bitbucket"
+
+func NewRepository() *bitbucket.Repository {
+	return &bitbucket.Repository{
+		UUID:     "{c1a5ab1c-8b1b-4b0e-9c8a-4f4a5f1d2e3b}",
+		Name:     "bar",
+		FullName: "foo/bar",
+		Type:     "repository",
+	}
+}
+
+func NewServerRepository() *bitbucket.ServerRepository {
+	return &bitbucket.ServerRepository{
+		ID:   84,
+		Slug: "bar",
+		Name: "bar",
+		Project: &bitbucket.ServerProject{
+			ID:   1,
+			Key:  "foo",
+			Name: "Foo",
+		},
+	}
+}
@@ -0,0 +1,61 @@
+package fakegitea
+
+import "github.com/tommy351/pullup/internal/gitea"
+
+type PullRequestEventModifier func(event *gitea.PullRequestEvent)
+
+func NewPullRequestEvent(modifiers ...PullRequestEventModifier) *gitea.PullRequestEvent {
+	event := &gitea.PullRequestEvent{
+		Action: "opened",
+		Number: 46,
+		PullRequest: &gitea.PullRequest{
+			ID:     99,
+			Number: 46,
+			Title:  "Test pull request",
+			State:  "open",
+			User:   NewUser(),
+			Base: &gitea.PullRequestBranch{
+				Ref:    "base",
+				Sha:    "b436f6eb3356504235c0c9a8e74605c820d8d9cc",
+				RepoID: 15,
+				Repo:   NewRepository(),
+			},
+			Head: &gitea.PullRequestBranch{
+				Ref:    "test",
+				Sha:    "0ce4cf0450de14c6555c563fa9d36be67e69aa2f",
+				RepoID: 15,
+				Repo:   NewRepository(),
+			},
+		},
+		Repository: NewRepository(),
+		Sender:     NewUser(),
+	}
+
+	for _, mod := range modifiers {
+		mod(event)
+	}
+
+	return event
+}
+
+func SetPullRequestEventAction(action string) PullRequestEventModifier {
+	return func(event *gitea.PullRequestEvent) {
+		event.Action = action
+	}
+}
+
+func SetPullRequestEventBranch(branch string) PullRequestEventModifier {
+	return func(event *gitea.PullRequestEvent) {
+		event.PullRequest.Base.Ref = branch
+	}
+}
+
+func SetPullRequestEventLabels(labels []string) PullRequestEventModifier {
+	return func(event *gitea.PullRequestEvent) {
+		event.PullRequest.Labels = make([]*gitea.Label, len(labels))
+
+		for i, label := range labels {
+			event.PullRequest.Labels[i] = &gitea.Label{ID: int64(i + 1), Name: label}
+		}
+	}
+}
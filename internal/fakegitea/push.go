@@ -0,0 +1,38 @@
+package fakegitea
+
+import "github.com/tommy351/pullup/internal/gitea"
+
+type PushEventModifier func(event *gitea.PushEvent)
+
+func NewPushEvent(modifiers ...PushEventModifier) *gitea.PushEvent {
+	event := &gitea.PushEvent{
+		Ref:    "refs/heads/master",
+		Before: "b436f6eb3356504235c0c9a8e74605c820d8d9cc",
+		After:  "0ce4cf0450de14c6555c563fa9d36be67e69aa2f",
+		HeadCommit: &gitea.Commit{
+			ID:      "0ce4cf0450de14c6555c563fa9d36be67e69aa2f",
+			Message: "Test commit",
+		},
+		Repository: NewRepository(),
+		Pusher:     NewUser(),
+		Sender:     NewUser(),
+	}
+
+	for _, mod := range modifiers {
+		mod(event)
+	}
+
+	return event
+}
+
+func SetPushEventBranch(branch string) PushEventModifier {
+	return func(event *gitea.PushEvent) {
+		event.Ref = "refs/heads/" + branch
+	}
+}
+
+func SetPushEventTag(tag string) PushEventModifier {
+	return func(event *gitea.PushEvent) {
+		event.Ref = "refs/tags/" + tag
+	}
+}
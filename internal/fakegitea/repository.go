@@ -0,0 +1,23 @@
+package fakegitea
+
+import "github.com/tommy351/pullup/internal/gitea"
+
+func NewUser() *gitea.User {
+	return &gitea.User{
+		ID:       1,
+		Login:    "foo",
+		UserName: "foo",
+	}
+}
+
+func NewRepository() *gitea.Repository {
+	return &gitea.Repository{
+		ID:            15,
+		Owner:         NewUser(),
+		Name:          "bar",
+		FullName:      "foo/bar",
+		HTMLURL:       "https://gitea.com/foo/bar",
+		CloneURL:      "https://gitea.com/foo/bar.git",
+		DefaultBranch: "master",
+	}
+}
@@ -0,0 +1,135 @@
+package fakegithub
+
+import (
+	"github.com/google/go-github/v32/github"
+	"k8s.io/utils/pointer"
+)
+
+// WorkflowRunEvent is a workflow_run event, which is not supported by
+// go-github yet.
+type WorkflowRunEvent struct {
+	Action       *string              `json:"action,omitempty"`
+	WorkflowRun  *WorkflowRun         `json:"workflow_run,omitempty"`
+	Repo         *github.Repository   `json:"repository,omitempty"`
+	Installation *github.Installation `json:"installation,omitempty"`
+}
+
+type WorkflowRun struct {
+	*github.WorkflowRun
+
+	Name *string `json:"name,omitempty"`
+}
+
+type WorkflowRunEventModifier func(event *WorkflowRunEvent)
+
+func NewWorkflowRunEvent(modifiers ...WorkflowRunEventModifier) *WorkflowRunEvent {
+	pr := NewPullRequest()
+	event := &WorkflowRunEvent{
+		Action: pointer.StringPtr("completed"),
+		WorkflowRun: &WorkflowRun{
+			Name: pointer.StringPtr("CI"),
+			WorkflowRun: &github.WorkflowRun{
+				HeadBranch: pr.Head.Ref,
+				HeadSHA:    pr.Head.SHA,
+				Status:     pointer.StringPtr("completed"),
+				Conclusion: pointer.StringPtr("success"),
+				PullRequests: []*github.PullRequest{
+					{Number: pr.Number},
+				},
+			},
+		},
+		Repo: newRepository(),
+	}
+
+	for _, mod := range modifiers {
+		mod(event)
+	}
+
+	return event
+}
+
+func SetWorkflowRunEventAction(action string) WorkflowRunEventModifier {
+	return func(event *WorkflowRunEvent) {
+		event.Action = pointer.StringPtr(action)
+	}
+}
+
+func SetWorkflowRunEventName(name string) WorkflowRunEventModifier {
+	return func(event *WorkflowRunEvent) {
+		event.WorkflowRun.Name = pointer.StringPtr(name)
+	}
+}
+
+func SetWorkflowRunEventConclusion(conclusion string) WorkflowRunEventModifier {
+	return func(event *WorkflowRunEvent) {
+		event.WorkflowRun.Conclusion = pointer.StringPtr(conclusion)
+	}
+}
+
+func SetWorkflowRunEventHeadBranch(branch string) WorkflowRunEventModifier {
+	return func(event *WorkflowRunEvent) {
+		event.WorkflowRun.HeadBranch = pointer.StringPtr(branch)
+	}
+}
+
+func SetWorkflowRunEventHeadSHA(sha string) WorkflowRunEventModifier {
+	return func(event *WorkflowRunEvent) {
+		event.WorkflowRun.HeadSHA = pointer.StringPtr(sha)
+	}
+}
+
+// UnsetWorkflowRunEventPullRequests removes pull requests from the event,
+// which happens when the head branch is in a fork.
+func UnsetWorkflowRunEventPullRequests() WorkflowRunEventModifier {
+	return func(event *WorkflowRunEvent) {
+		event.WorkflowRun.PullRequests = nil
+	}
+}
+
+type CheckSuiteEventModifier func(event *github.CheckSuiteEvent)
+
+func NewCheckSuiteEvent(modifiers ...CheckSuiteEventModifier) *github.CheckSuiteEvent {
+	pr := NewPullRequest()
+	event := &github.CheckSuiteEvent{
+		Action: pointer.StringPtr("completed"),
+		CheckSuite: &github.CheckSuite{
+			HeadBranch: pr.Head.Ref,
+			HeadSHA:    pr.Head.SHA,
+			Status:     pointer.StringPtr("completed"),
+			Conclusion: pointer.StringPtr("success"),
+			App: &github.App{
+				Slug: pointer.StringPtr("github-actions"),
+			},
+			PullRequests: []*github.PullRequest{
+				{Number: pr.Number},
+			},
+		},
+		Repo: newRepository(),
+	}
+
+	for _, mod := range modifiers {
+		mod(event)
+	}
+
+	return event
+}
+
+func SetCheckSuiteEventConclusion(conclusion string) CheckSuiteEventModifier {
+	return func(event *github.CheckSuiteEvent) {
+		event.CheckSuite.Conclusion = pointer.StringPtr(conclusion)
+	}
+}
+
+func SetCheckSuiteEventAppSlug(slug string) CheckSuiteEventModifier {
+	return func(event *github.CheckSuiteEvent) {
+		event.CheckSuite.App.Slug = pointer.StringPtr(slug)
+	}
+}
+
+func newRepository() *github.Repository {
+	return &github.Repository{
+		Name:     pointer.StringPtr("bar"),
+		FullName: pointer.StringPtr("foo/bar"),
+		Owner:    &github.User{Login: pointer.StringPtr("foo")},
+	}
+}
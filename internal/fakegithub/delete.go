@@ -0,0 +1,29 @@
+package fakegithub
+
+import (
+	"github.com/google/go-github/v32/github"
+	"k8s.io/utils/pointer"
+)
+
+type DeleteEventModifier func(event *github.DeleteEvent)
+
+func NewDeleteEvent(modifiers ...DeleteEventModifier) *github.DeleteEvent {
+	event := &github.DeleteEvent{
+		Ref:     pointer.StringPtr("test"),
+		RefType: pointer.StringPtr("branch"),
+		Repo:    newRepository(),
+	}
+
+	for _, mod := range modifiers {
+		mod(event)
+	}
+
+	return event
+}
+
+func SetDeleteEventTag(tag string) DeleteEventModifier {
+	return func(event *github.DeleteEvent) {
+		event.Ref = pointer.StringPtr(tag)
+		event.RefType = pointer.StringPtr("tag")
+	}
+}
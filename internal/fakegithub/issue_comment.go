@@ -0,0 +1,54 @@
+package fakegithub
+
+import (
+	"github.com/google/go-github/v32/github"
+	"k8s.io/utils/pointer"
+)
+
+type IssueCommentEventModifier func(event *github.IssueCommentEvent)
+
+func NewIssueCommentEvent(modifiers ...IssueCommentEventModifier) *github.IssueCommentEvent {
+	number := 46
+	event := &github.IssueCommentEvent{
+		Action: pointer.StringPtr("created"),
+		Issue: &github.Issue{
+			Number: &number,
+			PullRequestLinks: &github.PullRequestLinks{
+				URL: pointer.StringPtr("https://api.github.com/repos/foo/bar/pulls/46"),
+			},
+		},
+		Comment: &github.IssueComment{
+			Body:              pointer.StringPtr("/pullup deploy"),
+			AuthorAssociation: pointer.StringPtr("MEMBER"),
+		},
+		Repo: &github.Repository{
+			Name:     pointer.StringPtr("bar"),
+			FullName: pointer.StringPtr("foo/bar"),
+			Owner:    &github.User{Login: pointer.StringPtr("foo")},
+		},
+	}
+
+	for _, mod := range modifiers {
+		mod(event)
+	}
+
+	return event
+}
+
+func SetIssueCommentEventBody(body string) IssueCommentEventModifier {
+	return func(event *github.IssueCommentEvent) {
+		event.Comment.Body = pointer.StringPtr(body)
+	}
+}
+
+func SetIssueCommentEventAuthorAssociation(association string) IssueCommentEventModifier {
+	return func(event *github.IssueCommentEvent) {
+		event.Comment.AuthorAssociation = pointer.StringPtr(association)
+	}
+}
+
+func UnsetIssueCommentEventPullRequest() IssueCommentEventModifier {
+	return func(event *github.IssueCommentEvent) {
+		event.Issue.PullRequestLinks = nil
+	}
+}
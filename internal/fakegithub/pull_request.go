@@ -36,6 +36,17 @@ func NewPullRequestEvent(modifiers ...PullRequestEventModifier) *github.PullRequ
 	return event
 }
 
+// NewPullRequest returns the pull request of NewPullRequestEvent, which is
+// returned from the API.
+func NewPullRequest(modifiers ...PullRequestEventModifier) *github.PullRequest {
+	event := NewPullRequestEvent(modifiers...)
+	event.PullRequest.Number = event.Number
+	event.PullRequest.State = pointer.StringPtr("open")
+	event.PullRequest.Head.Label = pointer.StringPtr("foo:test")
+
+	return event.PullRequest
+}
+
 func SetPullRequestEventAction(action string) PullRequestEventModifier {
 	return func(event *github.PullRequestEvent) {
 		event.Action = pointer.StringPtr(action)
@@ -63,3 +74,23 @@ func SetPullRequestEventTriggeredLabel(label string) PullRequestEventModifier {
 		event.Label = NewLabel(SetLabelName(label))
 	}
 }
+
+// SetPullRequestEventFork sets the head repository to a fork owned by owner.
+func SetPullRequestEventFork(owner string) PullRequestEventModifier {
+	return func(event *github.PullRequestEvent) {
+		event.PullRequest.Base.Repo = event.Repo
+		event.PullRequest.Head.Repo = &github.Repository{
+			Name:     event.Repo.Name,
+			FullName: pointer.StringPtr(owner + "/" + event.Repo.GetName()),
+			Owner:    &github.User{Login: pointer.StringPtr(owner)},
+			Fork:     pointer.BoolPtr(true),
+		}
+	}
+}
+
+func SetPullRequestEventAuthor(login, association string) PullRequestEventModifier {
+	return func(event *github.PullRequestEvent) {
+		event.PullRequest.User = &github.User{Login: pointer.StringPtr(login)}
+		event.PullRequest.AuthorAssociation = pointer.StringPtr(association)
+	}
+}
@@ -1,6 +1,8 @@
 package fakegithub
 
 import (
+	"strings"
+
 	"github.com/google/go-github/v32/github"
 	"k8s.io/utils/pointer"
 )
@@ -38,3 +40,33 @@ func SetPushEventTag(tag string) PushEventModifier {
 		event.Ref = pointer.StringPtr("refs/tags/" + tag)
 	}
 }
+
+func SetPushEventInstallation(id int64) PushEventModifier {
+	return func(event *github.PushEvent) {
+		event.Installation = &github.Installation{ID: pointer.Int64Ptr(id)}
+	}
+}
+
+func SetPushEventDeleted() PushEventModifier {
+	return func(event *github.PushEvent) {
+		event.Deleted = pointer.BoolPtr(true)
+	}
+}
+
+func SetPushEventModifiedFiles(files ...string) PushEventModifier {
+	return func(event *github.PushEvent) {
+		event.Commits = []*github.HeadCommit{
+			{Modified: files},
+		}
+		event.Size = github.Int(1)
+	}
+}
+
+func SetPushEventRepository(fullName string) PushEventModifier {
+	return func(event *github.PushEvent) {
+		chunks := strings.SplitN(fullName, "/", 2)
+		event.Repo.FullName = pointer.StringPtr(fullName)
+		event.Repo.Name = pointer.StringPtr(chunks[1])
+		event.Repo.Owner = &github.User{Login: pointer.StringPtr(chunks[0])}
+	}
+}
@@ -0,0 +1,44 @@
+package fakegithub
+
+import (
+	"github.com/google/go-github/v32/github"
+	"k8s.io/utils/pointer"
+)
+
+type ReleaseEventModifier func(event *github.ReleaseEvent)
+
+func NewReleaseEvent(modifiers ...ReleaseEventModifier) *github.ReleaseEvent {
+	event := &github.ReleaseEvent{
+		Action: pointer.StringPtr("published"),
+		Release: &github.RepositoryRelease{
+			TagName:    pointer.StringPtr("v1.0.0"),
+			Draft:      pointer.BoolPtr(false),
+			Prerelease: pointer.BoolPtr(false),
+		},
+		Repo: newRepository(),
+	}
+
+	for _, mod := range modifiers {
+		mod(event)
+	}
+
+	return event
+}
+
+func SetReleaseEventAction(action string) ReleaseEventModifier {
+	return func(event *github.ReleaseEvent) {
+		event.Action = pointer.StringPtr(action)
+	}
+}
+
+func SetReleaseEventTag(tag string) ReleaseEventModifier {
+	return func(event *github.ReleaseEvent) {
+		event.Release.TagName = pointer.StringPtr(tag)
+	}
+}
+
+func SetReleaseEventPrerelease(prerelease bool) ReleaseEventModifier {
+	return func(event *github.ReleaseEvent) {
+		event.Release.Prerelease = pointer.BoolPtr(prerelease)
+	}
+}
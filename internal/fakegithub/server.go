@@ -0,0 +1,409 @@
+package fakegithub
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v32/github"
+	"github.com/tommy351/pullup/internal/githubapp"
+)
+
+const (
+	appID          = 46
+	installationID = 99
+
+	// BotLogin is the login of the bot account of the App.
+	BotLogin = appSlug + "[bot]"
+	appSlug  = "pullup"
+)
+
+// nolint: gochecknoglobals
+var (
+	installationPattern     = regexp.MustCompile(`^/repos/([^/]+)/([^/]+)/installation$`)
+	accessTokenPattern      = regexp.MustCompile(`^/app/installations/(\d+)/access_tokens$`)
+	deploymentsPattern      = regexp.MustCompile(`^/repos/([^/]+)/([^/]+)/deployments$`)
+	deploymentStatusPattern = regexp.MustCompile(`^/repos/([^/]+)/([^/]+)/deployments/(\d+)/statuses$`)
+	issueCommentsPattern    = regexp.MustCompile(`^/repos/([^/]+)/([^/]+)/issues/(\d+)/comments$`)
+	issueCommentPattern     = regexp.MustCompile(`^/repos/([^/]+)/([^/]+)/issues/comments/(\d+)$`)
+	issueLabelPattern       = regexp.MustCompile(`^/repos/([^/]+)/([^/]+)/issues/(\d+)/labels/([^/]+)$`)
+	pullRequestsPattern     = regexp.MustCompile(`^/repos/([^/]+)/([^/]+)/pulls$`)
+	pullRequestPattern      = regexp.MustCompile(`^/repos/([^/]+)/([^/]+)/pulls/(\d+)$`)
+	pullRequestFilesPattern = regexp.MustCompile(`^/repos/([^/]+)/([^/]+)/pulls/(\d+)/files$`)
+)
+
+// Server is a fake GitHub API server. It serves pull requests and their files,
+// and records deployments, deployment statuses, issue comments and removed
+// labels by GitHub App installations.
+type Server struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	deployments  []*github.Deployment
+	statuses     map[int64][]*github.DeploymentStatus
+	comments     []*issueComment
+	pullRequests map[int]*github.PullRequest
+	files        map[int][]*github.CommitFile
+	removed      map[int][]string
+}
+
+type issueComment struct {
+	Number  int
+	Comment *github.IssueComment
+}
+
+func NewServer() *Server {
+	s := &Server{
+		statuses:     map[int64][]*github.DeploymentStatus{},
+		pullRequests: map[int]*github.PullRequest{},
+		files:        map[int][]*github.CommitFile{},
+		removed:      map[int][]string{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+
+	return s
+}
+
+// NewAppClient returns a GitHub App client which sends requests to the server.
+func (s *Server) NewAppClient() (*githubapp.Client, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate a private key: %w", err)
+	}
+
+	return githubapp.NewClient(githubapp.Config{
+		ID: appID,
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(key),
+		})),
+		BaseURL: s.URL,
+	})
+}
+
+// Deployments returns created deployments.
+func (s *Server) Deployments() []*github.Deployment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*github.Deployment{}, s.deployments...)
+}
+
+// AddDeployment adds a deployment. The ID is set by the server.
+func (s *Server) AddDeployment(deployment *github.Deployment) *github.Deployment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deployment.ID = github.Int64(int64(len(s.deployments) + 1))
+	s.deployments = append(s.deployments, deployment)
+
+	return deployment
+}
+
+// DeploymentStatuses returns statuses of a deployment.
+func (s *Server) DeploymentStatuses(id int64) []*github.DeploymentStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*github.DeploymentStatus{}, s.statuses[id]...)
+}
+
+// AddPullRequest adds a pull request.
+func (s *Server) AddPullRequest(pr *github.PullRequest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pullRequests[pr.GetNumber()] = pr
+}
+
+// SetPullRequestFiles sets changed files of a pull request.
+func (s *Server) SetPullRequestFiles(number int, names ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	files := make([]*github.CommitFile, len(names))
+
+	for i, name := range names {
+		files[i] = &github.CommitFile{Filename: github.String(name)}
+	}
+
+	s.files[number] = files
+}
+
+// AddComment adds a comment of the App to an issue.
+func (s *Server) AddComment(number int, body string) *github.IssueComment {
+	return s.AddUserComment(number, BotLogin, body)
+}
+
+// AddUserComment adds a comment of a user to an issue.
+func (s *Server) AddUserComment(number int, login, body string) *github.IssueComment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	comment := &github.IssueComment{
+		ID:   github.Int64(int64(len(s.comments) + 1)),
+		Body: github.String(body),
+		User: &github.User{Login: github.String(login)},
+	}
+	s.comments = append(s.comments, &issueComment{Number: number, Comment: comment})
+
+	return comment
+}
+
+// Comments returns comments of an issue.
+func (s *Server) Comments(number int) []*github.IssueComment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result []*github.IssueComment
+
+	for _, c := range s.comments {
+		if c.Number == number {
+			result = append(result, c.Comment)
+		}
+	}
+
+	return result
+}
+
+// RemovedLabels returns labels removed from an issue.
+func (s *Server) RemovedLabels(number int) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string{}, s.removed[number]...)
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Path
+
+	switch {
+	case r.Method == http.MethodGet && installationPattern.MatchString(path):
+		writeJSON(w, http.StatusOK, &github.Installation{
+			ID: github.Int64(installationID),
+		})
+
+	case r.Method == http.MethodGet && path == "/app":
+		writeJSON(w, http.StatusOK, &github.App{
+			ID:   github.Int64(appID),
+			Slug: github.String(appSlug),
+		})
+
+	case r.Method == http.MethodPost && accessTokenPattern.MatchString(path):
+		expiresAt := time.Now().Add(time.Hour)
+		writeJSON(w, http.StatusCreated, &github.InstallationToken{
+			Token:     github.String("installation-token"),
+			ExpiresAt: &expiresAt,
+		})
+
+	case r.Method == http.MethodGet && deploymentsPattern.MatchString(path):
+		s.listDeployments(w, r)
+
+	case r.Method == http.MethodPost && deploymentsPattern.MatchString(path):
+		s.createDeployment(w, r)
+
+	case r.Method == http.MethodPost && deploymentStatusPattern.MatchString(path):
+		s.createDeploymentStatus(w, r)
+
+	case r.Method == http.MethodGet && pullRequestsPattern.MatchString(path):
+		s.listPullRequests(w, r)
+
+	case r.Method == http.MethodGet && pullRequestPattern.MatchString(path):
+		s.getPullRequest(w, r)
+
+	case r.Method == http.MethodGet && pullRequestFilesPattern.MatchString(path):
+		s.mu.Lock()
+		files := append([]*github.CommitFile{}, s.files[parseNumber(pullRequestFilesPattern, path)]...)
+		s.mu.Unlock()
+
+		writeJSON(w, http.StatusOK, files)
+
+	case r.Method == http.MethodGet && issueCommentsPattern.MatchString(path):
+		writeJSON(w, http.StatusOK, s.Comments(parseNumber(issueCommentsPattern, path)))
+
+	case r.Method == http.MethodPost && issueCommentsPattern.MatchString(path):
+		s.createComment(w, r)
+
+	case r.Method == http.MethodPatch && issueCommentPattern.MatchString(path):
+		s.editComment(w, r)
+
+	case r.Method == http.MethodDelete && issueLabelPattern.MatchString(path):
+		s.removeLabel(w, r)
+
+	default:
+		writeJSON(w, http.StatusNotFound, &github.ErrorResponse{
+			Message: "Not Found",
+		})
+	}
+}
+
+func (s *Server) createDeployment(w http.ResponseWriter, r *http.Request) {
+	var req github.DeploymentRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, &github.ErrorResponse{Message: err.Error()})
+
+		return
+	}
+
+	payload, err := json.Marshal(req.Payload)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, &github.ErrorResponse{Message: err.Error()})
+
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, s.AddDeployment(&github.Deployment{
+		SHA:         req.Ref,
+		Ref:         req.Ref,
+		Task:        req.Task,
+		Payload:     payload,
+		Environment: req.Environment,
+		Description: req.Description,
+	}))
+}
+
+func (s *Server) listDeployments(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	result := []*github.Deployment{}
+
+	s.mu.Lock()
+	for _, d := range s.deployments {
+		if matchQuery(query.Get("sha"), d.GetSHA()) && matchQuery(query.Get("task"), d.GetTask()) && matchQuery(query.Get("environment"), d.GetEnvironment()) {
+			result = append(result, d)
+		}
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func matchQuery(query, value string) bool {
+	return query == "" || query == value
+}
+
+func (s *Server) createDeploymentStatus(w http.ResponseWriter, r *http.Request) {
+	var req github.DeploymentStatusRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, &github.ErrorResponse{Message: err.Error()})
+
+		return
+	}
+
+	id := int64(parseNumber(deploymentStatusPattern, r.URL.Path))
+
+	s.mu.Lock()
+	status := &github.DeploymentStatus{
+		ID:             github.Int64(int64(len(s.statuses[id]) + 1)),
+		State:          req.State,
+		Description:    req.Description,
+		EnvironmentURL: req.EnvironmentURL,
+	}
+	s.statuses[id] = append(s.statuses[id], status)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, status)
+}
+
+func (s *Server) getPullRequest(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	pr, ok := s.pullRequests[parseNumber(pullRequestPattern, r.URL.Path)]
+	s.mu.Unlock()
+
+	if !ok {
+		writeJSON(w, http.StatusNotFound, &github.ErrorResponse{Message: "Not Found"})
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, pr)
+}
+
+func (s *Server) listPullRequests(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	state := query.Get("state")
+	head := query.Get("head")
+	result := []*github.PullRequest{}
+
+	s.mu.Lock()
+	for _, pr := range s.pullRequests {
+		if (state == "" || state == "all" || pr.GetState() == state) && (head == "" || pr.GetHead().GetLabel() == head) {
+			result = append(result, pr)
+		}
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) createComment(w http.ResponseWriter, r *http.Request) {
+	var req github.IssueComment
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, &github.ErrorResponse{Message: err.Error()})
+
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, s.AddComment(parseNumber(issueCommentsPattern, r.URL.Path), req.GetBody()))
+}
+
+func (s *Server) editComment(w http.ResponseWriter, r *http.Request) {
+	var req github.IssueComment
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, &github.ErrorResponse{Message: err.Error()})
+
+		return
+	}
+
+	id := int64(parseNumber(issueCommentPattern, r.URL.Path))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, c := range s.comments {
+		if c.Comment.GetID() == id {
+			c.Comment.Body = req.Body
+			writeJSON(w, http.StatusOK, c.Comment)
+
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusNotFound, &github.ErrorResponse{Message: "Not Found"})
+}
+
+func (s *Server) removeLabel(w http.ResponseWriter, r *http.Request) {
+	matches := issueLabelPattern.FindStringSubmatch(r.URL.Path)
+	number, _ := strconv.Atoi(matches[3])
+
+	s.mu.Lock()
+	s.removed[number] = append(s.removed[number], matches[4])
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, []*github.Label{})
+}
+
+func parseNumber(pattern *regexp.Regexp, path string) int {
+	matches := pattern.FindStringSubmatch(path)
+	n, _ := strconv.Atoi(matches[len(matches)-1])
+
+	return n
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
@@ -0,0 +1,54 @@
+package fakegitlab
+
+import "github.com/tommy351/pullup/internal/gitlab"
+
+type MergeRequestEventModifier func(event *gitlab.MergeRequestEvent)
+
+func NewMergeRequestEvent(modifiers ...MergeRequestEventModifier) *gitlab.MergeRequestEvent {
+	event := &gitlab.MergeRequestEvent{
+		ObjectKind: "merge_request",
+		Project:    NewProject(),
+		ObjectAttributes: &gitlab.MergeRequestAttributes{
+			ID:              99,
+			IID:             46,
+			Title:           "Test merge request",
+			State:           "opened",
+			Action:          "open",
+			SourceBranch:    "test",
+			SourceProjectID: 15,
+			TargetBranch:    "base",
+			TargetProjectID: 15,
+			LastCommit: &gitlab.Commit{
+				ID: "0ce4cf0450de14c6555c563fa9d36be67e69aa2f",
+			},
+		},
+	}
+
+	for _, mod := range modifiers {
+		mod(event)
+	}
+
+	return event
+}
+
+func SetMergeRequestEventAction(action string) MergeRequestEventModifier {
+	return func(event *gitlab.MergeRequestEvent) {
+		event.ObjectAttributes.Action = action
+	}
+}
+
+func SetMergeRequestEventBranch(branch string) MergeRequestEventModifier {
+	return func(event *gitlab.MergeRequestEvent) {
+		event.ObjectAttributes.TargetBranch = branch
+	}
+}
+
+func SetMergeRequestEventLabels(labels []string) MergeRequestEventModifier {
+	return func(event *gitlab.MergeRequestEvent) {
+		event.Labels = make([]*gitlab.Label, len(labels))
+
+		for i, label := range labels {
+			event.Labels[i] = &gitlab.Label{Title: label}
+		}
+	}
+}
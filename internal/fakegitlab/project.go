@@ -0,0 +1,14 @@
+package fakegitlab
+
+import "github.com/tommy351/pullup/internal/gitlab"
+
+func NewProject() *gitlab.Project {
+	return &gitlab.Project{
+		ID:                15,
+		Name:              "bar",
+		Namespace:         "foo",
+		PathWithNamespace: "foo/bar",
+		WebURL:            "https://gitlab.com/foo/bar",
+		DefaultBranch:     "master",
+	}
+}
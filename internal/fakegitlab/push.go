@@ -0,0 +1,36 @@
+package fakegitlab
+
+import "github.com/tommy351/pullup/internal/gitlab"
+
+type PushEventModifier func(event *gitlab.PushEvent)
+
+func NewPushEvent(modifiers ...PushEventModifier) *gitlab.PushEvent {
+	event := &gitlab.PushEvent{
+		ObjectKind:  "push",
+		Before:      "b436f6eb3356504235c0c9a8e74605c820d8d9cc",
+		After:       "0ce4cf0450de14c6555c563fa9d36be67e69aa2f",
+		CheckoutSHA: "0ce4cf0450de14c6555c563fa9d36be67e69aa2f",
+		Ref:         "refs/heads/test",
+		ProjectID:   15,
+		Project:     NewProject(),
+	}
+
+	for _, mod := range modifiers {
+		mod(event)
+	}
+
+	return event
+}
+
+func SetPushEventBranch(branch string) PushEventModifier {
+	return func(event *gitlab.PushEvent) {
+		event.Ref = "refs/heads/" + branch
+	}
+}
+
+func SetPushEventTag(tag string) PushEventModifier {
+	return func(event *gitlab.PushEvent) {
+		event.ObjectKind = "tag_push"
+		event.Ref = "refs/tags/" + tag
+	}
+}
@@ -0,0 +1,153 @@
+package gitea
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Gitea sends both X-Gitea-* and X-Gogs-* headers, and Forgejo additionally
+// sends X-Forgejo-* headers. The Gitea headers are checked first.
+const (
+	EventTypeHeader        = "X-Gitea-Event"
+	SignatureHeader        = "X-Gitea-Signature"
+	ForgejoEventTypeHeader = "X-Forgejo-Event"
+	ForgejoSignatureHeader = "X-Forgejo-Signature"
+)
+
+const (
+	PushEventType        = "push"
+	PullRequestEventType = "pull_request"
+)
+
+var ErrUnknownEventType = errors.New("unknown event type")
+
+type User struct {
+	ID       int64  `json:"id"`
+	Login    string `json:"login"`
+	FullName string `json:"full_name,omitempty"`
+	Email    string `json:"email,omitempty"`
+	UserName string `json:"username,omitempty"`
+}
+
+type Repository struct {
+	ID            int64  `json:"id"`
+	Owner         *User  `json:"owner,omitempty"`
+	Name          string `json:"name"`
+	FullName      string `json:"full_name"`
+	Private       bool   `json:"private,omitempty"`
+	Fork          bool   `json:"fork,omitempty"`
+	HTMLURL       string `json:"html_url,omitempty"`
+	CloneURL      string `json:"clone_url,omitempty"`
+	DefaultBranch string `json:"default_branch,omitempty"`
+}
+
+type CommitUser struct {
+	Name     string `json:"name,omitempty"`
+	Email    string `json:"email,omitempty"`
+	UserName string `json:"username,omitempty"`
+}
+
+type Commit struct {
+	ID        string      `json:"id"`
+	Message   string      `json:"message,omitempty"`
+	URL       string      `json:"url,omitempty"`
+	Author    *CommitUser `json:"author,omitempty"`
+	Committer *CommitUser `json:"committer,omitempty"`
+	Timestamp string      `json:"timestamp,omitempty"`
+	Added     []string    `json:"added,omitempty"`
+	Removed   []string    `json:"removed,omitempty"`
+	Modified  []string    `json:"modified,omitempty"`
+}
+
+// PushEvent is sent when commits are pushed to a branch or a tag.
+type PushEvent struct {
+	Ref        string      `json:"ref"`
+	Before     string      `json:"before"`
+	After      string      `json:"after"`
+	CompareURL string      `json:"compare_url,omitempty"`
+	Commits    []*Commit   `json:"commits,omitempty"`
+	HeadCommit *Commit     `json:"head_commit,omitempty"`
+	Repository *Repository `json:"repository"`
+	Pusher     *User       `json:"pusher,omitempty"`
+	Sender     *User       `json:"sender,omitempty"`
+}
+
+type Label struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color,omitempty"`
+}
+
+type PullRequestBranch struct {
+	Label  string      `json:"label,omitempty"`
+	Ref    string      `json:"ref"`
+	Sha    string      `json:"sha"`
+	RepoID int64       `json:"repo_id,omitempty"`
+	Repo   *Repository `json:"repo,omitempty"`
+}
+
+type PullRequest struct {
+	ID             int64              `json:"id"`
+	Number         int64              `json:"number"`
+	URL            string             `json:"url,omitempty"`
+	HTMLURL        string             `json:"html_url,omitempty"`
+	User           *User              `json:"user,omitempty"`
+	Title          string             `json:"title,omitempty"`
+	Body           string             `json:"body,omitempty"`
+	Labels         []*Label           `json:"labels,omitempty"`
+	State          string             `json:"state,omitempty"`
+	Merged         bool               `json:"merged"`
+	MergedCommitID string             `json:"merge_commit_sha,omitempty"`
+	Base           *PullRequestBranch `json:"base"`
+	Head           *PullRequestBranch `json:"head"`
+}
+
+// PullRequestEvent is sent when a pull request is changed.
+type PullRequestEvent struct {
+	Action      string          `json:"action"`
+	Number      int64           `json:"number"`
+	Changes     json.RawMessage `json:"changes,omitempty"`
+	PullRequest *PullRequest    `json:"pull_request"`
+	Repository  *Repository     `json:"repository"`
+	Sender      *User           `json:"sender,omitempty"`
+}
+
+// WebhookType returns the event type of the webhook request.
+func WebhookType(r *http.Request) string {
+	if v := r.Header.Get(EventTypeHeader); v != "" {
+		return v
+	}
+
+	return r.Header.Get(ForgejoEventTypeHeader)
+}
+
+// WebhookSignature returns the signature of the webhook request.
+func WebhookSignature(r *http.Request) string {
+	if v := r.Header.Get(SignatureHeader); v != "" {
+		return v
+	}
+
+	return r.Header.Get(ForgejoSignatureHeader)
+}
+
+// ParseWebhook parses the payload into a struct based on the event type.
+func ParseWebhook(eventType string, payload []byte) (interface{}, error) {
+	var event interface{}
+
+	switch eventType {
+	case PushEventType:
+		event = new(PushEvent)
+	case PullRequestEventType:
+		event = new(PullRequestEvent)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownEventType, eventType)
+	}
+
+	if err := json.Unmarshal(payload, event); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	return event, nil
+}
@@ -0,0 +1,261 @@
+package githubapp
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/form3tech-oss/jwt-go"
+	"github.com/google/go-github/v32/github"
+)
+
+type Config struct {
+	ID             int64  `mapstructure:"id"`
+	PrivateKey     string `mapstructure:"privateKey"`
+	PrivateKeyFile string `mapstructure:"privateKeyFile"`
+	WebhookSecret  string `mapstructure:"webhookSecret"`
+	BaseURL        string `mapstructure:"baseUrl"`
+}
+
+// Client authenticates as a GitHub App and creates API clients for its
+// installations.
+type Client struct {
+	config Config
+	app    *github.Client
+
+	mu            sync.Mutex
+	tokens        map[int64]*github.InstallationToken
+	tokenLocks    map[int64]*sync.Mutex
+	installations map[string]int64
+	botLogin      string
+}
+
+// NewClient returns a new client. It returns nil when the App ID is not set.
+func NewClient(conf Config) (*Client, error) {
+	if conf.ID == 0 {
+		return nil, nil
+	}
+
+	key, err := loadPrivateKey(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	app, err := NewGitHubClient(&http.Client{
+		Transport: &appTransport{
+			appID: conf.ID,
+			key:   key,
+			base:  http.DefaultTransport,
+		},
+	}, conf.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		config:        conf,
+		app:           app,
+		tokens:        map[int64]*github.InstallationToken{},
+		tokenLocks:    map[int64]*sync.Mutex{},
+		installations: map[string]int64{},
+	}, nil
+}
+
+// NewGitHubClient returns a GitHub client. The default API URL is used when
+// baseURL is empty.
+func NewGitHubClient(httpClient *http.Client, baseURL string) (*github.Client, error) {
+	client := github.NewClient(httpClient)
+
+	if baseURL != "" {
+		if !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the base URL: %w", err)
+		}
+
+		client.BaseURL = u
+	}
+
+	return client, nil
+}
+
+func loadPrivateKey(conf Config) (*rsa.PrivateKey, error) {
+	data := []byte(conf.PrivateKey)
+
+	if len(data) == 0 && conf.PrivateKeyFile != "" {
+		var err error
+
+		if data, err = ioutil.ReadFile(conf.PrivateKeyFile); err != nil {
+			return nil, fmt.Errorf("failed to read the private key file: %w", err)
+		}
+	}
+
+	key, err := jwt.ParseRSAPrivateKeyFromPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the private key: %w", err)
+	}
+
+	return key, nil
+}
+
+// WebhookSecret returns the webhook secret of the App.
+func (c *Client) WebhookSecret() []byte {
+	return []byte(c.config.WebhookSecret)
+}
+
+// AppClient returns a GitHub client authenticated as the App.
+func (c *Client) AppClient() *github.Client {
+	return c.app
+}
+
+// BotLogin returns the login of the bot account of the App, which is the slug
+// of the App followed by "[bot]".
+func (c *Client) BotLogin(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	login := c.botLogin
+	c.mu.Unlock()
+
+	if login != "" {
+		return login, nil
+	}
+
+	app, _, err := c.app.Apps.Get(ctx, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to get the App: %w", err)
+	}
+
+	login = app.GetSlug() + "[bot]"
+
+	c.mu.Lock()
+	c.botLogin = login
+	c.mu.Unlock()
+
+	return login, nil
+}
+
+// InstallationClient returns a GitHub client authenticated as an
+// installation.
+func (c *Client) InstallationClient(id int64) (*github.Client, error) {
+	return NewGitHubClient(&http.Client{
+		Transport: &installationTransport{
+			client:         c,
+			installationID: id,
+			base:           http.DefaultTransport,
+		},
+	}, c.config.BaseURL)
+}
+
+// RepositoryClient returns a GitHub client authenticated as the installation
+// which can access the repository.
+func (c *Client) RepositoryClient(ctx context.Context, owner, repo string) (*github.Client, error) {
+	id, err := c.findInstallation(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.InstallationClient(id)
+}
+
+func (c *Client) findInstallation(ctx context.Context, owner, repo string) (int64, error) {
+	name := owner + "/" + repo
+
+	c.mu.Lock()
+	id, ok := c.installations[name]
+	c.mu.Unlock()
+
+	if ok {
+		return id, nil
+	}
+
+	installation, _, err := c.app.Apps.FindRepositoryInstallation(ctx, owner, repo)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find the installation of repository %q: %w", name, err)
+	}
+
+	c.SetRepositories(installation.GetID(), name)
+
+	return installation.GetID(), nil
+}
+
+// SetRepositories records the repositories that the installation can access.
+func (c *Client) SetRepositories(id int64, names ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, name := range names {
+		c.installations[name] = id
+	}
+}
+
+// RemoveRepositories removes repositories from the installation.
+func (c *Client) RemoveRepositories(names ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, name := range names {
+		delete(c.installations, name)
+	}
+}
+
+// RemoveInstallation removes all records of the installation.
+func (c *Client) RemoveInstallation(id int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.tokens, id)
+
+	for name, v := range c.installations {
+		if v == id {
+			delete(c.installations, name)
+		}
+	}
+}
+
+func (c *Client) getInstallationToken(ctx context.Context, id int64) (string, error) {
+	// Tokens are created while holding the lock of the installation only, so a
+	// slow request does not block other installations.
+	lock := c.getTokenLock(id)
+	lock.Lock()
+	defer lock.Unlock()
+
+	c.mu.Lock()
+	token, ok := c.tokens[id]
+	c.mu.Unlock()
+
+	if ok && !tokenExpired(token) {
+		return token.GetToken(), nil
+	}
+
+	token, _, err := c.app.Apps.CreateInstallationToken(ctx, id, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create an installation token: %w", err)
+	}
+
+	c.mu.Lock()
+	c.tokens[id] = token
+	c.mu.Unlock()
+
+	return token.GetToken(), nil
+}
+
+func (c *Client) getTokenLock(id int64) *sync.Mutex {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	lock, ok := c.tokenLocks[id]
+
+	if !ok {
+		lock = new(sync.Mutex)
+		c.tokenLocks[id] = lock
+	}
+
+	return lock
+}
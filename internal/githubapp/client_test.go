@@ -0,0 +1,199 @@
+package githubapp
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/form3tech-oss/jwt-go"
+	"github.com/google/go-github/v32/github"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Client", func() {
+	var (
+		key        *rsa.PrivateKey
+		server     *httptest.Server
+		client     *Client
+		tokenCount int
+		appCount   int
+		expiresAt  time.Time
+		authHeader string
+		blocked    chan struct{}
+	)
+
+	encodeKey := func(key *rsa.PrivateKey) string {
+		return string(pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(key),
+		}))
+	}
+
+	verifyJWT := func(r *http.Request) {
+		token, err := jwt.ParseWithClaims(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), &jwt.StandardClaims{}, func(*jwt.Token) (interface{}, error) {
+			return &key.PublicKey, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token.Claims.(*jwt.StandardClaims).Issuer).To(Equal("46"))
+	}
+
+	BeforeEach(func() {
+		var err error
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).NotTo(HaveOccurred())
+
+		tokenCount = 0
+		appCount = 0
+		expiresAt = time.Now().Add(time.Hour)
+		blocked = make(chan struct{})
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/repos/foo/bar/installation", func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			verifyJWT(r)
+			Expect(json.NewEncoder(w).Encode(&github.Installation{ID: github.Int64(99)})).To(Succeed())
+		})
+		mux.HandleFunc("/app/installations/99/access_tokens", func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			verifyJWT(r)
+			Expect(r.Method).To(Equal(http.MethodPost))
+			tokenCount++
+			Expect(json.NewEncoder(w).Encode(&github.InstallationToken{
+				Token:     github.String("installation-token"),
+				ExpiresAt: &expiresAt,
+			})).To(Succeed())
+		})
+		mux.HandleFunc("/app/installations/100/access_tokens", func(w http.ResponseWriter, r *http.Request) {
+			<-blocked
+			w.WriteHeader(http.StatusNotFound)
+		})
+		mux.HandleFunc("/app", func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			verifyJWT(r)
+			appCount++
+			Expect(json.NewEncoder(w).Encode(&github.App{Slug: github.String("pullup")})).To(Succeed())
+		})
+		mux.HandleFunc("/repos/foo/bar", func(w http.ResponseWriter, r *http.Request) {
+			authHeader = r.Header.Get("Authorization")
+			Expect(json.NewEncoder(w).Encode(&github.Repository{FullName: github.String("foo/bar")})).To(Succeed())
+		})
+		server = httptest.NewServer(mux)
+	})
+
+	AfterEach(func() {
+		close(blocked)
+		server.Close()
+	})
+
+	Describe("NewClient", func() {
+		It("should return nil when ID is not set", func() {
+			Expect(NewClient(Config{})).To(BeNil())
+		})
+
+		It("should return an error when the private key is invalid", func() {
+			_, err := NewClient(Config{ID: 46, PrivateKey: "foo"})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("BotLogin", func() {
+		JustBeforeEach(func() {
+			var err error
+			client, err = NewClient(Config{
+				ID:         46,
+				PrivateKey: encodeKey(key),
+				BaseURL:    server.URL,
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should return the login of the bot account", func() {
+			Expect(client.BotLogin(context.Background())).To(Equal("pullup[bot]"))
+		})
+
+		It("should reuse the login", func() {
+			Expect(client.BotLogin(context.Background())).To(Equal("pullup[bot]"))
+			Expect(client.BotLogin(context.Background())).To(Equal("pullup[bot]"))
+			Expect(appCount).To(Equal(1))
+		})
+	})
+
+	Describe("RepositoryClient", func() {
+		getRepository := func() {
+			gh, err := client.RepositoryClient(context.Background(), "foo", "bar")
+			Expect(err).NotTo(HaveOccurred())
+
+			repo, _, err := gh.Repositories.Get(context.Background(), "foo", "bar")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(repo.GetFullName()).To(Equal("foo/bar"))
+		}
+
+		JustBeforeEach(func() {
+			var err error
+			client, err = NewClient(Config{
+				ID:         46,
+				PrivateKey: encodeKey(key),
+				BaseURL:    server.URL,
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should authenticate as the installation", func() {
+			getRepository()
+			Expect(authHeader).To(Equal("token installation-token"))
+		})
+
+		It("should reuse the installation token", func() {
+			getRepository()
+			getRepository()
+			Expect(tokenCount).To(Equal(1))
+		})
+
+		When("the token is expired", func() {
+			BeforeEach(func() {
+				expiresAt = time.Now().Add(time.Second)
+			})
+
+			It("should refresh the token", func() {
+				getRepository()
+				getRepository()
+				Expect(tokenCount).To(Equal(2))
+			})
+		})
+
+		When("the installation is removed", func() {
+			It("should create a new token", func() {
+				getRepository()
+				client.RemoveInstallation(99)
+				getRepository()
+				Expect(tokenCount).To(Equal(2))
+			})
+		})
+
+		When("creating a token of another installation is slow", func() {
+			It("should not block", func() {
+				go func() {
+					_, _ = client.getInstallationToken(context.Background(), 100)
+				}()
+
+				done := make(chan struct{})
+
+				go func() {
+					defer GinkgoRecover()
+					defer close(done)
+					getRepository()
+				}()
+
+				Eventually(done).Should(BeClosed())
+			})
+		})
+	})
+})
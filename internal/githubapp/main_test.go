@@ -0,0 +1,11 @@
+package githubapp
+
+import (
+	"testing"
+
+	"github.com/tommy351/pullup/internal/testutil"
+)
+
+func Test(t *testing.T) {
+	testutil.RunSpecs(t, "githubapp")
+}
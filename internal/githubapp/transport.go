@@ -0,0 +1,84 @@
+package githubapp
+
+import (
+	"crypto/rsa"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/form3tech-oss/jwt-go"
+	"github.com/google/go-github/v32/github"
+)
+
+const (
+	// GitHub rejects JWTs which expire more than 10 minutes in the future.
+	jwtExpiration = 9 * time.Minute
+
+	// Refresh installation tokens a bit earlier to avoid using expired
+	// tokens because of clock skew.
+	tokenExpirationBuffer = time.Minute
+)
+
+// nolint: gochecknoglobals
+var now = time.Now
+
+type appTransport struct {
+	appID int64
+	key   *rsa.PrivateKey
+	base  http.RoundTripper
+}
+
+func (t *appTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.signToken()
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	return t.base.RoundTrip(req)
+}
+
+func (t *appTransport) signToken() (string, error) {
+	issuedAt := now().Add(-time.Minute)
+	claims := jwt.StandardClaims{
+		IssuedAt:  issuedAt.Unix(),
+		ExpiresAt: issuedAt.Add(jwtExpiration).Unix(),
+		Issuer:    strconv.FormatInt(t.appID, 10),
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(t.key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign the token: %w", err)
+	}
+
+	return token, nil
+}
+
+type installationTransport struct {
+	client         *Client
+	installationID int64
+	base           http.RoundTripper
+}
+
+func (t *installationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.client.getInstallationToken(req.Context(), t.installationID)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "token "+token)
+
+	return t.base.RoundTrip(req)
+}
+
+func tokenExpired(token *github.InstallationToken) bool {
+	if token.ExpiresAt == nil {
+		return false
+	}
+
+	return now().Add(tokenExpirationBuffer).After(*token.ExpiresAt)
+}
@@ -0,0 +1,137 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+const (
+	EventTypeHeader = "X-Gitlab-Event"
+	TokenHeader     = "X-Gitlab-Token"
+)
+
+const (
+	PushHook         = "Push Hook"
+	TagPushHook      = "Tag Push Hook"
+	MergeRequestHook = "Merge Request Hook"
+)
+
+var ErrUnknownEventType = errors.New("unknown event type")
+
+type Project struct {
+	ID                int64  `json:"id"`
+	Name              string `json:"name"`
+	Description       string `json:"description,omitempty"`
+	WebURL            string `json:"web_url,omitempty"`
+	GitSSHURL         string `json:"git_ssh_url,omitempty"`
+	GitHTTPURL        string `json:"git_http_url,omitempty"`
+	Namespace         string `json:"namespace,omitempty"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	DefaultBranch     string `json:"default_branch,omitempty"`
+}
+
+type User struct {
+	ID        int64  `json:"id,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Username  string `json:"username,omitempty"`
+	Email     string `json:"email,omitempty"`
+	AvatarURL string `json:"avatar_url,omitempty"`
+}
+
+type Author struct {
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+type Commit struct {
+	ID        string   `json:"id"`
+	Message   string   `json:"message,omitempty"`
+	Title     string   `json:"title,omitempty"`
+	Timestamp string   `json:"timestamp,omitempty"`
+	URL       string   `json:"url,omitempty"`
+	Author    *Author  `json:"author,omitempty"`
+	Added     []string `json:"added,omitempty"`
+	Modified  []string `json:"modified,omitempty"`
+	Removed   []string `json:"removed,omitempty"`
+}
+
+type Label struct {
+	ID          int64  `json:"id,omitempty"`
+	Title       string `json:"title"`
+	Color       string `json:"color,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// PushEvent is sent for both "Push Hook" and "Tag Push Hook" events.
+type PushEvent struct {
+	ObjectKind        string    `json:"object_kind"`
+	Before            string    `json:"before"`
+	After             string    `json:"after"`
+	Ref               string    `json:"ref"`
+	CheckoutSHA       string    `json:"checkout_sha,omitempty"`
+	UserID            int64     `json:"user_id,omitempty"`
+	UserName          string    `json:"user_name,omitempty"`
+	UserUsername      string    `json:"user_username,omitempty"`
+	UserEmail         string    `json:"user_email,omitempty"`
+	ProjectID         int64     `json:"project_id"`
+	Project           *Project  `json:"project"`
+	Commits           []*Commit `json:"commits,omitempty"`
+	TotalCommitsCount int       `json:"total_commits_count,omitempty"`
+}
+
+type MergeRequestAttributes struct {
+	ID              int64    `json:"id"`
+	IID             int64    `json:"iid"`
+	Title           string   `json:"title,omitempty"`
+	Description     string   `json:"description,omitempty"`
+	State           string   `json:"state,omitempty"`
+	Action          string   `json:"action,omitempty"`
+	MergeStatus     string   `json:"merge_status,omitempty"`
+	URL             string   `json:"url,omitempty"`
+	SourceBranch    string   `json:"source_branch"`
+	SourceProjectID int64    `json:"source_project_id,omitempty"`
+	TargetBranch    string   `json:"target_branch"`
+	TargetProjectID int64    `json:"target_project_id,omitempty"`
+	AuthorID        int64    `json:"author_id,omitempty"`
+	Source          *Project `json:"source,omitempty"`
+	Target          *Project `json:"target,omitempty"`
+	LastCommit      *Commit  `json:"last_commit,omitempty"`
+	WorkInProgress  bool     `json:"work_in_progress,omitempty"`
+	OldRev          string   `json:"oldrev,omitempty"`
+}
+
+type MergeRequestEvent struct {
+	ObjectKind       string                  `json:"object_kind"`
+	User             *User                   `json:"user,omitempty"`
+	Project          *Project                `json:"project"`
+	ObjectAttributes *MergeRequestAttributes `json:"object_attributes"`
+	Labels           []*Label                `json:"labels,omitempty"`
+	Changes          json.RawMessage         `json:"changes,omitempty"`
+}
+
+// WebhookType returns the event type of the webhook request.
+func WebhookType(r *http.Request) string {
+	return r.Header.Get(EventTypeHeader)
+}
+
+// ParseWebhook parses the payload into a struct based on the event type.
+func ParseWebhook(eventType string, payload []byte) (interface{}, error) {
+	var event interface{}
+
+	switch eventType {
+	case PushHook, TagPushHook:
+		event = new(PushEvent)
+	case MergeRequestHook:
+		event = new(MergeRequestEvent)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownEventType, eventType)
+	}
+
+	if err := json.Unmarshal(payload, event); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	return event, nil
+}
@@ -0,0 +1,48 @@
+package httputil
+
+import (
+	"bytes"
+	"net/http"
+)
+
+// ResponseBuffer is a http.ResponseWriter which buffers a response, so it can
+// be inspected or discarded before being written.
+type ResponseBuffer struct {
+	StatusCode int
+	Body       bytes.Buffer
+
+	header http.Header
+}
+
+func NewResponseBuffer() *ResponseBuffer {
+	return &ResponseBuffer{
+		StatusCode: http.StatusOK,
+		header:     http.Header{},
+	}
+}
+
+func (b *ResponseBuffer) Header() http.Header {
+	return b.header
+}
+
+func (b *ResponseBuffer) WriteHeader(statusCode int) {
+	b.StatusCode = statusCode
+}
+
+func (b *ResponseBuffer) Write(data []byte) (int, error) {
+	return b.Body.Write(data)
+}
+
+// WriteTo writes the buffered response to w.
+func (b *ResponseBuffer) WriteTo(w http.ResponseWriter) error {
+	header := w.Header()
+
+	for k, v := range b.header {
+		header[k] = v
+	}
+
+	w.WriteHeader(b.StatusCode)
+	_, err := b.Body.WriteTo(w)
+
+	return err
+}
@@ -0,0 +1,211 @@
+package bitbucket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/google/wire"
+	"github.com/tommy351/pullup/internal/bitbucket"
+	"github.com/tommy351/pullup/internal/httputil"
+	"github.com/tommy351/pullup/internal/log"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// +kubebuilder:rbac:groups=pullup.dev,resources=bitbucketwebhooks,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+const nameField = "spec.repositories.name"
+
+// HandlerConfigSet provides a handler config.
+// nolint: gochecknoglobals
+var HandlerConfigSet = wire.NewSet(
+	wire.Struct(new(HandlerConfig), "*"),
+)
+
+// HandlerSet provides a handler.
+// nolint: gochecknoglobals
+var HandlerSet = wire.NewSet(
+	HandlerConfigSet,
+	NewHandler,
+)
+
+type HandlerConfig struct {
+	Client         client.Client
+	TriggerHandler hookutil.TriggerHandler
+}
+
+type Handler struct {
+	HandlerConfig
+}
+
+type delivery struct {
+	Signature string
+	Body      []byte
+}
+
+func NewHandler(conf HandlerConfig, mgr manager.Manager) (*Handler, error) {
+	err := mgr.GetFieldIndexer().IndexField(context.TODO(), &v1beta1.BitbucketWebhook{}, nameField, func(obj client.Object) []string {
+		var result []string
+
+		for _, repo := range obj.(*v1beta1.BitbucketWebhook).Spec.Repositories {
+			result = append(result, repo.Name)
+		}
+
+		return result
+	})
+	if err != nil {
+		return nil, fmt.Errorf("index failed: %w", err)
+	}
+
+	return &Handler{
+		HandlerConfig: conf,
+	}, nil
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) error {
+	logger := logr.FromContextOrDiscard(r.Context())
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read body: %w", err)
+	}
+
+	payload, err := bitbucket.ParseWebhook(bitbucket.WebhookType(r), body)
+	if err != nil {
+		if errors.Is(err, bitbucket.ErrUnknownEventKey) {
+			logger.V(log.Debug).Info("Skipped unsupported event", "event", bitbucket.WebhookType(r))
+
+			return httputil.JSON(w, http.StatusOK, &httputil.Response{})
+		}
+
+		logger.Error(err, "Invalid payload")
+
+		return httputil.JSON(w, http.StatusBadRequest, &httputil.Response{
+			Errors: []httputil.Error{
+				{Description: "Invalid payload"},
+			},
+		})
+	}
+
+	d := &delivery{
+		Signature: r.Header.Get(bitbucket.SignatureHeader),
+		Body:      body,
+	}
+
+	if err := h.handlePayload(r.Context(), d, bitbucket.WebhookType(r), payload); err != nil {
+		return err
+	}
+
+	return httputil.JSON(w, http.StatusOK, &httputil.Response{})
+}
+
+func (h *Handler) handlePayload(ctx context.Context, d *delivery, eventKey string, payload interface{}) error {
+	switch event := payload.(type) {
+	case *bitbucket.PullRequestEvent:
+		if event.Repository == nil || event.PullRequest == nil {
+			return nil
+		}
+
+		return h.handlePullRequestEvent(ctx, d, event.Repository.FullName, newCloudPullRequestEvent(eventKey, event))
+
+	case *bitbucket.ServerPullRequestEvent:
+		pr := event.PullRequest
+		if pr == nil || pr.ToRef == nil || pr.ToRef.Repository == nil {
+			return nil
+		}
+
+		return h.handlePullRequestEvent(ctx, d, pr.ToRef.Repository.FullName(), newServerPullRequestEvent(eventKey, event))
+
+	case *bitbucket.PushEvent:
+		if event.Repository == nil || event.Push == nil {
+			return nil
+		}
+
+		return h.handlePushEvents(ctx, d, event.Repository.FullName, newCloudPushEvents(event))
+
+	case *bitbucket.ServerRefsChangedEvent:
+		if event.Repository == nil {
+			return nil
+		}
+
+		return h.handlePushEvents(ctx, d, event.Repository.FullName(), newServerPushEvents(event))
+	}
+
+	return nil
+}
+
+func (h *Handler) listWebhooks(ctx context.Context, d *delivery, name string) ([]v1beta1.BitbucketWebhook, error) {
+	list := new(v1beta1.BitbucketWebhookList)
+	err := h.Client.List(ctx, list, client.MatchingFields(map[string]string{
+		nameField: name,
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	// nolint: prealloc
+	var result []v1beta1.BitbucketWebhook
+
+	for _, hook := range list.Items {
+		hook := hook
+		hook.SetGroupVersionKind(v1beta1.GroupVersion.WithKind("BitbucketWebhook"))
+
+		if !h.validateSignature(ctx, d, &hook) {
+			continue
+		}
+
+		result = append(result, hook)
+	}
+
+	if len(list.Items) > 0 && len(result) == 0 {
+		return nil, httputil.Response{
+			StatusCode: http.StatusForbidden,
+			Errors: []httputil.Error{
+				{Description: "Signature mismatch"},
+			},
+		}
+	}
+
+	return result, nil
+}
+
+func (h *Handler) validateSignature(ctx context.Context, d *delivery, hook *v1beta1.BitbucketWebhook) bool {
+	logger := logr.FromContextOrDiscard(ctx).WithValues("webhook", hook)
+
+	if hook.Spec.SecretToken == nil {
+		return true
+	}
+
+	secret, err := hookutil.GetSecretValue(ctx, h.Client, hook.Namespace, hook.Spec.SecretToken)
+	if err != nil {
+		logger.Error(err, "Failed to get the secret token")
+
+		return false
+	}
+
+	if !hookutil.ValidateSignature(d.Signature, d.Body, secret) {
+		logger.V(log.Debug).Info("Signature mismatch")
+
+		return false
+	}
+
+	return true
+}
+
+func extractRepository(hook *v1beta1.BitbucketWebhook, name string) *v1beta1.BitbucketRepository {
+	for _, r := range hook.Spec.Repositories {
+		r := r
+
+		if r.Name == name {
+			return &r
+		}
+	}
+
+	return nil
+}
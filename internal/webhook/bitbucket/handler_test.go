@@ -0,0 +1,431 @@
+package bitbucket
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tommy351/pullup/internal/bitbucket"
+	"github.com/tommy351/pullup/internal/fakebitbucket"
+	"github.com/tommy351/pullup/internal/k8s"
+	"github.com/tommy351/pullup/internal/random"
+	"github.com/tommy351/pullup/internal/testenv"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Handler", func() {
+	var (
+		handler      *Handler
+		req          *http.Request
+		recorder     *httptest.ResponseRecorder
+		mgr          *testenv.Manager
+		namespaceMap *random.NamespaceMap
+	)
+
+	encodeBody := func(body interface{}) []byte {
+		data, err := json.Marshal(body)
+		Expect(err).NotTo(HaveOccurred())
+
+		return data
+	}
+
+	newRequest := func(event string, body interface{}) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(encodeBody(body)))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(bitbucket.EventKeyHeader, event)
+
+		ctx := logr.NewContext(req.Context(), log.Log)
+
+		return req.WithContext(ctx)
+	}
+
+	sign := func(body interface{}, secret string) string {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(encodeBody(body))
+
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	loadTestData := func(name string) []client.Object {
+		data, err := k8s.LoadObjects(testenv.GetScheme(), fmt.Sprintf("testdata/%s.yml", name))
+		Expect(err).NotTo(HaveOccurred())
+
+		data, err = k8s.MapObjects(data, namespaceMap.SetObject)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testenv.CreateObjects(data)).To(Succeed())
+
+		return data
+	}
+
+	getChanges := func() []testenv.Change {
+		return testenv.GetChanges(handler.Client)
+	}
+
+	loadData := func(name string) {
+		var data []client.Object
+
+		BeforeEach(func() {
+			data = loadTestData(name)
+		})
+
+		AfterEach(func() {
+			Expect(testenv.DeleteObjects(data)).To(Succeed())
+		})
+	}
+
+	testSuccess := func(name string) {
+		loadData(name)
+
+		It("should respond 200", func() {
+			Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+		})
+	}
+
+	testTriggered := func() {
+		It("should change something", func() {
+			Expect(getChanges()).NotTo(BeEmpty())
+		})
+	}
+
+	testSkipped := func() {
+		It("should not change anything", func() {
+			Expect(getChanges()).To(BeEmpty())
+		})
+	}
+
+	testCreated := func() {
+		It("should record Created event", func() {
+			Expect(mgr.WaitForEvent(testenv.EventData{
+				Type:    v1.EventTypeNormal,
+				Reason:  hookutil.ReasonCreated,
+				Message: "Created resource template: foobar",
+			})).To(BeTrue())
+		})
+	}
+
+	testDeleted := func() {
+		It("should delete the resource", func() {
+			Expect(getChanges()).To(ContainElement(testenv.Change{
+				Type: "delete",
+				NamespacedName: types.NamespacedName{
+					Name:      "foobar",
+					Namespace: namespaceMap.GetRandom("test"),
+				},
+				GroupVersionKind: v1beta1.GroupVersion.WithKind("ResourceTemplate"),
+			}))
+		})
+	}
+
+	setEvent := func(key string, event interface{}) {
+		BeforeEach(func() {
+			req = newRequest(key, event)
+		})
+	}
+
+	BeforeEach(func() {
+		var err error
+		mgr, err = testenv.NewManager()
+		Expect(err).NotTo(HaveOccurred())
+
+		handler, err = NewHandler(NewHandlerConfig(mgr), mgr)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(mgr.Initialize()).To(Succeed())
+
+		namespaceMap = random.NewNamespaceMap()
+	})
+
+	JustBeforeEach(func() {
+		recorder = httptest.NewRecorder()
+		hookutil.NewHandler(handler.Handle).ServeHTTP(recorder, req)
+	})
+
+	AfterEach(func() {
+		mgr.Stop()
+	})
+
+	When("payload is invalid", func() {
+		BeforeEach(func() {
+			req = httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte("{")))
+			req.Header.Set(bitbucket.EventKeyHeader, bitbucket.RepoPush)
+		})
+
+		It("should respond 400", func() {
+			Expect(recorder).To(HaveHTTPStatus(http.StatusBadRequest))
+		})
+	})
+
+	When("event key is not supported", func() {
+		setEvent("issue:created", map[string]interface{}{})
+
+		It("should respond 200", func() {
+			Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+		})
+	})
+
+	When("event key = repo:push", func() {
+		When("branch is pushed", func() {
+			setEvent(bitbucket.RepoPush, fakebitbucket.NewPushEvent())
+
+			When("resource template exists", func() {
+				testSuccess("resource-exists")
+
+				It("should record Updated event", func() {
+					Expect(mgr.WaitForEvent(testenv.EventData{
+						Type:    v1.EventTypeNormal,
+						Reason:  hookutil.ReasonUpdated,
+						Message: "Updated resource template: foobar",
+					})).To(BeTrue())
+				})
+			})
+
+			When("resource template does not exist", func() {
+				testSuccess("resource-not-exist")
+				testCreated()
+			})
+
+			When("push event filter is not set", func() {
+				testSuccess("without-event-filters")
+				testSkipped()
+			})
+
+			When("only tag filter is set", func() {
+				testSuccess("push-tag-include")
+				testSkipped()
+			})
+		})
+
+		When("branch is deleted", func() {
+			setEvent(bitbucket.RepoPush, fakebitbucket.NewPushEvent(fakebitbucket.SetPushEventDeleted()))
+			testSuccess("resource-exists")
+			testSkipped()
+		})
+
+		When("branches.include is set", func() {
+			name := "push-branch-include"
+
+			When("exact match", func() {
+				setEvent(bitbucket.RepoPush, fakebitbucket.NewPushEvent(fakebitbucket.SetPushEventBranch("foo")))
+				testSuccess(name)
+				testTriggered()
+			})
+
+			When("match by regex", func() {
+				setEvent(bitbucket.RepoPush, fakebitbucket.NewPushEvent(fakebitbucket.SetPushEventBranch("bar-5")))
+				testSuccess(name)
+				testTriggered()
+			})
+
+			When("not match", func() {
+				setEvent(bitbucket.RepoPush, fakebitbucket.NewPushEvent(fakebitbucket.SetPushEventBranch("abc")))
+				testSuccess(name)
+				testSkipped()
+			})
+		})
+
+		When("tag is pushed", func() {
+			When("tag filter is not set", func() {
+				setEvent(bitbucket.RepoPush, fakebitbucket.NewPushEvent(fakebitbucket.SetPushEventTag("foo")))
+				testSuccess("resource-not-exist")
+				testSkipped()
+			})
+
+			When("tags.include matches", func() {
+				setEvent(bitbucket.RepoPush, fakebitbucket.NewPushEvent(fakebitbucket.SetPushEventTag("bar-5")))
+				testSuccess("push-tag-include")
+				testTriggered()
+			})
+
+			When("tags.include does not match", func() {
+				setEvent(bitbucket.RepoPush, fakebitbucket.NewPushEvent(fakebitbucket.SetPushEventTag("abc")))
+				testSuccess("push-tag-include")
+				testSkipped()
+			})
+		})
+
+		When("secret token is set", func() {
+			When("signature matches", func() {
+				BeforeEach(func() {
+					event := fakebitbucket.NewPushEvent()
+					req = newRequest(bitbucket.RepoPush, event)
+					req.Header.Set(bitbucket.SignatureHeader, sign(event, "secret"))
+				})
+
+				testSuccess("secret-token")
+				testTriggered()
+			})
+
+			When("signature does not match", func() {
+				BeforeEach(func() {
+					event := fakebitbucket.NewPushEvent()
+					req = newRequest(bitbucket.RepoPush, event)
+					req.Header.Set(bitbucket.SignatureHeader, sign(event, "wrong"))
+				})
+
+				loadData("secret-token")
+
+				It("should respond 403", func() {
+					Expect(recorder).To(HaveHTTPStatus(http.StatusForbidden))
+				})
+
+				testSkipped()
+			})
+
+			When("signature is missing", func() {
+				setEvent(bitbucket.RepoPush, fakebitbucket.NewPushEvent())
+				loadData("secret-token")
+
+				It("should respond 403", func() {
+					Expect(recorder).To(HaveHTTPStatus(http.StatusForbidden))
+				})
+
+				testSkipped()
+			})
+		})
+	})
+
+	When("event key = repo:refs_changed", func() {
+		When("branch is pushed", func() {
+			setEvent(bitbucket.ServerRepoRefsChanged, fakebitbucket.NewServerRefsChangedEvent())
+			testSuccess("resource-not-exist")
+			testCreated()
+		})
+
+		When("branches.include matches", func() {
+			setEvent(bitbucket.ServerRepoRefsChanged, fakebitbucket.NewServerRefsChangedEvent(fakebitbucket.SetServerRefsChangedEventBranch("bar-5")))
+			testSuccess("push-branch-include")
+			testTriggered()
+		})
+
+		When("tags.include matches", func() {
+			setEvent(bitbucket.ServerRepoRefsChanged, fakebitbucket.NewServerRefsChangedEvent(fakebitbucket.SetServerRefsChangedEventTag("bar-5")))
+			testSuccess("push-tag-include")
+			testTriggered()
+		})
+
+		When("tags.include does not match", func() {
+			setEvent(bitbucket.ServerRepoRefsChanged, fakebitbucket.NewServerRefsChangedEvent(fakebitbucket.SetServerRefsChangedEventTag("abc")))
+			testSuccess("push-tag-include")
+			testSkipped()
+		})
+	})
+
+	When("event key = pullrequest:*", func() {
+		When("no matching webhooks", func() {
+			setEvent(bitbucket.PullRequestCreated, fakebitbucket.NewPullRequestEvent())
+
+			It("should respond 200", func() {
+				Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+			})
+
+			testSkipped()
+		})
+
+		When("pull request event filter is not set", func() {
+			setEvent(bitbucket.PullRequestCreated, fakebitbucket.NewPullRequestEvent())
+			testSuccess("without-event-filters")
+			testSkipped()
+		})
+
+		for _, key := range []string{bitbucket.PullRequestCreated, bitbucket.PullRequestUpdated} {
+			key := key
+
+			When(fmt.Sprintf("event key = %s", key), func() {
+				setEvent(key, fakebitbucket.NewPullRequestEvent())
+				testSuccess("resource-not-exist")
+				testCreated()
+			})
+		}
+
+		for _, key := range []string{bitbucket.PullRequestFulfilled, bitbucket.PullRequestRejected} {
+			key := key
+
+			When(fmt.Sprintf("event key = %s", key), func() {
+				setEvent(key, fakebitbucket.NewPullRequestEvent())
+				testSuccess("resource-exists")
+				testDeleted()
+			})
+		}
+
+		When("branches.include is set", func() {
+			name := "pull-request-branch-include"
+
+			When("match", func() {
+				setEvent(bitbucket.PullRequestCreated, fakebitbucket.NewPullRequestEvent(fakebitbucket.SetPullRequestEventBranch("bar-5")))
+				testSuccess(name)
+				testTriggered()
+			})
+
+			When("not match", func() {
+				setEvent(bitbucket.PullRequestCreated, fakebitbucket.NewPullRequestEvent(fakebitbucket.SetPullRequestEventBranch("abc")))
+				testSuccess(name)
+				testSkipped()
+			})
+		})
+
+		When("types is set", func() {
+			name := "pull-request-type"
+
+			When("type = fulfilled", func() {
+				setEvent(bitbucket.PullRequestFulfilled, fakebitbucket.NewPullRequestEvent())
+				testSuccess(name)
+				testTriggered()
+			})
+
+			When("type = created", func() {
+				setEvent(bitbucket.PullRequestCreated, fakebitbucket.NewPullRequestEvent())
+				testSuccess(name)
+				testSkipped()
+			})
+		})
+	})
+
+	When("event key = pr:*", func() {
+		for _, key := range []string{bitbucket.ServerPullRequestOpened, bitbucket.ServerPullRequestModified, bitbucket.ServerPullRequestFromRefUpdated} {
+			key := key
+
+			When(fmt.Sprintf("event key = %s", key), func() {
+				setEvent(key, fakebitbucket.NewServerPullRequestEvent(fakebitbucket.SetServerPullRequestEventKey(key)))
+				testSuccess("resource-not-exist")
+				testCreated()
+			})
+		}
+
+		for _, key := range []string{bitbucket.ServerPullRequestMerged, bitbucket.ServerPullRequestDeclined, bitbucket.ServerPullRequestDeleted} {
+			key := key
+
+			When(fmt.Sprintf("event key = %s", key), func() {
+				setEvent(key, fakebitbucket.NewServerPullRequestEvent(fakebitbucket.SetServerPullRequestEventKey(key)))
+				testSuccess("resource-exists")
+				testDeleted()
+			})
+		}
+
+		When("types is set", func() {
+			When("event key = pr:merged", func() {
+				setEvent(bitbucket.ServerPullRequestMerged, fakebitbucket.NewServerPullRequestEvent(fakebitbucket.SetServerPullRequestEventKey(bitbucket.ServerPullRequestMerged)))
+				testSuccess("pull-request-type")
+				testTriggered()
+			})
+
+			When("event key = pr:opened", func() {
+				setEvent(bitbucket.ServerPullRequestOpened, fakebitbucket.NewServerPullRequestEvent())
+				testSuccess("pull-request-type")
+				testSkipped()
+			})
+		})
+	})
+})
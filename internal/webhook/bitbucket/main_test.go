@@ -0,0 +1,11 @@
+package bitbucket
+
+import (
+	"testing"
+
+	"github.com/tommy351/pullup/internal/testenv"
+)
+
+func Test(t *testing.T) {
+	testenv.RunSpecsInEnvironment(t, "webhook/bitbucket")
+}
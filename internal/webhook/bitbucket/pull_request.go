@@ -0,0 +1,147 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/tommy351/pullup/internal/bitbucket"
+	"github.com/tommy351/pullup/internal/log"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+)
+
+const (
+	pullRequestCreated   v1beta1.BitbucketPullRequestEventType = "created"
+	pullRequestUpdated   v1beta1.BitbucketPullRequestEventType = "updated"
+	pullRequestFulfilled v1beta1.BitbucketPullRequestEventType = "fulfilled"
+	pullRequestRejected  v1beta1.BitbucketPullRequestEventType = "rejected"
+)
+
+// nolint: gochecknoglobals
+var defaultPullRequestTypes = []v1beta1.BitbucketPullRequestEventType{
+	pullRequestCreated, pullRequestUpdated, pullRequestFulfilled, pullRequestRejected,
+}
+
+// nolint: gochecknoglobals
+var serverPullRequestTypes = map[string]v1beta1.BitbucketPullRequestEventType{
+	bitbucket.ServerPullRequestOpened:         pullRequestCreated,
+	bitbucket.ServerPullRequestModified:       pullRequestUpdated,
+	bitbucket.ServerPullRequestFromRefUpdated: pullRequestUpdated,
+	bitbucket.ServerPullRequestMerged:         pullRequestFulfilled,
+	bitbucket.ServerPullRequestDeclined:       pullRequestRejected,
+	bitbucket.ServerPullRequestDeleted:        pullRequestRejected,
+}
+
+// pullRequestEvent is a pull request event of either Bitbucket Cloud or
+// Bitbucket Server.
+type pullRequestEvent struct {
+	Type    v1beta1.BitbucketPullRequestEventType
+	Branch  string
+	Payload interface{}
+}
+
+func newCloudPullRequestEvent(eventKey string, event *bitbucket.PullRequestEvent) *pullRequestEvent {
+	result := &pullRequestEvent{
+		Type:    v1beta1.BitbucketPullRequestEventType(strings.TrimPrefix(eventKey, "pullrequest:")),
+		Payload: event,
+	}
+
+	if dest := event.PullRequest.Destination; dest != nil && dest.Branch != nil {
+		result.Branch = dest.Branch.Name
+	}
+
+	return result
+}
+
+func newServerPullRequestEvent(eventKey string, event *bitbucket.ServerPullRequestEvent) *pullRequestEvent {
+	return &pullRequestEvent{
+		Type:    serverPullRequestTypes[eventKey],
+		Branch:  event.PullRequest.ToRef.DisplayID,
+		Payload: event,
+	}
+}
+
+func (h *Handler) handlePullRequestEvent(ctx context.Context, d *delivery, repoName string, event *pullRequestEvent) error {
+	hooks, err := h.listWebhooks(ctx, d, repoName)
+	if err != nil {
+		return err
+	}
+
+	logger := logr.FromContextOrDiscard(ctx).WithValues(
+		"repository", repoName,
+		"type", event.Type,
+	)
+	ctx = logr.NewContext(ctx, logger)
+
+	for _, hook := range hooks {
+		hook := hook
+
+		if err := h.handlePullRequestEventHook(ctx, repoName, event, &hook); err != nil {
+			return fmt.Errorf("failed to handle pull request event: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (h *Handler) handlePullRequestEventHook(ctx context.Context, repoName string, event *pullRequestEvent, hook *v1beta1.BitbucketWebhook) error {
+	repo := extractRepository(hook, repoName)
+	logger := logr.FromContextOrDiscard(ctx).WithValues(
+		"webhook", hook,
+	)
+	ctx = logr.NewContext(ctx, logger)
+
+	if repo == nil {
+		logger.V(log.Debug).Info("Repository does not exist in the webhook")
+
+		return nil
+	}
+
+	if repo.PullRequest == nil {
+		logger.V(log.Debug).Info("Pull request event filter is not set")
+
+		return nil
+	}
+
+	if !filterByPullRequestType(repo.PullRequest.Types, event.Type) {
+		logger.V(log.Debug).Info("Skipped for the type")
+
+		return nil
+	}
+
+	if !hookutil.FilterWebhook(repo.PullRequest.Branches, []string{event.Branch}) {
+		logger.V(log.Debug).Info("Skipped on this branch", "branch", event.Branch)
+
+		return nil
+	}
+
+	options := &hookutil.TriggerOptions{
+		Action:        hook.Spec.Action,
+		DefaultAction: v1beta1.ActionApply,
+		Event:         event.Payload,
+		Source:        hook,
+		Triggers:      hook.Spec.Triggers,
+	}
+
+	if event.Type == pullRequestFulfilled || event.Type == pullRequestRejected {
+		options.DefaultAction = v1beta1.ActionDelete
+	}
+
+	return h.TriggerHandler.Handle(ctx, options)
+}
+
+func filterByPullRequestType(types []v1beta1.BitbucketPullRequestEventType, value v1beta1.BitbucketPullRequestEventType) bool {
+	if len(types) == 0 {
+		types = defaultPullRequestTypes
+	}
+
+	for _, t := range types {
+		if t == value {
+			return true
+		}
+	}
+
+	return false
+}
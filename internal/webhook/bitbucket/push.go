@@ -0,0 +1,174 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/tommy351/pullup/internal/bitbucket"
+	"github.com/tommy351/pullup/internal/gitutil"
+	"github.com/tommy351/pullup/internal/log"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+)
+
+// pushEvent is a change of a single ref. A push to Bitbucket may contain
+// changes of multiple refs, each of them is handled separately.
+type pushEvent struct {
+	Ref     gitutil.Ref
+	Deleted bool
+	Payload interface{}
+}
+
+func newCloudPushEvents(event *bitbucket.PushEvent) []*pushEvent {
+	// nolint: prealloc
+	var result []*pushEvent
+
+	for _, change := range event.Push.Changes {
+		if change == nil {
+			continue
+		}
+
+		ref := change.New
+		if ref == nil {
+			ref = change.Old
+		}
+
+		if ref == nil {
+			continue
+		}
+
+		payload := *event
+		payload.Push = &bitbucket.Push{
+			Changes: []*bitbucket.PushChange{change},
+		}
+
+		result = append(result, &pushEvent{
+			Ref:     gitutil.Ref{Type: getCloudRefType(ref.Type), Name: ref.Name},
+			Deleted: change.New == nil,
+			Payload: &payload,
+		})
+	}
+
+	return result
+}
+
+func getCloudRefType(refType string) gitutil.RefType {
+	switch refType {
+	case "branch", "named_branch":
+		return gitutil.RefTypeBranch
+	case "tag":
+		return gitutil.RefTypeTag
+	}
+
+	return gitutil.RefType(refType)
+}
+
+func newServerPushEvents(event *bitbucket.ServerRefsChangedEvent) []*pushEvent {
+	// nolint: prealloc
+	var result []*pushEvent
+
+	for _, change := range event.Changes {
+		if change == nil {
+			continue
+		}
+
+		ref, ok := gitutil.ParseRef(change.RefID)
+		if !ok {
+			continue
+		}
+
+		payload := *event
+		payload.Changes = []*bitbucket.ServerRefChange{change}
+
+		result = append(result, &pushEvent{
+			Ref:     ref,
+			Deleted: change.Type == "DELETE",
+			Payload: &payload,
+		})
+	}
+
+	return result
+}
+
+func (h *Handler) handlePushEvents(ctx context.Context, d *delivery, repoName string, events []*pushEvent) error {
+	hooks, err := h.listWebhooks(ctx, d, repoName)
+	if err != nil {
+		return err
+	}
+
+	logger := logr.FromContextOrDiscard(ctx).WithValues(
+		"repository", repoName,
+	)
+
+	for _, event := range events {
+		ctx := logr.NewContext(ctx, logger.WithValues("ref", event.Ref))
+
+		for _, hook := range hooks {
+			hook := hook
+
+			if err := h.handlePushEventHook(ctx, repoName, event, &hook); err != nil {
+				return fmt.Errorf("failed to handle push event: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (h *Handler) handlePushEventHook(ctx context.Context, repoName string, event *pushEvent, hook *v1beta1.BitbucketWebhook) error {
+	repo := extractRepository(hook, repoName)
+	logger := logr.FromContextOrDiscard(ctx).WithValues(
+		"webhook", hook,
+	)
+	ctx = logr.NewContext(ctx, logger)
+
+	if repo == nil {
+		logger.V(log.Debug).Info("Repository does not exist in the webhook")
+
+		return nil
+	}
+
+	if repo.Push == nil {
+		logger.V(log.Debug).Info("Push event filter is not set")
+
+		return nil
+	}
+
+	if event.Deleted {
+		logger.V(log.Debug).Info("Skipped because the ref is deleted")
+
+		return nil
+	}
+
+	switch event.Ref.Type {
+	case gitutil.RefTypeBranch:
+		if (repo.Push.Branches == nil && repo.Push.Tags != nil) || !hookutil.FilterWebhook(repo.Push.Branches, []string{event.Ref.Name}) {
+			logger.V(log.Debug).Info("Skipped on this branch", "branch", event.Ref.Name)
+
+			return nil
+		}
+
+	case gitutil.RefTypeTag:
+		if repo.Push.Tags == nil || !hookutil.FilterWebhook(repo.Push.Tags, []string{event.Ref.Name}) {
+			logger.V(log.Debug).Info("Skipped on this tag", "tag", event.Ref.Name)
+
+			return nil
+		}
+
+	default:
+		logger.V(log.Debug).Info("Unsupported ref type", "refType", event.Ref.Type)
+
+		return nil
+	}
+
+	options := &hookutil.TriggerOptions{
+		DefaultAction: v1beta1.ActionApply,
+		Action:        hook.Spec.Action,
+		Event:         event.Payload,
+		Source:        hook,
+		Triggers:      hook.Spec.Triggers,
+	}
+
+	return h.TriggerHandler.Handle(ctx, options)
+}
@@ -0,0 +1,211 @@
+package cloudevents
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	SpecVersion = "1.0"
+
+	AttributeSpecVersion     = "specversion"
+	AttributeID              = "id"
+	AttributeSource          = "source"
+	AttributeType            = "type"
+	AttributeSubject         = "subject"
+	AttributeDataContentType = "datacontenttype"
+	AttributeData            = "data"
+	AttributeDataBase64      = "data_base64"
+
+	contentTypeStructured = "application/cloudevents+json"
+	contentTypeBatch      = "application/cloudevents-batch+json"
+	headerPrefix          = "Ce-"
+)
+
+var (
+	ErrInvalidEvent         = errors.New("invalid CloudEvent")
+	ErrBatchModeUnsupported = errors.New("batch content mode is not supported")
+)
+
+// Event is a CloudEvent. Context attributes and extensions are keys of the map,
+// and the data is stored in "data" key. JSON data is decoded, and other data is
+// stored as a string.
+type Event map[string]interface{}
+
+func (e Event) GetString(key string) string {
+	if s, ok := e[key].(string); ok {
+		return s
+	}
+
+	return ""
+}
+
+func (e Event) GetID() string {
+	return e.GetString(AttributeID)
+}
+
+func (e Event) GetSource() string {
+	return e.GetString(AttributeSource)
+}
+
+func (e Event) GetType() string {
+	return e.GetString(AttributeType)
+}
+
+func (e Event) GetSubject() string {
+	return e.GetString(AttributeSubject)
+}
+
+func (e Event) validate() error {
+	if v := e.GetString(AttributeSpecVersion); v != SpecVersion {
+		return fmt.Errorf("%w: unsupported specversion %q", ErrInvalidEvent, v)
+	}
+
+	for _, key := range []string{AttributeID, AttributeSource, AttributeType} {
+		if e.GetString(key) == "" {
+			return fmt.Errorf("%w: %s is required", ErrInvalidEvent, key)
+		}
+	}
+
+	return nil
+}
+
+// ParseEvent parses a CloudEvent from an HTTP request in either binary or
+// structured content mode.
+func ParseEvent(header http.Header, body []byte) (Event, error) {
+	contentType := header.Get("Content-Type")
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	var (
+		event Event
+		err   error
+	)
+
+	switch mediaType {
+	case contentTypeBatch:
+		return nil, ErrBatchModeUnsupported
+
+	case contentTypeStructured:
+		event, err = parseStructuredEvent(body)
+
+	default:
+		event, err = parseBinaryEvent(header, contentType, body)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := event.validate(); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+func parseStructuredEvent(body []byte) (Event, error) {
+	var event Event
+
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("%w: invalid JSON", ErrInvalidEvent)
+	}
+
+	if event == nil {
+		return nil, fmt.Errorf("%w: event must be an object", ErrInvalidEvent)
+	}
+
+	if value, ok := event[AttributeDataBase64]; ok {
+		delete(event, AttributeDataBase64)
+
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s must be a string", ErrInvalidEvent, AttributeDataBase64)
+		}
+
+		data, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid %s", ErrInvalidEvent, AttributeDataBase64)
+		}
+
+		if err := event.setData(event.GetString(AttributeDataContentType), data); err != nil {
+			return nil, err
+		}
+	}
+
+	return event, nil
+}
+
+func parseBinaryEvent(header http.Header, contentType string, body []byte) (Event, error) {
+	event := Event{}
+
+	for key := range header {
+		if !strings.HasPrefix(key, headerPrefix) {
+			continue
+		}
+
+		value := header.Get(key)
+
+		// Header values may be percent-encoded.
+		if decoded, err := url.PathUnescape(value); err == nil {
+			value = decoded
+		}
+
+		event[strings.ToLower(strings.TrimPrefix(key, headerPrefix))] = value
+	}
+
+	if len(event) == 0 {
+		return nil, fmt.Errorf("%w: CloudEvent attributes are not found in headers", ErrInvalidEvent)
+	}
+
+	if contentType != "" {
+		event[AttributeDataContentType] = contentType
+	}
+
+	if len(body) > 0 {
+		if err := event.setData(contentType, body); err != nil {
+			return nil, err
+		}
+	}
+
+	return event, nil
+}
+
+func (e Event) setData(contentType string, data []byte) error {
+	if !isJSONContentType(contentType) {
+		e[AttributeData] = string(data)
+
+		return nil
+	}
+
+	var value interface{}
+
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("%w: data is not a valid JSON", ErrInvalidEvent)
+	}
+
+	e[AttributeData] = value
+
+	return nil
+}
+
+// isJSONContentType returns true if the content type is empty, which defaults
+// to JSON, or a JSON media type like "application/json" or
+// "application/vnd.foo+json".
+func isJSONContentType(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "application/json" || mediaType == "text/json" || strings.HasSuffix(mediaType, "+json")
+}
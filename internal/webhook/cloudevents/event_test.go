@@ -0,0 +1,187 @@
+package cloudevents
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseEvent", func() {
+	var (
+		header http.Header
+		body   []byte
+		event  Event
+		err    error
+	)
+
+	BeforeEach(func() {
+		header = http.Header{}
+		body = nil
+	})
+
+	JustBeforeEach(func() {
+		event, err = ParseEvent(header, body)
+	})
+
+	When("binary content mode", func() {
+		BeforeEach(func() {
+			header.Set("Ce-Specversion", "1.0")
+			header.Set("Ce-Id", "123")
+			header.Set("Ce-Source", "https://ci.example.com/builds")
+			header.Set("Ce-Type", "dev.pullup.build.completed")
+			header.Set("Ce-Subject", "foo%2Fbar")
+			header.Set("Ce-Traceparent", "abc")
+		})
+
+		When("data is JSON", func() {
+			BeforeEach(func() {
+				header.Set("Content-Type", "application/json; charset=utf-8")
+				body = []byte(`{"name":"foo"}`)
+			})
+
+			It("should parse attributes and data", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(event).To(Equal(Event{
+					"specversion":     "1.0",
+					"id":              "123",
+					"source":          "https://ci.example.com/builds",
+					"type":            "dev.pullup.build.completed",
+					"subject":         "foo/bar",
+					"traceparent":     "abc",
+					"datacontenttype": "application/json; charset=utf-8",
+					"data":            map[string]interface{}{"name": "foo"},
+				}))
+			})
+		})
+
+		When("data is not JSON", func() {
+			BeforeEach(func() {
+				header.Set("Content-Type", "text/plain")
+				body = []byte("hello")
+			})
+
+			It("should set data as a string", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(event).To(HaveKeyWithValue("data", "hello"))
+			})
+		})
+
+		When("data is an invalid JSON", func() {
+			BeforeEach(func() {
+				header.Set("Content-Type", "application/json")
+				body = []byte("{")
+			})
+
+			It("should return ErrInvalidEvent", func() {
+				Expect(err).To(MatchError(ErrInvalidEvent))
+			})
+		})
+
+		When("data is empty", func() {
+			It("should not set data", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(event).NotTo(HaveKey("data"))
+			})
+		})
+
+		When("type is missing", func() {
+			BeforeEach(func() {
+				header.Del("Ce-Type")
+			})
+
+			It("should return ErrInvalidEvent", func() {
+				Expect(err).To(MatchError(ErrInvalidEvent))
+			})
+		})
+
+		When("specversion is not supported", func() {
+			BeforeEach(func() {
+				header.Set("Ce-Specversion", "0.3")
+			})
+
+			It("should return ErrInvalidEvent", func() {
+				Expect(err).To(MatchError(ErrInvalidEvent))
+			})
+		})
+	})
+
+	When("structured content mode", func() {
+		BeforeEach(func() {
+			header.Set("Content-Type", "application/cloudevents+json; charset=utf-8")
+		})
+
+		When("data is JSON", func() {
+			BeforeEach(func() {
+				body = []byte(`{
+					"specversion": "1.0",
+					"id": "123",
+					"source": "https://ci.example.com/builds",
+					"type": "dev.pullup.build.completed",
+					"data": {"name": "foo"}
+				}`)
+			})
+
+			It("should parse attributes and data", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(event).To(Equal(Event{
+					"specversion": "1.0",
+					"id":          "123",
+					"source":      "https://ci.example.com/builds",
+					"type":        "dev.pullup.build.completed",
+					"data":        map[string]interface{}{"name": "foo"},
+				}))
+			})
+		})
+
+		When("data_base64 is given", func() {
+			BeforeEach(func() {
+				body = []byte(`{
+					"specversion": "1.0",
+					"id": "123",
+					"source": "https://ci.example.com/builds",
+					"type": "dev.pullup.build.completed",
+					"datacontenttype": "application/json",
+					"data_base64": "eyJuYW1lIjoiZm9vIn0="
+				}`)
+			})
+
+			It("should decode data", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(event).To(HaveKeyWithValue("data", map[string]interface{}{"name": "foo"}))
+				Expect(event).NotTo(HaveKey("data_base64"))
+			})
+		})
+
+		When("body is not a valid JSON", func() {
+			BeforeEach(func() {
+				body = []byte("{")
+			})
+
+			It("should return ErrInvalidEvent", func() {
+				Expect(err).To(MatchError(ErrInvalidEvent))
+			})
+		})
+
+		When("id is missing", func() {
+			BeforeEach(func() {
+				body = []byte(`{"specversion":"1.0","source":"a","type":"b"}`)
+			})
+
+			It("should return ErrInvalidEvent", func() {
+				Expect(err).To(MatchError(ErrInvalidEvent))
+			})
+		})
+	})
+
+	When("batch content mode", func() {
+		BeforeEach(func() {
+			header.Set("Content-Type", "application/cloudevents-batch+json")
+			body = []byte("[]")
+		})
+
+		It("should return ErrBatchModeUnsupported", func() {
+			Expect(err).To(MatchError(ErrBatchModeUnsupported))
+		})
+	})
+})
@@ -0,0 +1,261 @@
+package cloudevents
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/google/wire"
+	"github.com/tommy351/pullup/internal/httputil"
+	"github.com/tommy351/pullup/internal/log"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// +kubebuilder:rbac:groups=pullup.dev,resources=cloudeventsources,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+const (
+	secretHeader = "Pullup-Webhook-Secret"
+
+	// eventField indexes sources by pairs of types and sources of events.
+	// Filters which are not set or contain regular expressions are indexed as
+	// anyKey.
+	eventField = "spec.types.include,spec.sources.include"
+	anyKey     = "*"
+)
+
+// HandlerConfigSet provides a handler config.
+// nolint: gochecknoglobals
+var HandlerConfigSet = wire.NewSet(
+	wire.Struct(new(HandlerConfig), "*"),
+)
+
+// HandlerSet provides a handler.
+// nolint: gochecknoglobals
+var HandlerSet = wire.NewSet(
+	HandlerConfigSet,
+	NewHandler,
+)
+
+type HandlerConfig struct {
+	Client         client.Client
+	TriggerHandler hookutil.TriggerHandler
+}
+
+type Handler struct {
+	HandlerConfig
+}
+
+func NewHandler(conf HandlerConfig, mgr manager.Manager) (*Handler, error) {
+	err := mgr.GetFieldIndexer().IndexField(context.TODO(), &v1beta1.CloudEventSource{}, eventField, func(obj client.Object) []string {
+		spec := obj.(*v1beta1.CloudEventSource).Spec
+
+		var result []string
+
+		for _, t := range getFilterKeys(spec.Types) {
+			for _, s := range getFilterKeys(spec.Sources) {
+				result = append(result, getEventKey(t, s))
+			}
+		}
+
+		return result
+	})
+	if err != nil {
+		return nil, fmt.Errorf("index failed: %w", err)
+	}
+
+	return &Handler{
+		HandlerConfig: conf,
+	}, nil
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) error {
+	logger := logr.FromContextOrDiscard(r.Context())
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read body: %w", err)
+	}
+
+	event, err := ParseEvent(r.Header, body)
+	if err != nil {
+		if errors.Is(err, ErrInvalidEvent) || errors.Is(err, ErrBatchModeUnsupported) {
+			logger.V(log.Debug).Info("Invalid event", "error", err.Error())
+
+			return httputil.Response{
+				StatusCode: http.StatusBadRequest,
+				Errors: []httputil.Error{
+					{Description: err.Error()},
+				},
+			}
+		}
+
+		return err
+	}
+
+	logger = logger.WithValues(
+		"id", event.GetID(),
+		"source", event.GetSource(),
+		"type", event.GetType(),
+	)
+	ctx := logr.NewContext(r.Context(), logger)
+
+	sources, err := h.listSources(ctx, r, event)
+	if err != nil {
+		return err
+	}
+
+	for _, source := range sources {
+		source := source
+
+		if err := h.handleSource(ctx, event, &source); err != nil {
+			return err
+		}
+	}
+
+	return httputil.JSON(w, http.StatusOK, &httputil.Response{})
+}
+
+// listSources returns sources whose filters match the event. Sources with a
+// secret token which does not match the request are skipped, and it returns
+// 403 when all of matched sources are skipped.
+func (h *Handler) listSources(ctx context.Context, r *http.Request, event Event) ([]v1beta1.CloudEventSource, error) {
+	var items []v1beta1.CloudEventSource
+
+	seen := map[types.UID]bool{}
+
+	for _, key := range []string{
+		getEventKey(event.GetType(), event.GetSource()),
+		getEventKey(event.GetType(), anyKey),
+		getEventKey(anyKey, event.GetSource()),
+		getEventKey(anyKey, anyKey),
+	} {
+		list := new(v1beta1.CloudEventSourceList)
+
+		if err := h.Client.List(ctx, list, client.MatchingFields{eventField: key}); err != nil {
+			return nil, fmt.Errorf("failed to list CloudEventSource: %w", err)
+		}
+
+		for _, item := range list.Items {
+			if !seen[item.UID] {
+				seen[item.UID] = true
+				items = append(items, item)
+			}
+		}
+	}
+
+	var (
+		result  []v1beta1.CloudEventSource
+		matched int
+	)
+
+	for _, source := range items {
+		source := source
+		source.SetGroupVersionKind(v1beta1.GroupVersion.WithKind("CloudEventSource"))
+
+		if !filterEvent(&source, event) {
+			continue
+		}
+
+		matched++
+
+		if !h.validateSecretToken(ctx, r, &source) {
+			continue
+		}
+
+		result = append(result, source)
+	}
+
+	if matched > 0 && len(result) == 0 {
+		return nil, httputil.Response{
+			StatusCode: http.StatusForbidden,
+			Errors: []httputil.Error{
+				{Description: "Secret mismatch"},
+			},
+		}
+	}
+
+	return result, nil
+}
+
+func (h *Handler) validateSecretToken(ctx context.Context, r *http.Request, source *v1beta1.CloudEventSource) bool {
+	logger := logr.FromContextOrDiscard(ctx).WithValues("eventSource", source)
+
+	if source.Spec.SecretToken == nil {
+		return true
+	}
+
+	secret, err := hookutil.GetSecretValue(ctx, h.Client, source.Namespace, source.Spec.SecretToken)
+	if err != nil {
+		logger.Error(err, "Failed to get the secret token")
+
+		return false
+	}
+
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get(secretHeader)), secret) != 1 {
+		logger.V(log.Debug).Info("Secret mismatch")
+
+		return false
+	}
+
+	return true
+}
+
+func (h *Handler) handleSource(ctx context.Context, event Event, source *v1beta1.CloudEventSource) error {
+	logger := logr.FromContextOrDiscard(ctx).WithValues("eventSource", source)
+	ctx = logr.NewContext(ctx, logger)
+
+	options := &hookutil.TriggerOptions{
+		DefaultAction: v1beta1.ActionApply,
+		Action:        source.Spec.Action,
+		Event:         event,
+		Source:        source,
+		Triggers:      source.Spec.Triggers,
+	}
+
+	if err := h.TriggerHandler.Handle(ctx, options); err != nil {
+		return fmt.Errorf("failed to handle CloudEvent: %w", err)
+	}
+
+	return nil
+}
+
+func filterEvent(source *v1beta1.CloudEventSource, event Event) bool {
+	spec := source.Spec
+
+	return hookutil.FilterWebhook(spec.Types, []string{event.GetType()}) &&
+		hookutil.FilterWebhook(spec.Sources, []string{event.GetSource()}) &&
+		hookutil.FilterWebhook(spec.Subjects, []string{event.GetSubject()})
+}
+
+// getFilterKeys returns index keys of values which may be included by the
+// filter. Excluded values are checked by filterEvent after sources are listed.
+func getFilterKeys(filter *v1beta1.EventSourceFilter) []string {
+	if filter == nil || len(filter.Include) == 0 {
+		return []string{anyKey}
+	}
+
+	var result []string
+
+	for _, c := range filter.Include {
+		if strings.HasPrefix(c, "/") && strings.HasSuffix(c, "/") {
+			return []string{anyKey}
+		}
+
+		result = append(result, c)
+	}
+
+	return result
+}
+
+func getEventKey(eventType, source string) string {
+	return eventType + "\n" + source
+}
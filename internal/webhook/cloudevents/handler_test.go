@@ -0,0 +1,313 @@
+package cloudevents
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tommy351/pullup/internal/httputil"
+	"github.com/tommy351/pullup/internal/k8s"
+	"github.com/tommy351/pullup/internal/random"
+	"github.com/tommy351/pullup/internal/testenv"
+	"github.com/tommy351/pullup/internal/testutil"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Handler", func() {
+	var (
+		handler      *Handler
+		req          *http.Request
+		recorder     *httptest.ResponseRecorder
+		mgr          *testenv.Manager
+		namespaceMap *random.NamespaceMap
+	)
+
+	newBinaryRequest := func(eventType, subject string, data interface{}) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(testutil.MustMarshalJSON(data)))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Ce-Specversion", SpecVersion)
+		req.Header.Set("Ce-Id", "123")
+		req.Header.Set("Ce-Source", "https://ci.example.com/builds")
+		req.Header.Set("Ce-Type", eventType)
+		req.Header.Set("Ce-Subject", subject)
+
+		return req.WithContext(logr.NewContext(req.Context(), log.Log))
+	}
+
+	newStructuredRequest := func(event map[string]interface{}) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(testutil.MustMarshalJSON(event)))
+		req.Header.Set("Content-Type", "application/cloudevents+json")
+
+		return req.WithContext(logr.NewContext(req.Context(), log.Log))
+	}
+
+	loadTestData := func(name string) []client.Object {
+		data, err := k8s.LoadObjects(testenv.GetScheme(), fmt.Sprintf("testdata/%s.yml", name))
+		Expect(err).NotTo(HaveOccurred())
+
+		data, err = k8s.MapObjects(data, namespaceMap.SetObject)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testenv.CreateObjects(data)).To(Succeed())
+
+		return data
+	}
+
+	getChanges := func() []testenv.Change {
+		return testenv.GetChanges(handler.Client)
+	}
+
+	loadData := func(name string) {
+		var data []client.Object
+
+		BeforeEach(func() {
+			data = loadTestData(name)
+		})
+
+		AfterEach(func() {
+			Expect(testenv.DeleteObjects(data)).To(Succeed())
+		})
+	}
+
+	testSuccess := func(name string) {
+		loadData(name)
+
+		It("should respond 200", func() {
+			Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+		})
+	}
+
+	testCreated := func() {
+		It("should create the resource template", func() {
+			Expect(mgr.WaitForEvent(testenv.EventData{
+				Type:    corev1.EventTypeNormal,
+				Reason:  hookutil.ReasonCreated,
+				Message: "Created resource template: foobar-foo",
+			})).To(BeTrue())
+		})
+	}
+
+	testSkipped := func() {
+		It("should not change anything", func() {
+			Expect(getChanges()).To(BeEmpty())
+		})
+	}
+
+	testBadRequest := func() {
+		It("should respond 400", func() {
+			Expect(recorder).To(HaveHTTPStatus(http.StatusBadRequest))
+		})
+	}
+
+	BeforeEach(func() {
+		var err error
+		mgr, err = testenv.NewManager()
+		Expect(err).NotTo(HaveOccurred())
+
+		handler, err = NewHandler(NewHandlerConfig(mgr), mgr)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(mgr.Initialize()).To(Succeed())
+
+		namespaceMap = random.NewNamespaceMap()
+	})
+
+	JustBeforeEach(func() {
+		recorder = httptest.NewRecorder()
+		hookutil.NewHandler(handler.Handle).ServeHTTP(recorder, req)
+	})
+
+	AfterEach(func() {
+		mgr.Stop()
+	})
+
+	When("binary content mode", func() {
+		BeforeEach(func() {
+			req = newBinaryRequest("dev.pullup.build.completed", "builds/1", map[string]interface{}{
+				"name": "foo",
+			})
+		})
+
+		testSuccess("source")
+		testCreated()
+
+		It("should set attributes and data as the event", func() {
+			rt := new(v1beta1.ResourceTemplate)
+			Expect(handler.Client.Get(context.Background(), types.NamespacedName{
+				Namespace: namespaceMap.GetRandom("test"),
+				Name:      "foobar-foo",
+			}, rt)).To(Succeed())
+			Expect(rt.Spec.Data.Raw).To(MatchJSON(testutil.MustMarshalJSON(map[string]interface{}{
+				"event": map[string]interface{}{
+					"specversion":     SpecVersion,
+					"id":              "123",
+					"source":          "https://ci.example.com/builds",
+					"type":            "dev.pullup.build.completed",
+					"subject":         "builds/1",
+					"datacontenttype": "application/json",
+					"data": map[string]interface{}{
+						"name": "foo",
+					},
+				},
+			})))
+		})
+	})
+
+	When("structured content mode", func() {
+		BeforeEach(func() {
+			req = newStructuredRequest(map[string]interface{}{
+				"specversion": SpecVersion,
+				"id":          "123",
+				"source":      "https://ci.example.com/builds",
+				"type":        "dev.pullup.build.completed",
+				"data": map[string]interface{}{
+					"name": "foo",
+				},
+			})
+		})
+
+		testSuccess("source")
+		testCreated()
+	})
+
+	When("type does not match", func() {
+		BeforeEach(func() {
+			req = newBinaryRequest("dev.pullup.build.started", "builds/1", map[string]interface{}{
+				"name": "foo",
+			})
+		})
+
+		testSuccess("source")
+		testSkipped()
+	})
+
+	When("source does not match", func() {
+		BeforeEach(func() {
+			req = newBinaryRequest("dev.pullup.build.completed", "builds/1", map[string]interface{}{
+				"name": "foo",
+			})
+			req.Header.Set("Ce-Source", "https://example.com/builds")
+		})
+
+		testSuccess("source")
+		testSkipped()
+	})
+
+	When("subject is excluded", func() {
+		BeforeEach(func() {
+			req = newBinaryRequest("dev.pullup.build.completed", "ignored", map[string]interface{}{
+				"name": "foo",
+			})
+		})
+
+		testSuccess("source")
+		testSkipped()
+	})
+
+	When("type matches a pattern", func() {
+		BeforeEach(func() {
+			req = newBinaryRequest("dev.pullup.build.started", "builds/1", map[string]interface{}{
+				"name": "foo",
+			})
+		})
+
+		testSuccess("source-pattern")
+		testCreated()
+	})
+
+	When("source does not match exactly", func() {
+		BeforeEach(func() {
+			req = newBinaryRequest("dev.pullup.build.completed", "builds/1", map[string]interface{}{
+				"name": "foo",
+			})
+			req.Header.Set("Ce-Source", "https://ci.example.com/builds/1")
+		})
+
+		testSuccess("source-pattern")
+		testSkipped()
+	})
+
+	When("action is a template string", func() {
+		BeforeEach(func() {
+			req = newBinaryRequest("dev.pullup.build.deleted", "builds/1", map[string]interface{}{
+				"name": "foo",
+			})
+		})
+
+		testSuccess("source-action")
+
+		It("should delete the resource template", func() {
+			Expect(mgr.WaitForEvent(testenv.EventData{
+				Type:    corev1.EventTypeNormal,
+				Reason:  hookutil.ReasonNotExist,
+				Message: "Resource template does not exist: foobar-foo",
+			})).To(BeTrue())
+		})
+	})
+
+	When("required attributes are missing", func() {
+		BeforeEach(func() {
+			req = newBinaryRequest("dev.pullup.build.completed", "builds/1", map[string]interface{}{})
+			req.Header.Del("Ce-Id")
+		})
+
+		testBadRequest()
+
+		It("should respond errors", func() {
+			Expect(recorder.Body.Bytes()).To(MatchJSON(testutil.MustMarshalJSON(&httputil.Response{
+				Errors: []httputil.Error{
+					{Description: "invalid CloudEvent: id is required"},
+				},
+			})))
+		})
+	})
+
+	When("batch content mode", func() {
+		BeforeEach(func() {
+			req = httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte("[]")))
+			req.Header.Set("Content-Type", "application/cloudevents-batch+json")
+		})
+
+		testBadRequest()
+	})
+
+	When("secret token is given", func() {
+		BeforeEach(func() {
+			req = newBinaryRequest("dev.pullup.build.completed", "builds/1", map[string]interface{}{
+				"name": "foo",
+			})
+		})
+
+		When("secret matches", func() {
+			BeforeEach(func() {
+				req.Header.Set("Pullup-Webhook-Secret", "some-thing-very-secret")
+			})
+
+			testSuccess("secret-token")
+			testCreated()
+		})
+
+		When("secret mismatch", func() {
+			BeforeEach(func() {
+				req.Header.Set("Pullup-Webhook-Secret", "foobar")
+			})
+
+			loadData("secret-token")
+
+			It("should respond 403", func() {
+				Expect(recorder).To(HaveHTTPStatus(http.StatusForbidden))
+			})
+
+			testSkipped()
+		})
+	})
+})
@@ -0,0 +1,20 @@
+// +build wireinject
+
+package cloudevents
+
+import (
+	"github.com/google/wire"
+	"github.com/tommy351/pullup/internal/controller"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+func NewHandlerConfig(mgr manager.Manager) HandlerConfig {
+	wire.Build(
+		controller.NewClient,
+		hookutil.NewEventRecorder,
+		hookutil.TriggerHandlerSet,
+		HandlerConfigSet,
+	)
+	return HandlerConfig{}
+}
@@ -0,0 +1,198 @@
+package gitea
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/google/wire"
+	"github.com/tommy351/pullup/internal/gitea"
+	"github.com/tommy351/pullup/internal/httputil"
+	"github.com/tommy351/pullup/internal/log"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// +kubebuilder:rbac:groups=pullup.dev,resources=giteawebhooks,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+const nameField = "spec.repositories.name"
+
+// HandlerConfigSet provides a handler config.
+// nolint: gochecknoglobals
+var HandlerConfigSet = wire.NewSet(
+	wire.Struct(new(HandlerConfig), "*"),
+)
+
+// HandlerSet provides a handler.
+// nolint: gochecknoglobals
+var HandlerSet = wire.NewSet(
+	HandlerConfigSet,
+	NewHandler,
+)
+
+type HandlerConfig struct {
+	Client         client.Client
+	TriggerHandler hookutil.TriggerHandler
+}
+
+type Handler struct {
+	HandlerConfig
+}
+
+type delivery struct {
+	Signature string
+	Body      []byte
+}
+
+func NewHandler(conf HandlerConfig, mgr manager.Manager) (*Handler, error) {
+	err := mgr.GetFieldIndexer().IndexField(context.TODO(), &v1beta1.GiteaWebhook{}, nameField, func(obj client.Object) []string {
+		var result []string
+
+		for _, repo := range obj.(*v1beta1.GiteaWebhook).Spec.Repositories {
+			result = append(result, repo.Name)
+		}
+
+		return result
+	})
+	if err != nil {
+		return nil, fmt.Errorf("index failed: %w", err)
+	}
+
+	return &Handler{
+		HandlerConfig: conf,
+	}, nil
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) error {
+	logger := logr.FromContextOrDiscard(r.Context())
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read body: %w", err)
+	}
+
+	payload, err := gitea.ParseWebhook(gitea.WebhookType(r), body)
+	if err != nil {
+		if errors.Is(err, gitea.ErrUnknownEventType) {
+			logger.V(log.Debug).Info("Skipped unsupported event", "event", gitea.WebhookType(r))
+
+			return httputil.JSON(w, http.StatusOK, &httputil.Response{})
+		}
+
+		logger.Error(err, "Invalid payload")
+
+		return httputil.JSON(w, http.StatusBadRequest, &httputil.Response{
+			Errors: []httputil.Error{
+				{Description: "Invalid payload"},
+			},
+		})
+	}
+
+	d := &delivery{
+		Signature: gitea.WebhookSignature(r),
+		Body:      body,
+	}
+
+	if err := h.handlePayload(r.Context(), d, payload); err != nil {
+		return err
+	}
+
+	return httputil.JSON(w, http.StatusOK, &httputil.Response{})
+}
+
+func (h *Handler) handlePayload(ctx context.Context, d *delivery, payload interface{}) error {
+	switch event := payload.(type) {
+	case *gitea.PushEvent:
+		if event.Repository == nil {
+			return nil
+		}
+
+		return h.handlePushEvent(ctx, d, event)
+
+	case *gitea.PullRequestEvent:
+		if event.Repository == nil || event.PullRequest == nil {
+			return nil
+		}
+
+		return h.handlePullRequestEvent(ctx, d, event)
+	}
+
+	return nil
+}
+
+func (h *Handler) listWebhooks(ctx context.Context, d *delivery, name string) ([]v1beta1.GiteaWebhook, error) {
+	list := new(v1beta1.GiteaWebhookList)
+	err := h.Client.List(ctx, list, client.MatchingFields(map[string]string{
+		nameField: name,
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	// nolint: prealloc
+	var result []v1beta1.GiteaWebhook
+
+	for _, hook := range list.Items {
+		hook := hook
+		hook.SetGroupVersionKind(v1beta1.GroupVersion.WithKind("GiteaWebhook"))
+
+		if !h.validateSignature(ctx, d, &hook) {
+			continue
+		}
+
+		result = append(result, hook)
+	}
+
+	if len(list.Items) > 0 && len(result) == 0 {
+		return nil, httputil.Response{
+			StatusCode: http.StatusForbidden,
+			Errors: []httputil.Error{
+				{Description: "Signature mismatch"},
+			},
+		}
+	}
+
+	return result, nil
+}
+
+func (h *Handler) validateSignature(ctx context.Context, d *delivery, hook *v1beta1.GiteaWebhook) bool {
+	logger := logr.FromContextOrDiscard(ctx).WithValues("webhook", hook)
+
+	if hook.Spec.SecretToken == nil {
+		return true
+	}
+
+	secret, err := hookutil.GetSecretValue(ctx, h.Client, hook.Namespace, hook.Spec.SecretToken)
+	if err != nil {
+		logger.Error(err, "Failed to get the secret token")
+
+		return false
+	}
+
+	// Gitea signs payloads with HMAC-SHA256 and sends the hex digest without
+	// the algorithm prefix.
+	if !hookutil.ValidateSignature("sha256="+d.Signature, d.Body, secret) {
+		logger.V(log.Debug).Info("Signature mismatch")
+
+		return false
+	}
+
+	return true
+}
+
+func extractRepository(hook *v1beta1.GiteaWebhook, name string) *v1beta1.GiteaRepository {
+	for _, r := range hook.Spec.Repositories {
+		r := r
+
+		if r.Name == name {
+			return &r
+		}
+	}
+
+	return nil
+}
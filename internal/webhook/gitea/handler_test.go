@@ -0,0 +1,395 @@
+package gitea
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tommy351/pullup/internal/fakegitea"
+	"github.com/tommy351/pullup/internal/gitea"
+	"github.com/tommy351/pullup/internal/k8s"
+	"github.com/tommy351/pullup/internal/random"
+	"github.com/tommy351/pullup/internal/testenv"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Handler", func() {
+	var (
+		handler      *Handler
+		req          *http.Request
+		recorder     *httptest.ResponseRecorder
+		mgr          *testenv.Manager
+		namespaceMap *random.NamespaceMap
+	)
+
+	encodeBody := func(body interface{}) []byte {
+		data, err := json.Marshal(body)
+		Expect(err).NotTo(HaveOccurred())
+
+		return data
+	}
+
+	newRequest := func(event string, body interface{}) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(encodeBody(body)))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(gitea.EventTypeHeader, event)
+
+		ctx := logr.NewContext(req.Context(), log.Log)
+
+		return req.WithContext(ctx)
+	}
+
+	sign := func(body interface{}, secret string) string {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(encodeBody(body))
+
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+
+	loadTestData := func(name string) []client.Object {
+		data, err := k8s.LoadObjects(testenv.GetScheme(), fmt.Sprintf("testdata/%s.yml", name))
+		Expect(err).NotTo(HaveOccurred())
+
+		data, err = k8s.MapObjects(data, namespaceMap.SetObject)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testenv.CreateObjects(data)).To(Succeed())
+
+		return data
+	}
+
+	getChanges := func() []testenv.Change {
+		return testenv.GetChanges(handler.Client)
+	}
+
+	loadData := func(name string) {
+		var data []client.Object
+
+		BeforeEach(func() {
+			data = loadTestData(name)
+		})
+
+		AfterEach(func() {
+			Expect(testenv.DeleteObjects(data)).To(Succeed())
+		})
+	}
+
+	testSuccess := func(name string) {
+		loadData(name)
+
+		It("should respond 200", func() {
+			Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+		})
+	}
+
+	testTriggered := func() {
+		It("should change something", func() {
+			Expect(getChanges()).NotTo(BeEmpty())
+		})
+	}
+
+	testSkipped := func() {
+		It("should not change anything", func() {
+			Expect(getChanges()).To(BeEmpty())
+		})
+	}
+
+	setPullRequestEvent := func(event *gitea.PullRequestEvent) {
+		BeforeEach(func() {
+			req = newRequest(gitea.PullRequestEventType, event)
+		})
+	}
+
+	setPushEvent := func(event *gitea.PushEvent) {
+		BeforeEach(func() {
+			req = newRequest(gitea.PushEventType, event)
+		})
+	}
+
+	BeforeEach(func() {
+		var err error
+		mgr, err = testenv.NewManager()
+		Expect(err).NotTo(HaveOccurred())
+
+		handler, err = NewHandler(NewHandlerConfig(mgr), mgr)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(mgr.Initialize()).To(Succeed())
+
+		namespaceMap = random.NewNamespaceMap()
+	})
+
+	JustBeforeEach(func() {
+		recorder = httptest.NewRecorder()
+		hookutil.NewHandler(handler.Handle).ServeHTTP(recorder, req)
+	})
+
+	AfterEach(func() {
+		mgr.Stop()
+	})
+
+	When("payload is invalid", func() {
+		BeforeEach(func() {
+			req = httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte("{")))
+			req.Header.Set(gitea.EventTypeHeader, gitea.PushEventType)
+		})
+
+		It("should respond 400", func() {
+			Expect(recorder).To(HaveHTTPStatus(http.StatusBadRequest))
+		})
+	})
+
+	When("event type is not supported", func() {
+		BeforeEach(func() {
+			req = newRequest("issues", map[string]interface{}{})
+		})
+
+		It("should respond 200", func() {
+			Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+		})
+	})
+
+	When("event type = push", func() {
+		When("branch is pushed", func() {
+			setPushEvent(fakegitea.NewPushEvent())
+
+			When("resource template exists", func() {
+				testSuccess("resource-exists")
+
+				It("should record Updated event", func() {
+					Expect(mgr.WaitForEvent(testenv.EventData{
+						Type:    v1.EventTypeNormal,
+						Reason:  hookutil.ReasonUpdated,
+						Message: "Updated resource template: foobar",
+					})).To(BeTrue())
+				})
+			})
+
+			When("resource template does not exist", func() {
+				testSuccess("resource-not-exist")
+
+				It("should record Created event", func() {
+					Expect(mgr.WaitForEvent(testenv.EventData{
+						Type:    v1.EventTypeNormal,
+						Reason:  hookutil.ReasonCreated,
+						Message: "Created resource template: foobar",
+					})).To(BeTrue())
+				})
+			})
+
+			When("push event filter is not set", func() {
+				testSuccess("without-event-filters")
+				testSkipped()
+			})
+
+			When("only tag filter is set", func() {
+				testSuccess("push-tag-include")
+				testSkipped()
+			})
+		})
+
+		When("sent by Forgejo", func() {
+			BeforeEach(func() {
+				req = newRequest("", fakegitea.NewPushEvent())
+				req.Header.Del(gitea.EventTypeHeader)
+				req.Header.Set(gitea.ForgejoEventTypeHeader, gitea.PushEventType)
+			})
+
+			testSuccess("resource-not-exist")
+			testTriggered()
+		})
+
+		When("branches.include is set", func() {
+			name := "push-branch-include"
+
+			When("exact match", func() {
+				setPushEvent(fakegitea.NewPushEvent(fakegitea.SetPushEventBranch("foo")))
+				testSuccess(name)
+				testTriggered()
+			})
+
+			When("match by regex", func() {
+				setPushEvent(fakegitea.NewPushEvent(fakegitea.SetPushEventBranch("bar-5")))
+				testSuccess(name)
+				testTriggered()
+			})
+
+			When("not match", func() {
+				setPushEvent(fakegitea.NewPushEvent(fakegitea.SetPushEventBranch("abc")))
+				testSuccess(name)
+				testSkipped()
+			})
+		})
+
+		When("tag is pushed", func() {
+			When("tag filter is not set", func() {
+				setPushEvent(fakegitea.NewPushEvent(fakegitea.SetPushEventTag("foo")))
+				testSuccess("resource-not-exist")
+				testSkipped()
+			})
+
+			When("tags.include matches", func() {
+				setPushEvent(fakegitea.NewPushEvent(fakegitea.SetPushEventTag("bar-5")))
+				testSuccess("push-tag-include")
+				testTriggered()
+			})
+
+			When("tags.include does not match", func() {
+				setPushEvent(fakegitea.NewPushEvent(fakegitea.SetPushEventTag("abc")))
+				testSuccess("push-tag-include")
+				testSkipped()
+			})
+		})
+
+		When("secret token is set", func() {
+			When("signature matches", func() {
+				BeforeEach(func() {
+					event := fakegitea.NewPushEvent()
+					req = newRequest(gitea.PushEventType, event)
+					req.Header.Set(gitea.SignatureHeader, sign(event, "secret"))
+				})
+
+				testSuccess("secret-token")
+				testTriggered()
+			})
+
+			When("signature does not match", func() {
+				BeforeEach(func() {
+					event := fakegitea.NewPushEvent()
+					req = newRequest(gitea.PushEventType, event)
+					req.Header.Set(gitea.SignatureHeader, sign(event, "wrong"))
+				})
+
+				loadData("secret-token")
+
+				It("should respond 403", func() {
+					Expect(recorder).To(HaveHTTPStatus(http.StatusForbidden))
+				})
+
+				testSkipped()
+			})
+
+			When("signature is missing", func() {
+				setPushEvent(fakegitea.NewPushEvent())
+				loadData("secret-token")
+
+				It("should respond 403", func() {
+					Expect(recorder).To(HaveHTTPStatus(http.StatusForbidden))
+				})
+
+				testSkipped()
+			})
+		})
+	})
+
+	When("event type = pull_request", func() {
+		When("no matching webhooks", func() {
+			setPullRequestEvent(fakegitea.NewPullRequestEvent())
+
+			It("should respond 200", func() {
+				Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+			})
+
+			testSkipped()
+		})
+
+		When("pull request event filter is not set", func() {
+			setPullRequestEvent(fakegitea.NewPullRequestEvent())
+			testSuccess("without-event-filters")
+			testSkipped()
+		})
+
+		for _, action := range []string{"opened", "synchronized", "reopened"} {
+			action := action
+
+			When(fmt.Sprintf("action = %s", action), func() {
+				setPullRequestEvent(fakegitea.NewPullRequestEvent(fakegitea.SetPullRequestEventAction(action)))
+				testSuccess("resource-not-exist")
+
+				It("should record Created event", func() {
+					Expect(mgr.WaitForEvent(testenv.EventData{
+						Type:    v1.EventTypeNormal,
+						Reason:  hookutil.ReasonCreated,
+						Message: "Created resource template: foobar",
+					})).To(BeTrue())
+				})
+			})
+		}
+
+		When("action = closed", func() {
+			setPullRequestEvent(fakegitea.NewPullRequestEvent(fakegitea.SetPullRequestEventAction("closed")))
+			testSuccess("resource-exists")
+
+			It("should delete the resource", func() {
+				Expect(getChanges()).To(ContainElement(testenv.Change{
+					Type: "delete",
+					NamespacedName: types.NamespacedName{
+						Name:      "foobar",
+						Namespace: namespaceMap.GetRandom("test"),
+					},
+					GroupVersionKind: v1beta1.GroupVersion.WithKind("ResourceTemplate"),
+				}))
+			})
+		})
+
+		When("branches.include is set", func() {
+			name := "pull-request-branch-include"
+
+			When("match", func() {
+				setPullRequestEvent(fakegitea.NewPullRequestEvent(fakegitea.SetPullRequestEventBranch("bar-5")))
+				testSuccess(name)
+				testTriggered()
+			})
+
+			When("not match", func() {
+				setPullRequestEvent(fakegitea.NewPullRequestEvent(fakegitea.SetPullRequestEventBranch("abc")))
+				testSuccess(name)
+				testSkipped()
+			})
+		})
+
+		When("labels.include is set", func() {
+			name := "pull-request-label-include"
+
+			When("match", func() {
+				setPullRequestEvent(fakegitea.NewPullRequestEvent(fakegitea.SetPullRequestEventLabels([]string{"foo"})))
+				testSuccess(name)
+				testTriggered()
+			})
+
+			When("not match", func() {
+				setPullRequestEvent(fakegitea.NewPullRequestEvent(fakegitea.SetPullRequestEventLabels([]string{"abc"})))
+				testSuccess(name)
+				testSkipped()
+			})
+		})
+
+		When("types is set", func() {
+			name := "pull-request-type"
+
+			When("action = edited", func() {
+				setPullRequestEvent(fakegitea.NewPullRequestEvent(fakegitea.SetPullRequestEventAction("edited")))
+				testSuccess(name)
+				testTriggered()
+			})
+
+			When("action = opened", func() {
+				setPullRequestEvent(fakegitea.NewPullRequestEvent())
+				testSuccess(name)
+				testSkipped()
+			})
+		})
+	})
+})
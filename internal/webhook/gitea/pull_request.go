@@ -0,0 +1,129 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/tommy351/pullup/internal/gitea"
+	"github.com/tommy351/pullup/internal/log"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+)
+
+// nolint: gochecknoglobals
+var defaultPullRequestTypes = []v1beta1.GiteaPullRequestEventType{
+	"opened", "synchronized", "reopened", "closed",
+}
+
+func (h *Handler) handlePullRequestEvent(ctx context.Context, d *delivery, event *gitea.PullRequestEvent) error {
+	repoName := event.Repository.FullName
+	hooks, err := h.listWebhooks(ctx, d, repoName)
+	if err != nil {
+		return err
+	}
+
+	logger := logr.FromContextOrDiscard(ctx).WithValues(
+		"repository", repoName,
+		"action", event.Action,
+	)
+	ctx = logr.NewContext(ctx, logger)
+
+	for _, hook := range hooks {
+		hook := hook
+
+		if err := h.handlePullRequestEventHook(ctx, event, &hook); err != nil {
+			return fmt.Errorf("failed to handle pull request event: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (h *Handler) handlePullRequestEventHook(ctx context.Context, event *gitea.PullRequestEvent, hook *v1beta1.GiteaWebhook) error {
+	repo := extractRepository(hook, event.Repository.FullName)
+	logger := logr.FromContextOrDiscard(ctx).WithValues(
+		"webhook", hook,
+	)
+	ctx = logr.NewContext(ctx, logger)
+
+	if repo == nil {
+		logger.V(log.Debug).Info("Repository does not exist in the webhook")
+
+		return nil
+	}
+
+	if repo.PullRequest == nil {
+		logger.V(log.Debug).Info("Pull request event filter is not set")
+
+		return nil
+	}
+
+	if !filterByPullRequestType(repo.PullRequest.Types, event.Action) {
+		logger.V(log.Debug).Info("Skipped for the action")
+
+		return nil
+	}
+
+	if branch := getBaseBranch(event.PullRequest); !hookutil.FilterWebhook(repo.PullRequest.Branches, []string{branch}) {
+		logger.V(log.Debug).Info("Skipped on this branch", "branch", branch)
+
+		return nil
+	}
+
+	if filter := repo.PullRequest.Labels; filter != nil {
+		labels := getPullRequestLabels(event.PullRequest)
+
+		if !hookutil.FilterWebhook(filter, labels) {
+			logger.V(log.Debug).Info("Skipped on this label", "labels", labels)
+
+			return nil
+		}
+	}
+
+	options := &hookutil.TriggerOptions{
+		Action:        hook.Spec.Action,
+		DefaultAction: v1beta1.ActionApply,
+		Event:         event,
+		Source:        hook,
+		Triggers:      hook.Spec.Triggers,
+	}
+
+	if event.Action == "closed" {
+		options.DefaultAction = v1beta1.ActionDelete
+	}
+
+	return h.TriggerHandler.Handle(ctx, options)
+}
+
+func filterByPullRequestType(types []v1beta1.GiteaPullRequestEventType, action string) bool {
+	if len(types) == 0 {
+		types = defaultPullRequestTypes
+	}
+
+	for _, t := range types {
+		if string(t) == action {
+			return true
+		}
+	}
+
+	return false
+}
+
+func getBaseBranch(pr *gitea.PullRequest) string {
+	if pr.Base == nil {
+		return ""
+	}
+
+	return pr.Base.Ref
+}
+
+func getPullRequestLabels(pr *gitea.PullRequest) (result []string) {
+	for _, label := range pr.Labels {
+		if label != nil {
+			result = append(result, label.Name)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,90 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/tommy351/pullup/internal/gitea"
+	"github.com/tommy351/pullup/internal/gitutil"
+	"github.com/tommy351/pullup/internal/log"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+)
+
+func (h *Handler) handlePushEvent(ctx context.Context, d *delivery, event *gitea.PushEvent) error {
+	hooks, err := h.listWebhooks(ctx, d, event.Repository.FullName)
+	if err != nil {
+		return err
+	}
+
+	for _, hook := range hooks {
+		hook := hook
+
+		if err := h.handlePushEventHook(ctx, event, &hook); err != nil {
+			return fmt.Errorf("failed to handle push event: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (h *Handler) handlePushEventHook(ctx context.Context, event *gitea.PushEvent, hook *v1beta1.GiteaWebhook) error {
+	repoName := event.Repository.FullName
+	repo := extractRepository(hook, repoName)
+	logger := logr.FromContextOrDiscard(ctx).WithValues(
+		"repository", repoName,
+		"webhook", hook,
+	)
+	ctx = logr.NewContext(ctx, logger)
+
+	if repo == nil {
+		logger.V(log.Debug).Info("Repository does not exist in the webhook")
+
+		return nil
+	}
+
+	if repo.Push == nil {
+		logger.V(log.Debug).Info("Push event filter is not set")
+
+		return nil
+	}
+
+	ref, ok := gitutil.ParseRef(event.Ref)
+	if !ok {
+		logger.V(log.Debug).Info("Invalid ref", "ref", event.Ref)
+
+		return nil
+	}
+
+	switch ref.Type {
+	case gitutil.RefTypeBranch:
+		if (repo.Push.Branches == nil && repo.Push.Tags != nil) || !hookutil.FilterWebhook(repo.Push.Branches, []string{ref.Name}) {
+			logger.V(log.Debug).Info("Skipped on this branch", "branch", ref.Name)
+
+			return nil
+		}
+
+	case gitutil.RefTypeTag:
+		if repo.Push.Tags == nil || !hookutil.FilterWebhook(repo.Push.Tags, []string{ref.Name}) {
+			logger.V(log.Debug).Info("Skipped on this tag", "tag", ref.Name)
+
+			return nil
+		}
+
+	default:
+		logger.V(log.Debug).Info("Unsupported ref type", "refType", ref.Type)
+
+		return nil
+	}
+
+	options := &hookutil.TriggerOptions{
+		DefaultAction: v1beta1.ActionApply,
+		Action:        hook.Spec.Action,
+		Event:         event,
+		Source:        hook,
+		Triggers:      hook.Spec.Triggers,
+	}
+
+	return h.TriggerHandler.Handle(ctx, options)
+}
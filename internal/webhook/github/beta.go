@@ -5,19 +5,54 @@ import (
 )
 
 // nolint: gochecknoglobals
-var defaultPullRequestTypes = []v1beta1.GitHubPullRequestEventType{
-	"opened", "synchronize", "reopened", "closed",
-}
+var (
+	defaultPullRequestTypes = []v1beta1.GitHubPullRequestEventType{
+		"opened", "synchronize", "reopened", "closed",
+	}
+
+	defaultReleaseTypes = []v1beta1.GitHubReleaseEventType{
+		"published", "deleted",
+	}
+)
 
-func extractRepositoryBeta(hook *v1beta1.GitHubWebhook, name string) *v1beta1.GitHubRepository {
+func extractRepositoryBeta(hook *v1beta1.GitHubWebhook, ref *repositoryRef) *v1beta1.GitHubRepository {
 	for _, r := range hook.Spec.Repositories {
 		r := r
 
-		if r.Name == name {
+		if r.Name == ref.Name {
 			return &r
 		}
 	}
 
+	// Exact names take precedence over patterns.
+	for _, r := range hook.Spec.Repositories {
+		r := r
+
+		if isRepositoryPattern(r.Name) && matchRepository(r.Name, ref.Name) {
+			return &r
+		}
+	}
+
+	// Fall back to the event filters of installations when the repository is
+	// not listed explicitly.
+	if ref.InstallationID == 0 {
+		return nil
+	}
+
+	for _, inst := range hook.Spec.Installations {
+		if inst.ID == ref.InstallationID || (inst.Organization != "" && inst.Organization == ref.Owner) {
+			return &v1beta1.GitHubRepository{
+				Name:         ref.Name,
+				Push:         inst.Push,
+				PullRequest:  inst.PullRequest,
+				IssueComment: inst.IssueComment,
+				WorkflowRun:  inst.WorkflowRun,
+				CheckSuite:   inst.CheckSuite,
+				Release:      inst.Release,
+			}
+		}
+	}
+
 	return nil
 }
 
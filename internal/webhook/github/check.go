@@ -0,0 +1,304 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-github/v32/github"
+	"github.com/tommy351/pullup/internal/log"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+)
+
+const checkActionCompleted = "completed"
+
+// nolint: gochecknoglobals
+var defaultCheckConclusions = []v1beta1.GitHubCheckConclusion{"success"}
+
+// workflowRunEvent is triggered when a GitHub Actions workflow run is requested
+// or completed. It is not supported by go-github yet.
+type workflowRunEvent struct {
+	Action       *string              `json:"action,omitempty"`
+	WorkflowRun  *workflowRun         `json:"workflow_run,omitempty"`
+	Repo         *github.Repository   `json:"repository,omitempty"`
+	Org          *github.Organization `json:"organization,omitempty"`
+	Sender       *github.User         `json:"sender,omitempty"`
+	Installation *github.Installation `json:"installation,omitempty"`
+}
+
+func (e *workflowRunEvent) GetAction() string {
+	if e.Action == nil {
+		return ""
+	}
+
+	return *e.Action
+}
+
+func (e *workflowRunEvent) GetInstallation() *github.Installation {
+	return e.Installation
+}
+
+type workflowRun struct {
+	*github.WorkflowRun
+
+	Name *string `json:"name,omitempty"`
+}
+
+func (w *workflowRun) GetName() string {
+	if w.Name == nil {
+		return ""
+	}
+
+	return *w.Name
+}
+
+// checkEvent is a pull request event with the workflow run or the check suite
+// which triggers it, so Trigger templates for pull request events work as well.
+type checkEvent struct {
+	*github.PullRequestEvent
+
+	WorkflowRun *workflowRun       `json:"workflow_run,omitempty"`
+	CheckSuite  *github.CheckSuite `json:"check_suite,omitempty"`
+}
+
+// checkRef contains common fields of workflow_run and check_suite events.
+type checkRef struct {
+	Action       string
+	Name         string
+	Conclusion   string
+	HeadOwner    string
+	HeadBranch   string
+	HeadSHA      string
+	PullRequests []*github.PullRequest
+	Repo         *github.Repository
+	Sender       *github.User
+	Installation *github.Installation
+	GetFilter    func(repo *v1beta1.GitHubRepository) *v1beta1.GitHubCheckEventFilter
+	NewEvent     func(event *github.PullRequestEvent) *checkEvent
+}
+
+func (h *Handler) handleWorkflowRunEvent(ctx context.Context, d *delivery, event *workflowRunEvent) error {
+	run := event.WorkflowRun
+	if run == nil || run.WorkflowRun == nil {
+		return nil
+	}
+
+	headOwner := run.GetHeadRepository().GetOwner().GetLogin()
+	if headOwner == "" {
+		headOwner = event.Repo.GetOwner().GetLogin()
+	}
+
+	return h.handleCheck(ctx, d, &checkRef{
+		Action:       event.GetAction(),
+		Name:         run.GetName(),
+		Conclusion:   run.GetConclusion(),
+		HeadOwner:    headOwner,
+		HeadBranch:   run.GetHeadBranch(),
+		HeadSHA:      run.GetHeadSHA(),
+		PullRequests: run.PullRequests,
+		Repo:         event.Repo,
+		Sender:       event.Sender,
+		Installation: event.Installation,
+		GetFilter: func(repo *v1beta1.GitHubRepository) *v1beta1.GitHubCheckEventFilter {
+			return repo.WorkflowRun
+		},
+		NewEvent: func(prEvent *github.PullRequestEvent) *checkEvent {
+			return &checkEvent{PullRequestEvent: prEvent, WorkflowRun: run}
+		},
+	})
+}
+
+func (h *Handler) handleCheckSuiteEvent(ctx context.Context, d *delivery, event *github.CheckSuiteEvent) error {
+	suite := event.CheckSuite
+	if suite == nil {
+		return nil
+	}
+
+	return h.handleCheck(ctx, d, &checkRef{
+		Action:       event.GetAction(),
+		Name:         suite.GetApp().GetSlug(),
+		Conclusion:   suite.GetConclusion(),
+		HeadOwner:    event.Repo.GetOwner().GetLogin(),
+		HeadBranch:   suite.GetHeadBranch(),
+		HeadSHA:      suite.GetHeadSHA(),
+		PullRequests: suite.PullRequests,
+		Repo:         event.Repo,
+		Sender:       event.Sender,
+		Installation: event.Installation,
+		GetFilter: func(repo *v1beta1.GitHubRepository) *v1beta1.GitHubCheckEventFilter {
+			return repo.CheckSuite
+		},
+		NewEvent: func(prEvent *github.PullRequestEvent) *checkEvent {
+			return &checkEvent{PullRequestEvent: prEvent, CheckSuite: suite}
+		},
+	})
+}
+
+func (h *Handler) handleCheck(ctx context.Context, d *delivery, ref *checkRef) error {
+	if ref.Action != checkActionCompleted {
+		return nil
+	}
+
+	repoName := ref.Repo.GetFullName()
+	list, err := h.listWebhooks(ctx, d, newRepositoryRef(repoName, d))
+	if err != nil {
+		return err
+	}
+
+	logger := logr.FromContextOrDiscard(ctx).WithValues(
+		"repository", repoName,
+		"headSHA", ref.HeadSHA,
+	)
+	ctx = logr.NewContext(ctx, logger)
+
+	var (
+		pullRequests []*github.PullRequest
+		resolved     bool
+	)
+
+	for _, hook := range list.V1Beta1.Items {
+		hook := hook
+		repo := extractRepositoryBeta(&hook, newRepositoryRef(repoName, d))
+		logger := logger.WithValues("webhook", &hook)
+		ctx := logr.NewContext(ctx, logger)
+
+		if repo == nil {
+			logger.V(log.Debug).Info("Repository does not exist in the webhook")
+
+			continue
+		}
+
+		filter := ref.GetFilter(repo)
+
+		if filter == nil {
+			logger.V(log.Debug).Info("Check event filter is not set")
+
+			continue
+		}
+
+		if !hookutil.FilterWebhook(filter.Names, []string{ref.Name}) {
+			logger.V(log.Debug).Info("Skipped for the name", "name", ref.Name)
+
+			continue
+		}
+
+		if !filterByCheckConclusion(filter.Conclusions, ref.Conclusion) {
+			logger.V(log.Debug).Info("Skipped for the conclusion", "conclusion", ref.Conclusion)
+
+			continue
+		}
+
+		if !hookutil.FilterWebhook(filter.Branches, []string{ref.HeadBranch}) {
+			logger.V(log.Debug).Info("Skipped on this branch", "branch", ref.HeadBranch)
+
+			continue
+		}
+
+		if !resolved {
+			if pullRequests, err = h.resolveCheckPullRequests(ctx, ref); err != nil {
+				return err
+			}
+
+			resolved = true
+		}
+
+		if len(pullRequests) == 0 {
+			logger.V(log.Debug).Info("Pull request is not found")
+		}
+
+		for _, pr := range pullRequests {
+			prEvent := &github.PullRequestEvent{
+				Action:       github.String(ref.Action),
+				Number:       pr.Number,
+				PullRequest:  pr,
+				Repo:         ref.Repo,
+				Sender:       ref.Sender,
+				Installation: ref.Installation,
+			}
+
+			if reason := checkPullRequestPolicy(getPullRequestPolicy(repo), pr); reason != "" {
+				logger.Info("Skipped for the policy", "reason", reason, "pullRequest", pr.GetNumber())
+				h.recordUntrusted(&hook, prEvent, reason)
+
+				continue
+			}
+
+			options := &hookutil.TriggerOptions{
+				Action:        hook.Spec.Action,
+				DefaultAction: v1beta1.ActionApply,
+				Event:         ref.NewEvent(prEvent),
+				Source:        &hook,
+				Triggers:      hook.Spec.Triggers,
+				Annotations:   getPullRequestAnnotations(prEvent, &hook),
+			}
+
+			if err := h.TriggerHandler.Handle(ctx, options); err != nil {
+				return fmt.Errorf("failed to handle check event: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// resolveCheckPullRequests returns open pull requests whose head commit is the
+// head commit of the check. Pull requests in events do not contain full data
+// and are empty for forks, so they are loaded from the API. The API is called
+// anonymously when the GitHub App is not configured.
+func (h *Handler) resolveCheckPullRequests(ctx context.Context, ref *checkRef) ([]*github.PullRequest, error) {
+	owner := ref.Repo.GetOwner().GetLogin()
+	repo := ref.Repo.GetName()
+
+	gh, err := h.apiClient(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []*github.PullRequest
+
+	if len(ref.PullRequests) == 0 {
+		prs, _, err := gh.PullRequests.List(ctx, owner, repo, &github.PullRequestListOptions{
+			State: "open",
+			Head:  ref.HeadOwner + ":" + ref.HeadBranch,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pull requests: %w", err)
+		}
+
+		candidates = prs
+	} else {
+		for _, pr := range ref.PullRequests {
+			pr, _, err := gh.PullRequests.Get(ctx, owner, repo, pr.GetNumber())
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pull request: %w", err)
+			}
+
+			candidates = append(candidates, pr)
+		}
+	}
+
+	var result []*github.PullRequest
+
+	for _, pr := range candidates {
+		if pr.GetState() == "open" && pr.GetHead().GetSHA() == ref.HeadSHA {
+			result = append(result, pr)
+		}
+	}
+
+	return result, nil
+}
+
+func filterByCheckConclusion(conclusions []v1beta1.GitHubCheckConclusion, conclusion string) bool {
+	if len(conclusions) == 0 {
+		conclusions = defaultCheckConclusions
+	}
+
+	for _, c := range conclusions {
+		if string(c) == conclusion {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,39 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v32/github"
+	"github.com/tommy351/pullup/internal/gitutil"
+)
+
+// nolint: gochecknoglobals
+var deleteRefTypes = map[string]gitutil.RefType{
+	"branch": gitutil.RefTypeBranch,
+	"tag":    gitutil.RefTypeTag,
+}
+
+// handleDeleteEvent handles a delete event as a deleted push, so resource
+// names rendered from push events match.
+func (h *Handler) handleDeleteEvent(ctx context.Context, d *delivery, event *github.DeleteEvent) error {
+	refType, ok := deleteRefTypes[event.GetRefType()]
+	if !ok || event.Repo == nil {
+		return nil
+	}
+
+	return h.handlePushEvent(ctx, d, &github.PushEvent{
+		Ref:     github.String("refs/" + string(refType) + "/" + event.GetRef()),
+		Deleted: github.Bool(true),
+		Repo: &github.PushEventRepository{
+			ID:       event.Repo.ID,
+			NodeID:   event.Repo.NodeID,
+			Name:     event.Repo.Name,
+			FullName: event.Repo.FullName,
+			Owner:    event.Repo.Owner,
+			Private:  event.Repo.Private,
+			HTMLURL:  event.Repo.HTMLURL,
+		},
+		Sender:       event.Sender,
+		Installation: event.Installation,
+	})
+}
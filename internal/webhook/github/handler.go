@@ -1,13 +1,18 @@
 package github
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"strconv"
 
 	"github.com/go-logr/logr"
 	"github.com/google/go-github/v32/github"
 	"github.com/google/wire"
+	"github.com/tommy351/pullup/internal/githubapp"
 	"github.com/tommy351/pullup/internal/httputil"
 	"github.com/tommy351/pullup/internal/webhook/hookutil"
 	"github.com/tommy351/pullup/pkg/apis/pullup/v1alpha1"
@@ -20,16 +25,21 @@ import (
 // +kubebuilder:rbac:groups=pullup.dev,resources=webhooks,verbs=get;list;watch
 // +kubebuilder:rbac:groups=pullup.dev,resources=githubwebhooks,verbs=get;list;watch
 // +kubebuilder:rbac:groups=pullup.dev,resources=resourcesets,verbs=create;patch;delete;deletecollection
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 
 const (
-	nameField      = "spec.repositories.githubName"
-	repoTypeGitHub = "github"
+	nameField         = "spec.repositories.githubName"
+	patternField      = "spec.repositories.pattern"
+	installationField = "spec.installations.id"
+	organizationField = "spec.installations.organization"
+	repoTypeGitHub    = "github"
 )
 
 // HandlerConfigSet provides a handler config.
 // nolint: gochecknoglobals
 var HandlerConfigSet = wire.NewSet(
 	wire.Struct(new(HandlerConfig), "*"),
+	NewAppClient,
 )
 
 // HandlerSet provides a handler.
@@ -40,11 +50,13 @@ var HandlerSet = wire.NewSet(
 )
 
 type Config struct {
-	Secret string `mapstructure:"secret"`
+	Secret string           `mapstructure:"secret"`
+	App    githubapp.Config `mapstructure:"app"`
 }
 
 type HandlerConfig struct {
 	Config         Config
+	App            *githubapp.Client
 	Client         client.Client
 	Recorder       record.EventRecorder
 	TriggerHandler hookutil.TriggerHandler
@@ -75,7 +87,54 @@ func NewHandler(conf HandlerConfig, mgr manager.Manager) (*Handler, error) {
 		var result []string
 
 		for _, repo := range obj.(*v1beta1.GitHubWebhook).Spec.Repositories {
-			result = append(result, repo.Name)
+			if !isRepositoryPattern(repo.Name) {
+				result = append(result, repo.Name)
+			}
+		}
+
+		return result
+	})
+	if err != nil {
+		return nil, fmt.Errorf("index failed: %w", err)
+	}
+
+	err = indexer.IndexField(context.TODO(), &v1beta1.GitHubWebhook{}, patternField, func(obj client.Object) []string {
+		var result []string
+
+		for _, repo := range obj.(*v1beta1.GitHubWebhook).Spec.Repositories {
+			if isRepositoryPattern(repo.Name) {
+				result = append(result, getRepositoryPatternKey(repo.Name))
+			}
+		}
+
+		return result
+	})
+	if err != nil {
+		return nil, fmt.Errorf("index failed: %w", err)
+	}
+
+	err = indexer.IndexField(context.TODO(), &v1beta1.GitHubWebhook{}, installationField, func(obj client.Object) []string {
+		var result []string
+
+		for _, inst := range obj.(*v1beta1.GitHubWebhook).Spec.Installations {
+			if inst.ID != 0 {
+				result = append(result, strconv.FormatInt(inst.ID, 10))
+			}
+		}
+
+		return result
+	})
+	if err != nil {
+		return nil, fmt.Errorf("index failed: %w", err)
+	}
+
+	err = indexer.IndexField(context.TODO(), &v1beta1.GitHubWebhook{}, organizationField, func(obj client.Object) []string {
+		var result []string
+
+		for _, inst := range obj.(*v1beta1.GitHubWebhook).Spec.Installations {
+			if inst.Organization != "" {
+				result = append(result, inst.Organization)
+			}
 		}
 
 		return result
@@ -89,9 +148,18 @@ func NewHandler(conf HandlerConfig, mgr manager.Manager) (*Handler, error) {
 	}, nil
 }
 
+func NewAppClient(conf Config) (*githubapp.Client, error) {
+	client, err := githubapp.NewClient(conf.App)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GitHub App client: %w", err)
+	}
+
+	return client, nil
+}
+
 func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) error {
 	logger := logr.FromContextOrDiscard(r.Context())
-	payload, err := h.parsePayload(r)
+	d, payload, err := h.parsePayload(r)
 	if err != nil {
 		logger.Error(err, "Invalid payload")
 
@@ -102,29 +170,86 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) error {
 		})
 	}
 
-	if err := h.handlePayload(r, payload); err != nil {
+	if !h.validateAppSignature(d) {
+		return httputil.Response{
+			StatusCode: http.StatusForbidden,
+			Errors: []httputil.Error{
+				{Description: "Signature mismatch"},
+			},
+		}
+	}
+
+	if err := h.handlePayload(r.Context(), d, payload); err != nil {
 		return err
 	}
 
 	return httputil.JSON(w, http.StatusOK, &httputil.Response{})
 }
 
-func (h *Handler) handlePayload(r *http.Request, payload interface{}) error {
+func (h *Handler) handlePayload(ctx context.Context, d *delivery, payload interface{}) error {
 	switch event := payload.(type) {
 	case *github.PushEvent:
-		return h.handlePushEvent(r.Context(), event)
+		return h.handlePushEvent(ctx, d, event)
+	case *github.DeleteEvent:
+		return h.handleDeleteEvent(ctx, d, event)
 	case *github.PullRequestEvent:
-		return h.handlePullRequestEvent(r.Context(), event)
+		return h.handlePullRequestEvent(ctx, d, event)
+	case *github.IssueCommentEvent:
+		return h.handleIssueCommentEvent(ctx, d, event)
+	case *workflowRunEvent:
+		return h.handleWorkflowRunEvent(ctx, d, event)
+	case *github.CheckSuiteEvent:
+		return h.handleCheckSuiteEvent(ctx, d, event)
+	case *github.ReleaseEvent:
+		return h.handleReleaseEvent(ctx, d, event)
+	case *github.InstallationEvent:
+		return h.handleInstallationEvent(ctx, d, event)
+	case *github.InstallationRepositoriesEvent:
+		return h.handleInstallationRepositoriesEvent(ctx, d, event)
 	}
 
 	return nil
 }
 
-func (h *Handler) parsePayload(r *http.Request) (interface{}, error) {
-	payload, err := github.ValidatePayload(r, []byte(h.Config.Secret))
+func (h *Handler) parsePayload(r *http.Request) (*delivery, interface{}, error) {
+	body, err := ioutil.ReadAll(r.Body)
 	if err != nil {
-		return nil, fmt.Errorf("invalid github payload: %w", err)
+		return nil, nil, fmt.Errorf("failed to read body: %w", err)
+	}
+
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	// Signatures are validated against the secrets of matched webhooks later.
+	payload, err := github.ValidatePayload(r, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid github payload: %w", err)
+	}
+
+	event, err := parseWebHook(github.WebHookType(r), payload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse github payload: %w", err)
+	}
+
+	d := newDelivery(r, body)
+
+	if e, ok := event.(installationEvent); ok {
+		d.InstallationID = e.GetInstallation().GetID()
+	}
+
+	return d, event, nil
+}
+
+func parseWebHook(messageType string, payload []byte) (interface{}, error) {
+	// workflow_run events are not supported by go-github yet.
+	if messageType == "workflow_run" {
+		event := new(workflowRunEvent)
+
+		if err := json.Unmarshal(payload, event); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal workflow run event: %w", err)
+		}
+
+		return event, nil
 	}
 
-	return github.ParseWebHook(github.WebHookType(r), payload)
+	return github.ParseWebHook(messageType, payload)
 }
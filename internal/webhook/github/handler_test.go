@@ -3,7 +3,15 @@ package github
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1" // nolint: gosec
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -14,8 +22,10 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/tommy351/pullup/internal/fakegithub"
+	"github.com/tommy351/pullup/internal/githubapp"
 	"github.com/tommy351/pullup/internal/golden"
 	"github.com/tommy351/pullup/internal/k8s"
+	"github.com/tommy351/pullup/internal/middleware"
 	"github.com/tommy351/pullup/internal/random"
 	"github.com/tommy351/pullup/internal/testenv"
 	"github.com/tommy351/pullup/internal/webhook/hookutil"
@@ -36,10 +46,15 @@ var _ = Describe("Handler", func() {
 		namespaceMap *random.NamespaceMap
 	)
 
+	encodeBody := func(body interface{}) []byte {
+		data, err := json.Marshal(body)
+		Expect(err).NotTo(HaveOccurred())
+
+		return data
+	}
+
 	newRequest := func(event string, body interface{}) *http.Request {
-		var buf bytes.Buffer
-		Expect(json.NewEncoder(&buf).Encode(body)).To(Succeed())
-		req := httptest.NewRequest(http.MethodPost, "/", &buf)
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(encodeBody(body)))
 		req.Header.Set("Content-Type", "application/json")
 		req.Header.Set("X-Github-Event", event)
 
@@ -48,6 +63,13 @@ var _ = Describe("Handler", func() {
 		return req.WithContext(ctx)
 	}
 
+	sign := func(body interface{}, secret string) string {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(encodeBody(body))
+
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
 	loadTestData := func(name string) []client.Object {
 		data, err := k8s.LoadObjects(testenv.GetScheme(), fmt.Sprintf("testdata/%s.yml", name))
 		Expect(err).NotTo(HaveOccurred())
@@ -102,6 +124,23 @@ var _ = Describe("Handler", func() {
 		})
 	}
 
+	newAppClient := func(secret string) *githubapp.Client {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).NotTo(HaveOccurred())
+
+		client, err := githubapp.NewClient(githubapp.Config{
+			ID: 46,
+			PrivateKey: string(pem.EncodeToMemory(&pem.Block{
+				Type:  "RSA PRIVATE KEY",
+				Bytes: x509.MarshalPKCS1PrivateKey(key),
+			})),
+			WebhookSecret: secret,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		return client
+	}
+
 	setPullRequestEvent := func(event *github.PullRequestEvent) {
 		BeforeEach(func() {
 			req = newRequest("pull_request", event)
@@ -119,7 +158,9 @@ var _ = Describe("Handler", func() {
 		mgr, err = testenv.NewManager()
 		Expect(err).NotTo(HaveOccurred())
 
-		handlerConfig := NewHandlerConfig(Config{}, mgr)
+		handlerConfig, err := NewHandlerConfig(Config{}, mgr)
+		Expect(err).NotTo(HaveOccurred())
+
 		handler, err = NewHandler(handlerConfig, mgr)
 		Expect(err).NotTo(HaveOccurred())
 
@@ -130,7 +171,10 @@ var _ = Describe("Handler", func() {
 
 	JustBeforeEach(func() {
 		recorder = httptest.NewRecorder()
-		hookutil.NewHandler(handler.Handle).ServeHTTP(recorder, req)
+		recovery := middleware.Recovery(func(w http.ResponseWriter, r *http.Request, err error) {
+			w.WriteHeader(http.StatusInternalServerError)
+		})
+		recovery(hookutil.NewHandler(handler.Handle)).ServeHTTP(recorder, req)
 	})
 
 	AfterEach(func() {
@@ -147,6 +191,83 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	When("event type = installation", func() {
+		var event *github.InstallationEvent
+
+		BeforeEach(func() {
+			event = &github.InstallationEvent{
+				Action:       github.String("created"),
+				Installation: &github.Installation{ID: github.Int64(99)},
+				Repositories: []*github.Repository{
+					{FullName: github.String("foo/bar")},
+				},
+			}
+			req = newRequest("installation", event)
+		})
+
+		It("should respond 200", func() {
+			Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+		})
+
+		testSkipped()
+
+		When("GitHub App webhook secret is set", func() {
+			BeforeEach(func() {
+				handler.App = newAppClient("app-secret")
+			})
+
+			When("signature matches", func() {
+				BeforeEach(func() {
+					req.Header.Set("X-Hub-Signature-256", sign(event, "app-secret"))
+				})
+
+				It("should respond 200", func() {
+					Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+				})
+			})
+
+			When("signed with the global secret", func() {
+				BeforeEach(func() {
+					handler.Config.Secret = "secret"
+					req.Header.Set("X-Hub-Signature-256", sign(event, "secret"))
+				})
+
+				It("should respond 403", func() {
+					Expect(recorder).To(HaveHTTPStatus(http.StatusForbidden))
+				})
+			})
+
+			When("signature is missing", func() {
+				It("should respond 403", func() {
+					Expect(recorder).To(HaveHTTPStatus(http.StatusForbidden))
+				})
+			})
+		})
+
+		When("GitHub App webhook secret is not set", func() {
+			BeforeEach(func() {
+				handler.App = newAppClient("")
+				handler.Config.Secret = "secret"
+			})
+
+			When("signed with the global secret", func() {
+				BeforeEach(func() {
+					req.Header.Set("X-Hub-Signature-256", sign(event, "secret"))
+				})
+
+				It("should respond 200", func() {
+					Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+				})
+			})
+
+			When("signature is missing", func() {
+				It("should respond 403", func() {
+					Expect(recorder).To(HaveHTTPStatus(http.StatusForbidden))
+				})
+			})
+		})
+	})
+
 	Context("v1alpha1.Webhook", func() {
 		testApplySuccess := func() {
 			When("resource set exists", func() {
@@ -525,6 +646,58 @@ var _ = Describe("Handler", func() {
 				})
 			})
 
+			When("paths is set", func() {
+				name := "beta/push-paths"
+
+				When("included", func() {
+					setPushEvent(fakegithub.NewPushEvent(fakegithub.SetPushEventModifiedFiles("foo/main.go")))
+					testSuccess(name)
+					testTriggered()
+				})
+
+				When("excluded", func() {
+					setPushEvent(fakegithub.NewPushEvent(fakegithub.SetPushEventModifiedFiles("foo/README.md")))
+					testSuccess(name)
+					testSkipped()
+				})
+
+				When("not included", func() {
+					setPushEvent(fakegithub.NewPushEvent(fakegithub.SetPushEventModifiedFiles("bar/main.go")))
+					testSuccess(name)
+					testSkipped()
+				})
+
+				When("some files are excluded", func() {
+					setPushEvent(fakegithub.NewPushEvent(fakegithub.SetPushEventModifiedFiles("foo/README.md", "foo/main.go")))
+					testSuccess(name)
+					testTriggered()
+				})
+			})
+
+			When("branch is deleted", func() {
+				setPushEvent(fakegithub.NewPushEvent(fakegithub.SetPushEventDeleted()))
+				testSuccess("beta/resource-exists")
+
+				It("should delete the resource", func() {
+					Expect(getChanges()).To(ContainElement(testenv.Change{
+						Type: "delete",
+						NamespacedName: types.NamespacedName{
+							Name:      "foobar",
+							Namespace: namespaceMap.GetRandom("test"),
+						},
+						GroupVersionKind: v1beta1.GroupVersion.WithKind("ResourceTemplate"),
+					}))
+				})
+
+				It("should record Deleted event", func() {
+					Expect(mgr.WaitForEvent(testenv.EventData{
+						Type:    v1.EventTypeNormal,
+						Reason:  hookutil.ReasonDeleted,
+						Message: "Deleted resource template: foobar",
+					})).To(BeTrue())
+				})
+			})
+
 			When("action is set", func() {
 				setPushEvent(fakegithub.NewPushEvent())
 				testSuccess("beta/action")
@@ -535,6 +708,65 @@ var _ = Describe("Handler", func() {
 			})
 		})
 
+		When("event type = delete", func() {
+			setDeleteEvent := func(event *github.DeleteEvent) {
+				BeforeEach(func() {
+					req = newRequest("delete", event)
+				})
+			}
+
+			When("deleting a branch", func() {
+				setDeleteEvent(fakegithub.NewDeleteEvent())
+				testSuccess("beta/resource-exists")
+
+				It("should delete the resource", func() {
+					Expect(getChanges()).To(ContainElement(testenv.Change{
+						Type: "delete",
+						NamespacedName: types.NamespacedName{
+							Name:      "foobar",
+							Namespace: namespaceMap.GetRandom("test"),
+						},
+						GroupVersionKind: v1beta1.GroupVersion.WithKind("ResourceTemplate"),
+					}))
+				})
+
+				It("should record Deleted event", func() {
+					Expect(mgr.WaitForEvent(testenv.EventData{
+						Type:    v1.EventTypeNormal,
+						Reason:  hookutil.ReasonDeleted,
+						Message: "Deleted resource template: foobar",
+					})).To(BeTrue())
+				})
+			})
+
+			When("deleting a tag", func() {
+				When("tag filter is not set", func() {
+					setDeleteEvent(fakegithub.NewDeleteEvent(fakegithub.SetDeleteEventTag("foo")))
+					testSuccess("beta/resource-exists")
+					testSkipped()
+				})
+
+				When("tags.include is set", func() {
+					setDeleteEvent(fakegithub.NewDeleteEvent(fakegithub.SetDeleteEventTag("foo")))
+					testSuccess("beta/push-tag-include")
+
+					It("should record NotExist event", func() {
+						Expect(mgr.WaitForEvent(testenv.EventData{
+							Type:    v1.EventTypeNormal,
+							Reason:  hookutil.ReasonNotExist,
+							Message: "Resource template does not exist: foobar",
+						})).To(BeTrue())
+					})
+				})
+			})
+
+			When("push event filter is not set", func() {
+				setDeleteEvent(fakegithub.NewDeleteEvent())
+				testSuccess("beta/without-event-filters")
+				testSkipped()
+			})
+		})
+
 		When("event type = pull_request", func() {
 			When("push event filter is not set", func() {
 				setPullRequestEvent(fakegithub.NewPullRequestEvent(fakegithub.SetPullRequestEventAction("opened")))
@@ -610,6 +842,176 @@ var _ = Describe("Handler", func() {
 				})
 			})
 
+			When("policy is set", func() {
+				name := "beta/pull-request-policy"
+
+				testUntrusted := func(reason string) {
+					It("should record Untrusted event", func() {
+						Expect(mgr.WaitForEvent(testenv.EventData{
+							Type:    v1.EventTypeWarning,
+							Reason:  ReasonUntrusted,
+							Message: "Skipped pull request foo/bar#46: " + reason,
+						})).To(BeTrue())
+					})
+				}
+
+				When("author association is allowed", func() {
+					setPullRequestEvent(fakegithub.NewPullRequestEvent(fakegithub.SetPullRequestEventAuthor("bob", "MEMBER")))
+					testSuccess(name)
+					testTriggered()
+				})
+
+				When("login is allowed", func() {
+					setPullRequestEvent(fakegithub.NewPullRequestEvent(fakegithub.SetPullRequestEventAuthor("alice", "NONE")))
+					testSuccess(name)
+					testTriggered()
+				})
+
+				When("author is not allowed", func() {
+					setPullRequestEvent(fakegithub.NewPullRequestEvent(fakegithub.SetPullRequestEventAuthor("bob", "NONE")))
+					testSuccess(name)
+					testSkipped()
+					testUntrusted(`author "bob" (NONE) is not allowed`)
+				})
+
+				When("head repository is a fork", func() {
+					setPullRequestEvent(fakegithub.NewPullRequestEvent(
+						fakegithub.SetPullRequestEventAuthor("bob", "MEMBER"),
+						fakegithub.SetPullRequestEventFork("bob"),
+					))
+					testSuccess(name)
+					testSkipped()
+					testUntrusted("pull requests from forks are not allowed")
+				})
+
+				When("pull request has the approval label", func() {
+					setPullRequestEvent(fakegithub.NewPullRequestEvent(
+						fakegithub.SetPullRequestEventAction("reopened"),
+						fakegithub.SetPullRequestEventAuthor("bob", "NONE"),
+						fakegithub.SetPullRequestEventFork("bob"),
+						fakegithub.SetPullRequestEventLabels([]string{"safe-to-deploy"}),
+					))
+					testSuccess(name)
+					testTriggered()
+				})
+
+				When("new commits are pushed to an approved pull request", func() {
+					var server *fakegithub.Server
+
+					setPullRequestEvent(fakegithub.NewPullRequestEvent(
+						fakegithub.SetPullRequestEventAction("synchronize"),
+						fakegithub.SetPullRequestEventAuthor("bob", "NONE"),
+						fakegithub.SetPullRequestEventFork("bob"),
+						fakegithub.SetPullRequestEventLabels([]string{"safe-to-deploy"}),
+					))
+
+					BeforeEach(func() {
+						server = fakegithub.NewServer()
+
+						var err error
+						handler.App, err = server.NewAppClient()
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					AfterEach(func() {
+						server.Close()
+					})
+
+					testSuccess(name)
+					testSkipped()
+					testUntrusted(`new commits must be approved again: author "bob" (NONE) is not allowed`)
+
+					It("should remove the approval label", func() {
+						Expect(server.RemovedLabels(46)).To(Equal([]string{"safe-to-deploy"}))
+					})
+				})
+
+				When("new commits are pushed to a trusted pull request", func() {
+					setPullRequestEvent(fakegithub.NewPullRequestEvent(
+						fakegithub.SetPullRequestEventAction("synchronize"),
+						fakegithub.SetPullRequestEventAuthor("bob", "MEMBER"),
+						fakegithub.SetPullRequestEventLabels([]string{"safe-to-deploy"}),
+					))
+					testSuccess(name)
+					testTriggered()
+				})
+
+				When("approval label is added", func() {
+					setPullRequestEvent(fakegithub.NewPullRequestEvent(
+						fakegithub.SetPullRequestEventAction("labeled"),
+						fakegithub.SetPullRequestEventAuthor("bob", "NONE"),
+						fakegithub.SetPullRequestEventFork("bob"),
+						fakegithub.SetPullRequestEventLabels([]string{"safe-to-deploy"}),
+						fakegithub.SetPullRequestEventTriggeredLabel("safe-to-deploy"),
+					))
+					testSuccess(name)
+					testTriggered()
+				})
+
+				When("other label is added", func() {
+					setPullRequestEvent(fakegithub.NewPullRequestEvent(
+						fakegithub.SetPullRequestEventAction("labeled"),
+						fakegithub.SetPullRequestEventAuthor("bob", "MEMBER"),
+						fakegithub.SetPullRequestEventLabels([]string{"foo"}),
+						fakegithub.SetPullRequestEventTriggeredLabel("foo"),
+					))
+					testSuccess(name)
+					testSkipped()
+				})
+
+				When("forks are allowed", func() {
+					setPullRequestEvent(fakegithub.NewPullRequestEvent(fakegithub.SetPullRequestEventFork("bob")))
+					testSuccess("beta/pull-request-policy-forks")
+					testTriggered()
+				})
+			})
+
+			When("paths is set", func() {
+				name := "beta/pull-request-paths"
+				var server *fakegithub.Server
+
+				setPullRequestEvent(fakegithub.NewPullRequestEvent())
+
+				setFiles := func(files ...string) {
+					BeforeEach(func() {
+						server.SetPullRequestFiles(46, files...)
+					})
+				}
+
+				BeforeEach(func() {
+					server = fakegithub.NewServer()
+					handler.Config.App.BaseURL = server.URL
+				})
+
+				AfterEach(func() {
+					server.Close()
+				})
+
+				When("included", func() {
+					setFiles("foo/main.go")
+					testSuccess(name)
+					testTriggered()
+				})
+
+				When("excluded", func() {
+					setFiles("foo/README.md")
+					testSuccess(name)
+					testSkipped()
+				})
+
+				When("not included", func() {
+					setFiles("bar/main.go")
+					testSuccess(name)
+					testSkipped()
+				})
+
+				When("some files are excluded", func() {
+					setFiles("foo/README.md", "foo/main.go")
+					testSuccess(name)
+					testTriggered()
+				})
+			})
+
 			When("branches.include is set", func() {
 				name := "beta/pull-request-branch-include"
 
@@ -745,6 +1147,691 @@ var _ = Describe("Handler", func() {
 					Expect(getChanges()).To(BeEmpty())
 				})
 			})
+
+			When("deployments is enabled", func() {
+				testAnnotations := func() {
+					It("should set GitHub annotations", func() {
+						rt := new(v1beta1.ResourceTemplate)
+						Expect(handler.Client.Get(context.TODO(), types.NamespacedName{
+							Name:      "foobar",
+							Namespace: namespaceMap.GetRandom("test"),
+						}, rt)).To(Succeed())
+						Expect(rt.Annotations).To(HaveKeyWithValue(v1beta1.AnnotationGitHubRepository, "foo/bar"))
+						Expect(rt.Annotations).To(HaveKeyWithValue(v1beta1.AnnotationGitHubSHA, "0ce4cf0450de14c6555c563fa9d36be67e69aa2f"))
+					})
+				}
+
+				When("resource template does not exist", func() {
+					setPullRequestEvent(fakegithub.NewPullRequestEvent())
+					testSuccess("beta/deployments")
+					testAnnotations()
+				})
+
+				When("resource template exists", func() {
+					setPullRequestEvent(fakegithub.NewPullRequestEvent(fakegithub.SetPullRequestEventAction("synchronize")))
+					testSuccess("beta/deployments-resource-exists")
+					testAnnotations()
+				})
+			})
+
+			When("comment is enabled", func() {
+				setPullRequestEvent(fakegithub.NewPullRequestEvent())
+				testSuccess("beta/comment")
+
+				It("should set GitHub annotations", func() {
+					rt := new(v1beta1.ResourceTemplate)
+					Expect(handler.Client.Get(context.TODO(), types.NamespacedName{
+						Name:      "foobar",
+						Namespace: namespaceMap.GetRandom("test"),
+					}, rt)).To(Succeed())
+					Expect(rt.Annotations).To(Equal(map[string]string{
+						v1beta1.AnnotationGitHubRepository:  "foo/bar",
+						v1beta1.AnnotationGitHubPullRequest: "46",
+					}))
+				})
+			})
+		})
+
+		When("event type = issue_comment", func() {
+			var server *fakegithub.Server
+
+			setIssueCommentEvent := func(event *github.IssueCommentEvent) {
+				BeforeEach(func() {
+					req = newRequest("issue_comment", event)
+				})
+			}
+
+			BeforeEach(func() {
+				server = fakegithub.NewServer()
+				server.AddPullRequest(fakegithub.NewPullRequest())
+
+				var err error
+				handler.App, err = server.NewAppClient()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				server.Close()
+			})
+
+			When("command = deploy", func() {
+				setIssueCommentEvent(fakegithub.NewIssueCommentEvent())
+				testSuccess("beta/issue-comment")
+
+				It("should record Created event", func() {
+					Expect(mgr.WaitForEvent(testenv.EventData{
+						Type:    v1.EventTypeNormal,
+						Reason:  hookutil.ReasonCreated,
+						Message: "Created resource template: foobar",
+					})).To(BeTrue())
+				})
+
+				It("should load the pull request into the event", func() {
+					rt := new(v1beta1.ResourceTemplate)
+					Expect(handler.Client.Get(context.TODO(), types.NamespacedName{
+						Name:      "foobar",
+						Namespace: namespaceMap.GetRandom("test"),
+					}, rt)).To(Succeed())
+
+					var data map[string]map[string]interface{}
+					Expect(json.Unmarshal(rt.Spec.Data.Raw, &data)).To(Succeed())
+					Expect(data["event"]).To(HaveKeyWithValue("number", BeEquivalentTo(46)))
+					Expect(data["event"]).To(HaveKeyWithValue("command", "deploy"))
+					Expect(data["event"]).To(HaveKeyWithValue("pull_request", HaveKeyWithValue("head", HaveKeyWithValue("ref", "test"))))
+				})
+			})
+
+			When("command = redeploy", func() {
+				setIssueCommentEvent(fakegithub.NewIssueCommentEvent(fakegithub.SetIssueCommentEventBody("/pullup redeploy")))
+				testSuccess("beta/issue-comment-resource-exists")
+
+				It("should record Updated event", func() {
+					Expect(mgr.WaitForEvent(testenv.EventData{
+						Type:    v1.EventTypeNormal,
+						Reason:  hookutil.ReasonUpdated,
+						Message: "Updated resource template: foobar",
+					})).To(BeTrue())
+				})
+			})
+
+			When("command = destroy", func() {
+				setIssueCommentEvent(fakegithub.NewIssueCommentEvent(fakegithub.SetIssueCommentEventBody("/pullup destroy")))
+				testSuccess("beta/issue-comment-resource-exists")
+
+				It("should record Deleted event", func() {
+					Expect(mgr.WaitForEvent(testenv.EventData{
+						Type:    v1.EventTypeNormal,
+						Reason:  hookutil.ReasonDeleted,
+						Message: "Deleted resource template: foobar",
+					})).To(BeTrue())
+				})
+			})
+
+			When("command is unknown", func() {
+				setIssueCommentEvent(fakegithub.NewIssueCommentEvent(fakegithub.SetIssueCommentEventBody("/pullup foo")))
+				testSuccess("beta/issue-comment")
+				testSkipped()
+			})
+
+			When("comment is not a command", func() {
+				setIssueCommentEvent(fakegithub.NewIssueCommentEvent(fakegithub.SetIssueCommentEventBody("LGTM")))
+				testSuccess("beta/issue-comment")
+				testSkipped()
+			})
+
+			When("author association is not allowed", func() {
+				setIssueCommentEvent(fakegithub.NewIssueCommentEvent(fakegithub.SetIssueCommentEventAuthorAssociation("NONE")))
+				testSuccess("beta/issue-comment")
+				testSkipped()
+			})
+
+			When("comment is on an issue", func() {
+				setIssueCommentEvent(fakegithub.NewIssueCommentEvent(fakegithub.UnsetIssueCommentEventPullRequest()))
+				testSuccess("beta/issue-comment")
+				testSkipped()
+			})
+
+			When("issue comment event filter is not set", func() {
+				setIssueCommentEvent(fakegithub.NewIssueCommentEvent())
+				testSuccess("beta/resource-not-exist")
+				testSkipped()
+			})
+
+			When("prefix and authorAssociations are set", func() {
+				name := "beta/issue-comment-options"
+
+				When("match", func() {
+					setIssueCommentEvent(fakegithub.NewIssueCommentEvent(
+						fakegithub.SetIssueCommentEventBody("/preview deploy"),
+						fakegithub.SetIssueCommentEventAuthorAssociation("CONTRIBUTOR"),
+					))
+					testSuccess(name)
+					testTriggered()
+				})
+
+				When("prefix does not match", func() {
+					setIssueCommentEvent(fakegithub.NewIssueCommentEvent(
+						fakegithub.SetIssueCommentEventAuthorAssociation("CONTRIBUTOR"),
+					))
+					testSuccess(name)
+					testSkipped()
+				})
+
+				When("author association does not match", func() {
+					setIssueCommentEvent(fakegithub.NewIssueCommentEvent(
+						fakegithub.SetIssueCommentEventBody("/preview deploy"),
+					))
+					testSuccess(name)
+					testSkipped()
+				})
+			})
+
+			When("pull request policy is set", func() {
+				name := "beta/issue-comment-policy"
+
+				setIssueCommentEvent(fakegithub.NewIssueCommentEvent())
+
+				When("pull request is not trusted", func() {
+					BeforeEach(func() {
+						server.AddPullRequest(fakegithub.NewPullRequest(fakegithub.SetPullRequestEventFork("bob")))
+					})
+
+					testSuccess(name)
+					testSkipped()
+				})
+
+				When("pull request is approved", func() {
+					BeforeEach(func() {
+						server.AddPullRequest(fakegithub.NewPullRequest(
+							fakegithub.SetPullRequestEventFork("bob"),
+							fakegithub.SetPullRequestEventLabels([]string{"safe-to-deploy"}),
+						))
+					})
+
+					testSuccess(name)
+					testTriggered()
+				})
+			})
+
+			When("GitHub App is not configured", func() {
+				setIssueCommentEvent(fakegithub.NewIssueCommentEvent())
+
+				BeforeEach(func() {
+					handler.App = nil
+					handler.Config.App.BaseURL = server.URL
+				})
+
+				testSuccess("beta/issue-comment")
+				testTriggered()
+			})
+		})
+
+		When("event type = workflow_run", func() {
+			var server *fakegithub.Server
+
+			setWorkflowRunEvent := func(event *fakegithub.WorkflowRunEvent) {
+				BeforeEach(func() {
+					req = newRequest("workflow_run", event)
+				})
+			}
+
+			BeforeEach(func() {
+				server = fakegithub.NewServer()
+				server.AddPullRequest(fakegithub.NewPullRequest())
+
+				var err error
+				handler.App, err = server.NewAppClient()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				server.Close()
+			})
+
+			When("workflow run succeeded", func() {
+				setWorkflowRunEvent(fakegithub.NewWorkflowRunEvent())
+				testSuccess("beta/workflow-run")
+
+				It("should record Created event", func() {
+					Expect(mgr.WaitForEvent(testenv.EventData{
+						Type:    v1.EventTypeNormal,
+						Reason:  hookutil.ReasonCreated,
+						Message: "Created resource template: foobar",
+					})).To(BeTrue())
+				})
+
+				It("should load the pull request into the event", func() {
+					rt := new(v1beta1.ResourceTemplate)
+					Expect(handler.Client.Get(context.TODO(), types.NamespacedName{
+						Name:      "foobar",
+						Namespace: namespaceMap.GetRandom("test"),
+					}, rt)).To(Succeed())
+
+					var data map[string]map[string]interface{}
+					Expect(json.Unmarshal(rt.Spec.Data.Raw, &data)).To(Succeed())
+					Expect(data["event"]).To(HaveKeyWithValue("number", BeEquivalentTo(46)))
+					Expect(data["event"]).To(HaveKeyWithValue("pull_request", HaveKeyWithValue("head", HaveKeyWithValue("ref", "test"))))
+					Expect(data["event"]).To(HaveKeyWithValue("workflow_run", HaveKeyWithValue("name", "CI")))
+				})
+			})
+
+			When("head branch is in a fork", func() {
+				setWorkflowRunEvent(fakegithub.NewWorkflowRunEvent(fakegithub.UnsetWorkflowRunEventPullRequests()))
+				testSuccess("beta/workflow-run")
+				testTriggered()
+			})
+
+			When("GitHub App is not configured", func() {
+				setWorkflowRunEvent(fakegithub.NewWorkflowRunEvent())
+
+				BeforeEach(func() {
+					handler.App = nil
+					handler.Config.App.BaseURL = server.URL
+				})
+
+				testSuccess("beta/workflow-run")
+				testTriggered()
+			})
+
+			When("workflow run failed", func() {
+				setWorkflowRunEvent(fakegithub.NewWorkflowRunEvent(fakegithub.SetWorkflowRunEventConclusion("failure")))
+				testSuccess("beta/workflow-run")
+				testSkipped()
+			})
+
+			When("workflow run is requested", func() {
+				setWorkflowRunEvent(fakegithub.NewWorkflowRunEvent(fakegithub.SetWorkflowRunEventAction("requested")))
+				testSuccess("beta/workflow-run")
+				testSkipped()
+			})
+
+			When("head commit is outdated", func() {
+				setWorkflowRunEvent(fakegithub.NewWorkflowRunEvent(fakegithub.SetWorkflowRunEventHeadSHA("b436f6eb3356504235c0c9a8e74605c820d8d9cc")))
+				testSuccess("beta/workflow-run")
+				testSkipped()
+			})
+
+			When("workflow run event filter is not set", func() {
+				setWorkflowRunEvent(fakegithub.NewWorkflowRunEvent())
+				testSuccess("beta/resource-not-exist")
+				testSkipped()
+			})
+
+			When("pull request policy is set", func() {
+				name := "beta/workflow-run-policy"
+
+				setWorkflowRunEvent(fakegithub.NewWorkflowRunEvent())
+
+				When("pull request is not trusted", func() {
+					BeforeEach(func() {
+						server.AddPullRequest(fakegithub.NewPullRequest(fakegithub.SetPullRequestEventFork("bob")))
+					})
+
+					testSuccess(name)
+					testSkipped()
+				})
+
+				When("pull request is approved", func() {
+					BeforeEach(func() {
+						server.AddPullRequest(fakegithub.NewPullRequest(
+							fakegithub.SetPullRequestEventFork("bob"),
+							fakegithub.SetPullRequestEventLabels([]string{"safe-to-deploy"}),
+						))
+					})
+
+					testSuccess(name)
+					testTriggered()
+				})
+			})
+
+			When("names, branches and conclusions are set", func() {
+				name := "beta/workflow-run-options"
+
+				When("match", func() {
+					setWorkflowRunEvent(fakegithub.NewWorkflowRunEvent(
+						fakegithub.SetWorkflowRunEventName("Deploy"),
+						fakegithub.SetWorkflowRunEventConclusion("neutral"),
+					))
+					testSuccess(name)
+					testTriggered()
+				})
+
+				When("name does not match", func() {
+					setWorkflowRunEvent(fakegithub.NewWorkflowRunEvent())
+					testSuccess(name)
+					testSkipped()
+				})
+
+				When("branch does not match", func() {
+					setWorkflowRunEvent(fakegithub.NewWorkflowRunEvent(
+						fakegithub.SetWorkflowRunEventName("Deploy"),
+						fakegithub.SetWorkflowRunEventHeadBranch("main"),
+					))
+					testSuccess(name)
+					testSkipped()
+				})
+
+				When("conclusion does not match", func() {
+					setWorkflowRunEvent(fakegithub.NewWorkflowRunEvent(
+						fakegithub.SetWorkflowRunEventName("Deploy"),
+						fakegithub.SetWorkflowRunEventConclusion("cancelled"),
+					))
+					testSuccess(name)
+					testSkipped()
+				})
+			})
+		})
+
+		When("event type = check_suite", func() {
+			var server *fakegithub.Server
+
+			setCheckSuiteEvent := func(event *github.CheckSuiteEvent) {
+				BeforeEach(func() {
+					req = newRequest("check_suite", event)
+				})
+			}
+
+			BeforeEach(func() {
+				server = fakegithub.NewServer()
+				server.AddPullRequest(fakegithub.NewPullRequest())
+
+				var err error
+				handler.App, err = server.NewAppClient()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				server.Close()
+			})
+
+			When("check suite succeeded", func() {
+				setCheckSuiteEvent(fakegithub.NewCheckSuiteEvent())
+				testSuccess("beta/check-suite")
+				testTriggered()
+			})
+
+			When("check suite failed", func() {
+				setCheckSuiteEvent(fakegithub.NewCheckSuiteEvent(fakegithub.SetCheckSuiteEventConclusion("failure")))
+				testSuccess("beta/check-suite")
+				testSkipped()
+			})
+
+			When("app does not match", func() {
+				setCheckSuiteEvent(fakegithub.NewCheckSuiteEvent(fakegithub.SetCheckSuiteEventAppSlug("travis-ci")))
+				testSuccess("beta/check-suite")
+				testSkipped()
+			})
+		})
+
+		When("event type = release", func() {
+			setReleaseEvent := func(event *github.ReleaseEvent) {
+				BeforeEach(func() {
+					req = newRequest("release", event)
+				})
+			}
+
+			When("release is published", func() {
+				setReleaseEvent(fakegithub.NewReleaseEvent())
+				testSuccess("beta/release")
+
+				It("should record Created event", func() {
+					Expect(mgr.WaitForEvent(testenv.EventData{
+						Type:    v1.EventTypeNormal,
+						Reason:  hookutil.ReasonCreated,
+						Message: "Created resource template: foobar",
+					})).To(BeTrue())
+				})
+			})
+
+			When("release is deleted", func() {
+				setReleaseEvent(fakegithub.NewReleaseEvent(fakegithub.SetReleaseEventAction("deleted")))
+				testSuccess("beta/release-resource-exists")
+
+				It("should record Deleted event", func() {
+					Expect(mgr.WaitForEvent(testenv.EventData{
+						Type:    v1.EventTypeNormal,
+						Reason:  hookutil.ReasonDeleted,
+						Message: "Deleted resource template: foobar",
+					})).To(BeTrue())
+				})
+			})
+
+			When("action is not in default types", func() {
+				setReleaseEvent(fakegithub.NewReleaseEvent(fakegithub.SetReleaseEventAction("edited")))
+				testSuccess("beta/release")
+				testSkipped()
+			})
+
+			When("release event filter is not set", func() {
+				setReleaseEvent(fakegithub.NewReleaseEvent())
+				testSuccess("beta/resource-not-exist")
+				testSkipped()
+			})
+
+			When("types, tags and prerelease are set", func() {
+				name := "beta/release-options"
+
+				When("match", func() {
+					setReleaseEvent(fakegithub.NewReleaseEvent(
+						fakegithub.SetReleaseEventAction("prereleased"),
+						fakegithub.SetReleaseEventTag("v1.0.0-rc.1"),
+						fakegithub.SetReleaseEventPrerelease(true),
+					))
+					testSuccess(name)
+					testTriggered()
+				})
+
+				When("type does not match", func() {
+					setReleaseEvent(fakegithub.NewReleaseEvent(
+						fakegithub.SetReleaseEventTag("v1.0.0-rc.1"),
+						fakegithub.SetReleaseEventPrerelease(true),
+					))
+					testSuccess(name)
+					testSkipped()
+				})
+
+				When("tag does not match", func() {
+					setReleaseEvent(fakegithub.NewReleaseEvent(
+						fakegithub.SetReleaseEventAction("prereleased"),
+						fakegithub.SetReleaseEventPrerelease(true),
+					))
+					testSuccess(name)
+					testSkipped()
+				})
+
+				When("prerelease does not match", func() {
+					setReleaseEvent(fakegithub.NewReleaseEvent(
+						fakegithub.SetReleaseEventAction("prereleased"),
+						fakegithub.SetReleaseEventTag("v1.0.0-rc.1"),
+					))
+					testSuccess(name)
+					testSkipped()
+				})
+			})
+		})
+
+		When("repository name is a pattern", func() {
+			setPushEvent(fakegithub.NewPushEvent())
+
+			When("glob matches", func() {
+				testSuccess("beta/repository-glob")
+				testTriggered()
+			})
+
+			When("glob matches any owner", func() {
+				testSuccess("beta/repository-glob-any-owner")
+				testTriggered()
+			})
+
+			When("glob does not match", func() {
+				testSuccess("beta/repository-glob-other-owner")
+				testSkipped()
+			})
+
+			When("regex matches", func() {
+				testSuccess("beta/repository-regex")
+				testTriggered()
+			})
+
+			When("regex does not match", func() {
+				setPushEvent(fakegithub.NewPushEvent(fakegithub.SetPushEventRepository("foo/qux")))
+				testSuccess("beta/repository-regex")
+				testSkipped()
+			})
+
+			When("exact name is also listed", func() {
+				testSuccess("beta/repository-pattern-precedence")
+				testSkipped()
+			})
+		})
+
+		When("installations is set", func() {
+			When("GitHub App is not configured", func() {
+				When("installation ID matches", func() {
+					setPushEvent(fakegithub.NewPushEvent(fakegithub.SetPushEventInstallation(99)))
+					testSuccess("beta/installation")
+					testSkipped()
+				})
+
+				When("organization matches", func() {
+					setPushEvent(fakegithub.NewPushEvent(fakegithub.SetPushEventInstallation(99)))
+					testSuccess("beta/organization")
+					testSkipped()
+				})
+			})
+
+			When("GitHub App webhook secret is set", func() {
+				setSignedPushEvent := func(event *github.PushEvent, secret string) {
+					BeforeEach(func() {
+						req = newRequest("push", event)
+						req.Header.Set("X-Hub-Signature-256", sign(event, secret))
+					})
+				}
+
+				BeforeEach(func() {
+					handler.App = newAppClient("app-secret")
+				})
+
+				When("installation ID matches", func() {
+					setSignedPushEvent(fakegithub.NewPushEvent(fakegithub.SetPushEventInstallation(99)), "app-secret")
+					testSuccess("beta/installation")
+					testTriggered()
+				})
+
+				When("installation ID does not match", func() {
+					setSignedPushEvent(fakegithub.NewPushEvent(fakegithub.SetPushEventInstallation(100)), "app-secret")
+					testSuccess("beta/installation")
+					testSkipped()
+				})
+
+				When("organization matches", func() {
+					setSignedPushEvent(fakegithub.NewPushEvent(fakegithub.SetPushEventInstallation(99)), "app-secret")
+					testSuccess("beta/organization")
+					testTriggered()
+				})
+
+				When("installation is not set", func() {
+					setSignedPushEvent(fakegithub.NewPushEvent(), "app-secret")
+					testSuccess("beta/organization")
+					testSkipped()
+				})
+
+				When("signature does not match", func() {
+					setSignedPushEvent(fakegithub.NewPushEvent(fakegithub.SetPushEventInstallation(99)), "wrong")
+
+					It("should respond 403", func() {
+						Expect(recorder).To(HaveHTTPStatus(http.StatusForbidden))
+					})
+
+					testSkipped()
+				})
+
+				When("signature is missing", func() {
+					setPushEvent(fakegithub.NewPushEvent())
+
+					It("should respond 403", func() {
+						Expect(recorder).To(HaveHTTPStatus(http.StatusForbidden))
+					})
+
+					testSkipped()
+				})
+			})
+		})
+
+		When("secret token is set", func() {
+			var data []client.Object
+
+			BeforeEach(func() {
+				data = loadTestData("beta/secret-token")
+			})
+
+			AfterEach(func() {
+				Expect(testenv.DeleteObjects(data)).To(Succeed())
+			})
+
+			setSignedPushEvent := func(header, secret string) {
+				BeforeEach(func() {
+					event := fakegithub.NewPushEvent()
+					req = newRequest("push", event)
+					req.Header.Set(header, sign(event, secret))
+				})
+			}
+
+			When("signed with the webhook secret", func() {
+				setSignedPushEvent("X-Hub-Signature-256", "secret")
+
+				It("should respond 200", func() {
+					Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+				})
+
+				testTriggered()
+			})
+
+			When("signed with the repository secret", func() {
+				setSignedPushEvent("X-Hub-Signature-256", "new-secret")
+
+				It("should respond 200", func() {
+					Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+				})
+
+				testTriggered()
+			})
+
+			When("signed with SHA-1", func() {
+				BeforeEach(func() {
+					event := fakegithub.NewPushEvent()
+					req = newRequest("push", event)
+					mac := hmac.New(sha1.New, []byte("secret"))
+					mac.Write(encodeBody(event))
+					req.Header.Set("X-Hub-Signature", "sha1="+hex.EncodeToString(mac.Sum(nil)))
+				})
+
+				It("should respond 200", func() {
+					Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+				})
+
+				testTriggered()
+			})
+
+			When("signature does not match", func() {
+				setSignedPushEvent("X-Hub-Signature-256", "wrong")
+
+				It("should respond 403", func() {
+					Expect(recorder).To(HaveHTTPStatus(http.StatusForbidden))
+				})
+
+				testSkipped()
+			})
+
+			When("signature is missing", func() {
+				setPushEvent(fakegithub.NewPushEvent())
+
+				It("should respond 403", func() {
+					Expect(recorder).To(HaveHTTPStatus(http.StatusForbidden))
+				})
+
+				testSkipped()
+			})
 		})
 	})
 })
@@ -0,0 +1,91 @@
+package github
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-github/v32/github"
+	"github.com/tommy351/pullup/internal/httputil"
+	"github.com/tommy351/pullup/internal/log"
+)
+
+func (h *Handler) handleInstallationEvent(ctx context.Context, d *delivery, event *github.InstallationEvent) error {
+	id := event.GetInstallation().GetID()
+	logger := logr.FromContextOrDiscard(ctx).WithValues(
+		"installation", id,
+		"action", event.GetAction(),
+	)
+
+	if h.App == nil {
+		logger.V(log.Debug).Info("GitHub App is not configured")
+
+		return nil
+	}
+
+	// Installations are only changed by signed deliveries, otherwise anyone
+	// could change repositories that installations can access.
+	if !h.validateInstallationSignature(d) {
+		return httputil.Response{
+			StatusCode: http.StatusForbidden,
+			Errors: []httputil.Error{
+				{Description: "Signature mismatch"},
+			},
+		}
+	}
+
+	switch event.GetAction() {
+	case "created", "unsuspend", "new_permissions_accepted":
+		h.App.SetRepositories(id, getRepositoryNames(event.Repositories)...)
+	case "deleted", "suspend":
+		h.App.RemoveInstallation(id)
+	}
+
+	logger.Info("Installation updated")
+
+	return nil
+}
+
+func (h *Handler) handleInstallationRepositoriesEvent(ctx context.Context, d *delivery, event *github.InstallationRepositoriesEvent) error {
+	id := event.GetInstallation().GetID()
+	logger := logr.FromContextOrDiscard(ctx).WithValues(
+		"installation", id,
+		"action", event.GetAction(),
+	)
+
+	if h.App == nil {
+		logger.V(log.Debug).Info("GitHub App is not configured")
+
+		return nil
+	}
+
+	// Installations are only changed by signed deliveries, otherwise anyone
+	// could change repositories that installations can access.
+	if !h.validateInstallationSignature(d) {
+		return httputil.Response{
+			StatusCode: http.StatusForbidden,
+			Errors: []httputil.Error{
+				{Description: "Signature mismatch"},
+			},
+		}
+	}
+
+	h.App.SetRepositories(id, getRepositoryNames(event.RepositoriesAdded)...)
+	h.App.RemoveRepositories(getRepositoryNames(event.RepositoriesRemoved)...)
+
+	logger.Info("Installation repositories updated")
+
+	return nil
+}
+
+func getRepositoryNames(repos []*github.Repository) []string {
+	result := make([]string, 0, len(repos))
+
+	for _, repo := range repos {
+		if repo != nil && repo.FullName != nil {
+			result = append(result, *repo.FullName)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,184 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-github/v32/github"
+	"github.com/tommy351/pullup/internal/log"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+)
+
+const defaultCommandPrefix = "/pullup"
+
+// nolint: gochecknoglobals
+var (
+	commentCommands = map[string]string{
+		"deploy":   v1beta1.ActionCreate,
+		"redeploy": v1beta1.ActionApply,
+		"destroy":  v1beta1.ActionDelete,
+	}
+
+	defaultAuthorAssociations = []v1beta1.GitHubAuthorAssociation{
+		"OWNER", "MEMBER", "COLLABORATOR",
+	}
+
+	errAppNotConfigured = errors.New("GitHub App is not configured")
+)
+
+// issueCommentEvent is a pull request event with the comment which contains
+// the command. Pull request data is loaded from the API, so Trigger templates
+// for pull request events work as well.
+type issueCommentEvent struct {
+	*github.PullRequestEvent
+
+	Comment *github.IssueComment `json:"comment,omitempty"`
+	Command string               `json:"command,omitempty"`
+}
+
+func (h *Handler) handleIssueCommentEvent(ctx context.Context, d *delivery, event *github.IssueCommentEvent) error {
+	if event.GetAction() != "created" || !event.GetIssue().IsPullRequest() {
+		return nil
+	}
+
+	repoName := event.Repo.GetFullName()
+	list, err := h.listWebhooks(ctx, d, newRepositoryRef(repoName, d))
+	if err != nil {
+		return err
+	}
+
+	logger := logr.FromContextOrDiscard(ctx).WithValues(
+		"repository", repoName,
+		"issue", event.Issue.GetNumber(),
+	)
+	ctx = logr.NewContext(ctx, logger)
+
+	var prEvent *issueCommentEvent
+
+	for _, hook := range list.V1Beta1.Items {
+		hook := hook
+		repo := extractRepositoryBeta(&hook, newRepositoryRef(repoName, d))
+		logger := logger.WithValues("webhook", &hook)
+		ctx := logr.NewContext(ctx, logger)
+
+		if repo == nil || repo.IssueComment == nil {
+			logger.V(log.Debug).Info("Issue comment event filter is not set")
+
+			continue
+		}
+
+		command := parseCommentCommand(repo.IssueComment.Prefix, event.Comment.GetBody())
+		action, ok := commentCommands[command]
+
+		if !ok {
+			logger.V(log.Debug).Info("Skipped for the comment")
+
+			continue
+		}
+
+		if association := event.Comment.GetAuthorAssociation(); !filterByAuthorAssociation(repo.IssueComment.AuthorAssociations, association) {
+			logger.V(log.Debug).Info("Skipped for the author association", "authorAssociation", association)
+
+			continue
+		}
+
+		if prEvent == nil {
+			if prEvent, err = h.loadIssueCommentPullRequest(ctx, event); err != nil {
+				return err
+			}
+		}
+
+		// Pull requests are deleted even if they are not trusted.
+		if action != v1beta1.ActionDelete {
+			if reason := checkPullRequestPolicy(getPullRequestPolicy(repo), prEvent.PullRequest); reason != "" {
+				logger.Info("Skipped for the policy", "reason", reason)
+				h.recordUntrusted(&hook, prEvent.PullRequestEvent, reason)
+
+				continue
+			}
+		}
+
+		prEvent.Command = command
+
+		options := &hookutil.TriggerOptions{
+			Action:        hook.Spec.Action,
+			DefaultAction: action,
+			Event:         prEvent,
+			Source:        &hook,
+			Triggers:      hook.Spec.Triggers,
+		}
+
+		if action != v1beta1.ActionDelete {
+			options.Annotations = getPullRequestAnnotations(prEvent.PullRequestEvent, &hook)
+		}
+
+		if err := h.TriggerHandler.Handle(ctx, options); err != nil {
+			return fmt.Errorf("failed to handle issue comment event: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// loadIssueCommentPullRequest loads the pull request of the comment. The API is
+// called anonymously when the GitHub App is not configured.
+func (h *Handler) loadIssueCommentPullRequest(ctx context.Context, event *github.IssueCommentEvent) (*issueCommentEvent, error) {
+	owner := event.Repo.GetOwner().GetLogin()
+	repo := event.Repo.GetName()
+
+	gh, err := h.apiClient(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, _, err := gh.PullRequests.Get(ctx, owner, repo, event.Issue.GetNumber())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request: %w", err)
+	}
+
+	return &issueCommentEvent{
+		PullRequestEvent: &github.PullRequestEvent{
+			Action:       event.Action,
+			Number:       pr.Number,
+			PullRequest:  pr,
+			Repo:         event.Repo,
+			Sender:       event.Sender,
+			Installation: event.Installation,
+		},
+		Comment: event.Comment,
+	}, nil
+}
+
+// parseCommentCommand returns the command in the first line of a comment.
+func parseCommentCommand(prefix, body string) string {
+	if prefix == "" {
+		prefix = defaultCommandPrefix
+	}
+
+	line := strings.SplitN(strings.TrimSpace(body), "\n", 2)[0]
+	fields := strings.Fields(line)
+
+	if len(fields) < 2 || fields[0] != prefix {
+		return ""
+	}
+
+	return fields[1]
+}
+
+func filterByAuthorAssociation(associations []v1beta1.GitHubAuthorAssociation, association string) bool {
+	if len(associations) == 0 {
+		associations = defaultAuthorAssociations
+	}
+
+	for _, a := range associations {
+		if strings.EqualFold(string(a), association) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -3,9 +3,13 @@ package github
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"strconv"
 
+	"github.com/tommy351/pullup/internal/httputil"
 	"github.com/tommy351/pullup/pkg/apis/pullup/v1alpha1"
 	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -14,30 +18,82 @@ type webhookList struct {
 	V1Beta1  v1beta1.GitHubWebhookList
 }
 
-func (h *Handler) listWebhooks(ctx context.Context, name string) (*webhookList, error) {
+func (h *Handler) listWebhooks(ctx context.Context, d *delivery, ref *repositoryRef) (*webhookList, error) {
 	list := webhookList{}
 	options := []client.ListOption{
 		client.MatchingFields(map[string]string{
-			nameField: name,
+			nameField: ref.Name,
 		}),
 	}
-	targets := []client.ObjectList{
-		&list.V1Alpha1,
-		&list.V1Beta1,
+
+	if err := h.Client.List(ctx, &list.V1Alpha1, options...); err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	// Webhooks are looked up by indexes. Repository patterns are indexed by
+	// owners, so only patterns which may match the repository are listed.
+	betaFields := []client.MatchingFields{
+		{nameField: ref.Name},
+		{patternField: ref.Owner},
+		{patternField: anyOwnerKey},
+	}
+
+	if ref.InstallationID != 0 {
+		betaFields = append(betaFields,
+			client.MatchingFields{installationField: strconv.FormatInt(ref.InstallationID, 10)},
+			client.MatchingFields{organizationField: ref.Owner},
+		)
 	}
 
-	for _, target := range targets {
-		if err := h.Client.List(ctx, target, options...); err != nil {
+	seen := map[types.UID]bool{}
+
+	for _, fields := range betaFields {
+		var betaList v1beta1.GitHubWebhookList
+
+		if err := h.Client.List(ctx, &betaList, fields); err != nil {
 			return nil, fmt.Errorf("failed to list webhooks: %w", err)
 		}
+
+		for _, item := range betaList.Items {
+			item := item
+
+			if !seen[item.UID] && extractRepositoryBeta(&item, ref) != nil {
+				seen[item.UID] = true
+				list.V1Beta1.Items = append(list.V1Beta1.Items, item)
+			}
+		}
 	}
 
+	total := len(list.V1Alpha1.Items) + len(list.V1Beta1.Items)
+	alphaItems := list.V1Alpha1.Items[:0]
+	betaItems := list.V1Beta1.Items[:0]
+
 	for _, item := range list.V1Alpha1.Items {
 		item.SetGroupVersionKind(v1alpha1.GroupVersion.WithKind("Webhook"))
+
+		if h.validateAlphaSignature(ctx, d, &item) {
+			alphaItems = append(alphaItems, item)
+		}
 	}
 
 	for _, item := range list.V1Beta1.Items {
 		item.SetGroupVersionKind(v1beta1.GroupVersion.WithKind("GitHubWebhook"))
+
+		if h.validateBetaSignature(ctx, d, &item, ref) {
+			betaItems = append(betaItems, item)
+		}
+	}
+
+	list.V1Alpha1.Items = alphaItems
+	list.V1Beta1.Items = betaItems
+
+	if total > 0 && len(alphaItems)+len(betaItems) == 0 {
+		return nil, httputil.Response{
+			StatusCode: http.StatusForbidden,
+			Errors: []httputil.Error{
+				{Description: "Signature mismatch"},
+			},
+		}
 	}
 
 	return &list, nil
@@ -0,0 +1,104 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/v32/github"
+	"github.com/tommy351/pullup/internal/githubapp"
+)
+
+const pullRequestFilesPageSize = 100
+
+// getPushEventPaths returns changed files in commits of a push event. It
+// returns false when commits are truncated and the file list is incomplete.
+func getPushEventPaths(event *github.PushEvent) ([]string, bool) {
+	if event.GetSize() > len(event.Commits) {
+		return nil, false
+	}
+
+	var result []string
+
+	seen := map[string]bool{}
+
+	for _, commit := range event.Commits {
+		for _, files := range [][]string{commit.Added, commit.Removed, commit.Modified} {
+			for _, file := range files {
+				if !seen[file] {
+					seen[file] = true
+					result = append(result, file)
+				}
+			}
+		}
+	}
+
+	return result, true
+}
+
+// pullRequestFiles loads changed files of a pull request lazily, so the API is
+// called at most once for each event.
+type pullRequestFiles struct {
+	handler *Handler
+	event   *github.PullRequestEvent
+	files   []string
+	loaded  bool
+}
+
+func (p *pullRequestFiles) Get(ctx context.Context) ([]string, error) {
+	if p.loaded {
+		return p.files, nil
+	}
+
+	owner := p.event.Repo.GetOwner().GetLogin()
+	repo := p.event.Repo.GetName()
+
+	gh, err := p.handler.apiClient(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &github.ListOptions{PerPage: pullRequestFilesPageSize}
+
+	for {
+		files, res, err := gh.PullRequests.ListFiles(ctx, owner, repo, p.event.GetNumber(), opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pull request files: %w", err)
+		}
+
+		for _, file := range files {
+			p.files = append(p.files, file.GetFilename())
+
+			// Renamed files are changed in both paths.
+			if prev := file.GetPreviousFilename(); prev != "" {
+				p.files = append(p.files, prev)
+			}
+		}
+
+		if res.NextPage == 0 {
+			break
+		}
+
+		opts.Page = res.NextPage
+	}
+
+	p.loaded = true
+
+	return p.files, nil
+}
+
+// apiClient returns a GitHub client for the repository. The GitHub App is used
+// when it is configured, otherwise the API is called anonymously, which only
+// works for public repositories.
+func (h *Handler) apiClient(ctx context.Context, owner, repo string) (*github.Client, error) {
+	if h.App != nil {
+		return h.App.RepositoryClient(ctx, owner, repo)
+	}
+
+	gh, err := githubapp.NewGitHubClient(http.DefaultClient, h.Config.App.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	return gh, nil
+}
@@ -0,0 +1,132 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v32/github"
+	"github.com/tommy351/pullup/internal/controller"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const ReasonUntrusted = "Untrusted"
+
+// isApprovalEvent returns true when the approval label of the policy is added
+// to the pull request.
+func isApprovalEvent(policy *v1beta1.GitHubPullRequestPolicy, event *github.PullRequestEvent) bool {
+	return policy != nil &&
+		policy.ApprovalLabel != "" &&
+		event.GetAction() == "labeled" &&
+		event.GetLabel().GetName() == policy.ApprovalLabel
+}
+
+func getPullRequestPolicy(repo *v1beta1.GitHubRepository) *v1beta1.GitHubPullRequestPolicy {
+	if repo.PullRequest == nil {
+		return nil
+	}
+
+	return repo.PullRequest.Policy
+}
+
+// isApproved returns true when the pull request has the approval label of the
+// policy.
+func isApproved(policy *v1beta1.GitHubPullRequestPolicy, pr *github.PullRequest) bool {
+	if policy == nil || policy.ApprovalLabel == "" {
+		return false
+	}
+
+	for _, l := range getPullRequestLabels(pr) {
+		if l == policy.ApprovalLabel {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkPullRequestPolicy returns the reason when the pull request is not
+// trusted by the policy, or an empty string when it is trusted.
+func checkPullRequestPolicy(policy *v1beta1.GitHubPullRequestPolicy, pr *github.PullRequest) string {
+	if isApproved(policy, pr) {
+		return ""
+	}
+
+	return checkPullRequestAuthor(policy, pr)
+}
+
+// checkPullRequestAuthor is the same as checkPullRequestPolicy except that the
+// approval label is ignored.
+func checkPullRequestAuthor(policy *v1beta1.GitHubPullRequestPolicy, pr *github.PullRequest) string {
+	if policy == nil {
+		return ""
+	}
+
+	if !policy.AllowForks && isForkPullRequest(pr) {
+		return "pull requests from forks are not allowed"
+	}
+
+	if len(policy.AuthorAssociations) > 0 || len(policy.Logins) > 0 {
+		login := pr.GetUser().GetLogin()
+		association := pr.GetAuthorAssociation()
+
+		if !isAllowedAuthor(policy, login, association) {
+			return fmt.Sprintf("author %q (%s) is not allowed", login, association)
+		}
+	}
+
+	return ""
+}
+
+func isForkPullRequest(pr *github.PullRequest) bool {
+	return pr.GetHead().GetRepo().GetFullName() != pr.GetBase().GetRepo().GetFullName()
+}
+
+func isAllowedAuthor(policy *v1beta1.GitHubPullRequestPolicy, login, association string) bool {
+	for _, l := range policy.Logins {
+		if strings.EqualFold(l, login) {
+			return true
+		}
+	}
+
+	for _, a := range policy.AuthorAssociations {
+		if strings.EqualFold(string(a), association) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resetApproval removes the approval label from a pull request, so new commits
+// of an untrusted pull request must be approved again.
+func (h *Handler) resetApproval(ctx context.Context, event *github.PullRequestEvent, label string) error {
+	if h.App == nil {
+		return fmt.Errorf("failed to reset the approval: %w", errAppNotConfigured)
+	}
+
+	owner := event.Repo.GetOwner().GetLogin()
+	repo := event.Repo.GetName()
+
+	gh, err := h.App.RepositoryClient(ctx, owner, repo)
+	if err != nil {
+		return err
+	}
+
+	res, err := gh.Issues.RemoveLabelForIssue(ctx, owner, repo, event.GetNumber(), label)
+	if err != nil && (res == nil || res.StatusCode != http.StatusNotFound) {
+		return fmt.Errorf("failed to remove the approval label: %w", err)
+	}
+
+	return nil
+}
+
+func (h *Handler) recordUntrusted(hook *v1beta1.GitHubWebhook, event *github.PullRequestEvent, reason string) {
+	controller.Result{
+		EventType: corev1.EventTypeWarning,
+		Reason:    ReasonUntrusted,
+		Message:   fmt.Sprintf("Skipped pull request %s#%d: %s", event.Repo.GetFullName(), event.GetNumber(), reason),
+	}.RecordEvent(h.Recorder, hook)
+}
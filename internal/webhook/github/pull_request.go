@@ -8,9 +8,9 @@ import (
 	"github.com/google/go-github/v32/github"
 )
 
-func (h *Handler) handlePullRequestEvent(ctx context.Context, event *github.PullRequestEvent) error {
+func (h *Handler) handlePullRequestEvent(ctx context.Context, d *delivery, event *github.PullRequestEvent) error {
 	repoName := event.Repo.GetFullName()
-	list, err := h.listWebhooks(ctx, repoName)
+	list, err := h.listWebhooks(ctx, d, newRepositoryRef(repoName, d))
 	if err != nil {
 		return err
 	}
@@ -20,11 +20,12 @@ func (h *Handler) handlePullRequestEvent(ctx context.Context, event *github.Pull
 		"action", event.GetAction(),
 	)
 	ctx = logr.NewContext(ctx, logger)
+	files := &pullRequestFiles{handler: h, event: event}
 
 	for _, hook := range list.V1Beta1.Items {
 		hook := hook
 
-		if err := h.handlePullRequestEventBeta(ctx, event, &hook); err != nil {
+		if err := h.handlePullRequestEventBeta(ctx, d, event, &hook, files); err != nil {
 			return fmt.Errorf("failed to handle pull request event: %w", err)
 		}
 	}
@@ -2,6 +2,7 @@ package github
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/go-logr/logr"
 	"github.com/google/go-github/v32/github"
@@ -10,10 +11,10 @@ import (
 	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
 )
 
-func (h *Handler) handlePullRequestEventBeta(ctx context.Context, event *github.PullRequestEvent, hook *v1beta1.GitHubWebhook) error {
+func (h *Handler) handlePullRequestEventBeta(ctx context.Context, d *delivery, event *github.PullRequestEvent, hook *v1beta1.GitHubWebhook, files *pullRequestFiles) error {
 	repoName := event.Repo.GetFullName()
 	eventAction := event.GetAction()
-	repo := extractRepositoryBeta(hook, repoName)
+	repo := extractRepositoryBeta(hook, newRepositoryRef(repoName, d))
 	logger := logr.FromContextOrDiscard(ctx).WithValues(
 		"webhook", hook,
 	)
@@ -31,7 +32,28 @@ func (h *Handler) handlePullRequestEventBeta(ctx context.Context, event *github.
 		return nil
 	}
 
-	if !filterByPullRequestType(repo.PullRequest.Types, eventAction) {
+	policy := repo.PullRequest.Policy
+	approved := isApprovalEvent(policy, event)
+
+	// Approvals are reset when new commits are pushed to untrusted pull
+	// requests, otherwise new commits would be deployed without review.
+	if eventAction == "synchronize" && isApproved(policy, event.PullRequest) {
+		if reason := checkPullRequestAuthor(policy, event.PullRequest); reason != "" {
+			if err := h.resetApproval(ctx, event, policy.ApprovalLabel); err != nil {
+				logger.Error(err, "Failed to reset the approval")
+			}
+
+			reason = "new commits must be approved again: " + reason
+			logger.Info("Skipped for the policy", "reason", reason)
+			h.recordUntrusted(hook, event, reason)
+
+			return nil
+		}
+	}
+
+	// Pull requests are handled when the approval label is added, even if
+	// labeled events are not in the types.
+	if !approved && !filterByPullRequestType(repo.PullRequest.Types, eventAction) {
 		logger.V(log.Debug).Info("Skipped for the action")
 
 		return nil
@@ -46,6 +68,10 @@ func (h *Handler) handlePullRequestEventBeta(ctx context.Context, event *github.
 	if filter := repo.PullRequest.Labels; filter != nil {
 		labels := getPullRequestEventLabels(event)
 
+		if approved {
+			labels = getPullRequestLabels(event.PullRequest)
+		}
+
 		if !hookutil.FilterWebhook(filter, labels) {
 			logger.V(log.Debug).Info("Skipped on this label", "labels", labels)
 
@@ -53,6 +79,29 @@ func (h *Handler) handlePullRequestEventBeta(ctx context.Context, event *github.
 		}
 	}
 
+	// Closed pull requests are always deleted.
+	if eventAction != "closed" {
+		if reason := checkPullRequestPolicy(policy, event.PullRequest); reason != "" {
+			logger.Info("Skipped for the policy", "reason", reason)
+			h.recordUntrusted(hook, event, reason)
+
+			return nil
+		}
+	}
+
+	if filter := repo.PullRequest.Paths; filter != nil {
+		paths, err := files.Get(ctx)
+		if err != nil {
+			return err
+		}
+
+		if !hookutil.FilterPaths(filter, paths) {
+			logger.V(log.Debug).Info("Skipped for changed files", "paths", paths)
+
+			return nil
+		}
+	}
+
 	options := &hookutil.TriggerOptions{
 		Action:        hook.Spec.Action,
 		DefaultAction: v1beta1.ActionApply,
@@ -63,7 +112,29 @@ func (h *Handler) handlePullRequestEventBeta(ctx context.Context, event *github.
 
 	if eventAction == "closed" {
 		options.DefaultAction = v1beta1.ActionDelete
+	} else {
+		options.Annotations = getPullRequestAnnotations(event, hook)
 	}
 
 	return h.TriggerHandler.Handle(ctx, options)
 }
+
+func getPullRequestAnnotations(event *github.PullRequestEvent, hook *v1beta1.GitHubWebhook) map[string]string {
+	if !hook.Spec.Deployments && !hook.Spec.Comment {
+		return nil
+	}
+
+	annotations := map[string]string{
+		v1beta1.AnnotationGitHubRepository: event.Repo.GetFullName(),
+	}
+
+	if hook.Spec.Deployments {
+		annotations[v1beta1.AnnotationGitHubSHA] = event.PullRequest.Head.GetSHA()
+	}
+
+	if hook.Spec.Comment {
+		annotations[v1beta1.AnnotationGitHubPullRequest] = strconv.Itoa(event.GetNumber())
+	}
+
+	return annotations
+}
@@ -6,9 +6,8 @@ import (
 	"github.com/google/go-github/v32/github"
 )
 
-func (h *Handler) handlePushEvent(ctx context.Context, event *github.PushEvent) error {
-	repoName := event.Repo.GetFullName()
-	list, err := h.listWebhooks(ctx, repoName)
+func (h *Handler) handlePushEvent(ctx context.Context, d *delivery, event *github.PushEvent) error {
+	list, err := h.listWebhooks(ctx, d, newRepositoryRef(event.Repo.GetFullName(), d))
 	if err != nil {
 		return err
 	}
@@ -16,7 +15,7 @@ func (h *Handler) handlePushEvent(ctx context.Context, event *github.PushEvent)
 	for _, hook := range list.V1Beta1.Items {
 		hook := hook
 
-		if err := h.handlePushEventBeta(ctx, event, &hook); err != nil {
+		if err := h.handlePushEventBeta(ctx, d, event, &hook); err != nil {
 			return err
 		}
 	}
@@ -11,9 +11,9 @@ import (
 	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
 )
 
-func (h *Handler) handlePushEventBeta(ctx context.Context, event *github.PushEvent, hook *v1beta1.GitHubWebhook) error {
+func (h *Handler) handlePushEventBeta(ctx context.Context, d *delivery, event *github.PushEvent, hook *v1beta1.GitHubWebhook) error {
 	repoName := event.Repo.GetFullName()
-	repo := extractRepositoryBeta(hook, repoName)
+	repo := extractRepositoryBeta(hook, newRepositoryRef(repoName, d))
 	logger := logr.FromContextOrDiscard(ctx).WithValues(
 		"repository", repoName,
 		"webhook", hook,
@@ -59,6 +59,15 @@ func (h *Handler) handlePushEventBeta(ctx context.Context, event *github.PushEve
 		return nil
 	}
 
+	// Deleted refs do not have any commits, so they are not filtered by paths.
+	if filter := repo.Push.Paths; filter != nil && !event.GetDeleted() {
+		if paths, ok := getPushEventPaths(event); ok && !hookutil.FilterPaths(filter, paths) {
+			logger.V(log.Debug).Info("Skipped for changed files", "paths", paths)
+
+			return nil
+		}
+	}
+
 	options := &hookutil.TriggerOptions{
 		DefaultAction: v1beta1.ActionApply,
 		Action:        hook.Spec.Action,
@@ -67,5 +76,9 @@ func (h *Handler) handlePushEventBeta(ctx context.Context, event *github.PushEve
 		Triggers:      hook.Spec.Triggers,
 	}
 
+	if event.GetDeleted() {
+		options.DefaultAction = v1beta1.ActionDelete
+	}
+
 	return h.TriggerHandler.Handle(ctx, options)
 }
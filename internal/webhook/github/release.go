@@ -0,0 +1,114 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-github/v32/github"
+	"github.com/tommy351/pullup/internal/log"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+)
+
+func (h *Handler) handleReleaseEvent(ctx context.Context, d *delivery, event *github.ReleaseEvent) error {
+	repoName := event.Repo.GetFullName()
+	list, err := h.listWebhooks(ctx, d, newRepositoryRef(repoName, d))
+	if err != nil {
+		return err
+	}
+
+	logger := logr.FromContextOrDiscard(ctx).WithValues(
+		"repository", repoName,
+		"action", event.GetAction(),
+		"tag", event.Release.GetTagName(),
+	)
+	ctx = logr.NewContext(ctx, logger)
+
+	for _, hook := range list.V1Beta1.Items {
+		hook := hook
+
+		if err := h.handleReleaseEventBeta(ctx, d, event, &hook); err != nil {
+			return fmt.Errorf("failed to handle release event: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (h *Handler) handleReleaseEventBeta(ctx context.Context, d *delivery, event *github.ReleaseEvent, hook *v1beta1.GitHubWebhook) error {
+	eventAction := event.GetAction()
+	repo := extractRepositoryBeta(hook, newRepositoryRef(event.Repo.GetFullName(), d))
+	logger := logr.FromContextOrDiscard(ctx).WithValues(
+		"webhook", hook,
+	)
+	ctx = logr.NewContext(ctx, logger)
+
+	if repo == nil {
+		logger.V(log.Debug).Info("Repository does not exist in the webhook")
+
+		return nil
+	}
+
+	filter := repo.Release
+
+	if filter == nil {
+		logger.V(log.Debug).Info("Release event filter is not set")
+
+		return nil
+	}
+
+	if !filterByReleaseType(filter.Types, eventAction) {
+		logger.V(log.Debug).Info("Skipped for the action")
+
+		return nil
+	}
+
+	release := event.GetRelease()
+
+	if tag := release.GetTagName(); !hookutil.FilterWebhook(filter.Tags, []string{tag}) {
+		logger.V(log.Debug).Info("Skipped on this tag", "tag", tag)
+
+		return nil
+	}
+
+	if draft := filter.Draft; draft != nil && *draft != release.GetDraft() {
+		logger.V(log.Debug).Info("Skipped for the draft flag", "draft", release.GetDraft())
+
+		return nil
+	}
+
+	if prerelease := filter.Prerelease; prerelease != nil && *prerelease != release.GetPrerelease() {
+		logger.V(log.Debug).Info("Skipped for the prerelease flag", "prerelease", release.GetPrerelease())
+
+		return nil
+	}
+
+	options := &hookutil.TriggerOptions{
+		Action:        hook.Spec.Action,
+		DefaultAction: v1beta1.ActionApply,
+		Event:         event,
+		Source:        hook,
+		Triggers:      hook.Spec.Triggers,
+	}
+
+	if eventAction == "deleted" {
+		options.DefaultAction = v1beta1.ActionDelete
+	}
+
+	return h.TriggerHandler.Handle(ctx, options)
+}
+
+func filterByReleaseType(types []v1beta1.GitHubReleaseEventType, action string) bool {
+	if len(types) == 0 {
+		types = defaultReleaseTypes
+	}
+
+	for _, t := range types {
+		if string(t) == action {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,74 @@
+package github
+
+import (
+	"path"
+	"strings"
+
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+)
+
+// anyOwnerKey is the index key of repository patterns which may match
+// repositories of any owner.
+const anyOwnerKey = "*"
+
+// repositoryRef identifies the repository and the GitHub App installation
+// of an event.
+type repositoryRef struct {
+	Name  string
+	Owner string
+
+	// InstallationID is only set when the delivery is verified with the webhook
+	// secret of the App, otherwise anyone could send a delivery to webhooks of
+	// an installation or an organization.
+	InstallationID int64
+}
+
+func newRepositoryRef(name string, d *delivery) *repositoryRef {
+	ref := &repositoryRef{
+		Name:  name,
+		Owner: strings.SplitN(name, "/", 2)[0],
+	}
+
+	if d.Verified {
+		ref.InstallationID = d.InstallationID
+	}
+
+	return ref
+}
+
+func isRegexpPattern(name string) bool {
+	return len(name) > 1 && strings.HasPrefix(name, "/") && strings.HasSuffix(name, "/")
+}
+
+// isRepositoryPattern returns true when the name is a glob pattern such as
+// "myorg/*", or a regular expression such as "/^myorg\/api-.+$/".
+func isRepositoryPattern(name string) bool {
+	return isRegexpPattern(name) || strings.ContainsAny(name, "*?[")
+}
+
+func matchRepository(pattern, name string) bool {
+	if isRegexpPattern(pattern) {
+		return hookutil.FilterByConditions([]string{pattern}, name)
+	}
+
+	matched, _ := path.Match(pattern, name)
+
+	return matched
+}
+
+// getRepositoryPatternKey returns the owner of a glob pattern, so webhooks can
+// be looked up by the owner of a repository. Patterns which may match any owner
+// share the same key.
+func getRepositoryPatternKey(pattern string) string {
+	if isRegexpPattern(pattern) {
+		return anyOwnerKey
+	}
+
+	chunks := strings.SplitN(pattern, "/", 2)
+
+	if len(chunks) != 2 || strings.ContainsAny(chunks[0], "*?[\\") {
+		return anyOwnerKey
+	}
+
+	return chunks[0]
+}
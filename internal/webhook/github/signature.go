@@ -0,0 +1,163 @@
+package github
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-github/v32/github"
+	"github.com/tommy351/pullup/internal/log"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1alpha1"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+)
+
+const (
+	signatureHeader       = "X-Hub-Signature"
+	signatureSHA256Header = "X-Hub-Signature-256"
+)
+
+type delivery struct {
+	Signature string
+	Body      []byte
+
+	// InstallationID is the ID of the GitHub App installation which the
+	// delivery is sent to.
+	InstallationID int64
+
+	// Verified is true when the delivery is sent to a GitHub App and has been
+	// verified with the webhook secret of the App.
+	Verified bool
+}
+
+func newDelivery(r *http.Request, body []byte) *delivery {
+	signature := r.Header.Get(signatureSHA256Header)
+	if signature == "" {
+		signature = r.Header.Get(signatureHeader)
+	}
+
+	return &delivery{
+		Signature: signature,
+		Body:      body,
+	}
+}
+
+type installationEvent interface {
+	GetInstallation() *github.Installation
+}
+
+// validateAppSignature validates a delivery sent to a GitHub App with the
+// webhook secret of the App. Deliveries which are verified here skip the
+// validation of each webhook, and are the only deliveries which can match
+// webhooks by installations. Unsigned deliveries are rejected when the webhook
+// secret of the App is set.
+func (h *Handler) validateAppSignature(d *delivery) bool {
+	if h.App == nil || len(h.App.WebhookSecret()) == 0 {
+		return true
+	}
+
+	if d.Signature == "" {
+		return false
+	}
+
+	// Deliveries without installations are not sent to the App. They are
+	// validated by secrets of webhooks instead.
+	if d.InstallationID == 0 {
+		return true
+	}
+
+	if !hookutil.ValidateSignature(d.Signature, d.Body, h.App.WebhookSecret()) {
+		return false
+	}
+
+	d.Verified = true
+
+	return true
+}
+
+// validateInstallationSignature validates a delivery which changes the state
+// of installations. The delivery must be verified with the webhook secret of
+// the App, or the global secret when the App does not have a webhook secret.
+func (h *Handler) validateInstallationSignature(d *delivery) bool {
+	if d.Verified {
+		return true
+	}
+
+	if h.App != nil && len(h.App.WebhookSecret()) > 0 {
+		return false
+	}
+
+	return h.Config.Secret != "" && hookutil.ValidateSignature(d.Signature, d.Body, []byte(h.Config.Secret))
+}
+
+// validateAlphaSignature validates a delivery for a v1alpha1 webhook. These
+// webhooks can only be secured by the global secret.
+func (h *Handler) validateAlphaSignature(ctx context.Context, d *delivery, hook *v1alpha1.Webhook) bool {
+	if d.Verified || h.Config.Secret == "" {
+		return true
+	}
+
+	if !hookutil.ValidateSignature(d.Signature, d.Body, []byte(h.Config.Secret)) {
+		logr.FromContextOrDiscard(ctx).V(log.Debug).Info("Signature mismatch", "webhook", hook)
+
+		return false
+	}
+
+	return true
+}
+
+// validateBetaSignature validates a delivery for a v1beta1 webhook. Secrets
+// set on both the webhook and the repository are accepted, which allows a
+// secret to be rotated without downtime. The global secret is used when
+// neither of them is set.
+func (h *Handler) validateBetaSignature(ctx context.Context, d *delivery, hook *v1beta1.GitHubWebhook, ref *repositoryRef) bool {
+	if d.Verified {
+		return true
+	}
+
+	logger := logr.FromContextOrDiscard(ctx).WithValues("webhook", hook)
+	values := []*v1beta1.SecretValue{hook.Spec.SecretToken}
+
+	if repo := extractRepositoryBeta(hook, ref); repo != nil {
+		values = append(values, repo.SecretToken)
+	}
+
+	var (
+		secrets    [][]byte
+		configured bool
+	)
+
+	for _, value := range values {
+		if value == nil {
+			continue
+		}
+
+		configured = true
+		secret, err := hookutil.GetSecretValue(ctx, h.Client, hook.Namespace, value)
+		if err != nil {
+			logger.Error(err, "Failed to get the secret token")
+
+			continue
+		}
+
+		secrets = append(secrets, secret)
+	}
+
+	if !configured {
+		if h.Config.Secret == "" {
+			return true
+		}
+
+		secrets = [][]byte{[]byte(h.Config.Secret)}
+	}
+
+	for _, secret := range secrets {
+		if hookutil.ValidateSignature(d.Signature, d.Body, secret) {
+			return true
+		}
+	}
+
+	logger.V(log.Debug).Info("Signature mismatch")
+
+	return false
+}
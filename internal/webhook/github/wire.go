@@ -9,12 +9,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
-func NewHandlerConfig(conf Config, mgr manager.Manager) HandlerConfig {
+func NewHandlerConfig(conf Config, mgr manager.Manager) (HandlerConfig, error) {
 	wire.Build(
 		controller.NewClient,
 		hookutil.NewEventRecorder,
 		hookutil.TriggerHandlerSet,
 		HandlerConfigSet,
 	)
-	return HandlerConfig{}
+	return HandlerConfig{}, nil
 }
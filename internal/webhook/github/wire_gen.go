@@ -13,7 +13,11 @@ import (
 
 // Injectors from wire.go:
 
-func NewHandlerConfig(conf Config, mgr manager.Manager) HandlerConfig {
+func NewHandlerConfig(conf Config, mgr manager.Manager) (HandlerConfig, error) {
+	githubappClient, err := NewAppClient(conf)
+	if err != nil {
+		return HandlerConfig{}, err
+	}
 	client := controller.NewClient(mgr)
 	eventRecorder := hookutil.NewEventRecorder(mgr)
 	triggerHandler := hookutil.TriggerHandler{
@@ -22,9 +26,10 @@ func NewHandlerConfig(conf Config, mgr manager.Manager) HandlerConfig {
 	}
 	handlerConfig := HandlerConfig{
 		Config:         conf,
+		App:            githubappClient,
 		Client:         client,
 		Recorder:       eventRecorder,
 		TriggerHandler: triggerHandler,
 	}
-	return handlerConfig
+	return handlerConfig, nil
 }
@@ -0,0 +1,185 @@
+package gitlab
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/google/wire"
+	"github.com/tommy351/pullup/internal/gitlab"
+	"github.com/tommy351/pullup/internal/httputil"
+	"github.com/tommy351/pullup/internal/log"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// +kubebuilder:rbac:groups=pullup.dev,resources=gitlabwebhooks,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+const nameField = "spec.projects.name"
+
+// HandlerConfigSet provides a handler config.
+// nolint: gochecknoglobals
+var HandlerConfigSet = wire.NewSet(
+	wire.Struct(new(HandlerConfig), "*"),
+)
+
+// HandlerSet provides a handler.
+// nolint: gochecknoglobals
+var HandlerSet = wire.NewSet(
+	HandlerConfigSet,
+	NewHandler,
+)
+
+type HandlerConfig struct {
+	Client         client.Client
+	TriggerHandler hookutil.TriggerHandler
+}
+
+type Handler struct {
+	HandlerConfig
+}
+
+func NewHandler(conf HandlerConfig, mgr manager.Manager) (*Handler, error) {
+	err := mgr.GetFieldIndexer().IndexField(context.TODO(), &v1beta1.GitLabWebhook{}, nameField, func(obj client.Object) []string {
+		var result []string
+
+		for _, project := range obj.(*v1beta1.GitLabWebhook).Spec.Projects {
+			result = append(result, project.Name)
+		}
+
+		return result
+	})
+	if err != nil {
+		return nil, fmt.Errorf("index failed: %w", err)
+	}
+
+	return &Handler{
+		HandlerConfig: conf,
+	}, nil
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) error {
+	logger := logr.FromContextOrDiscard(r.Context())
+	payload, err := h.parsePayload(r)
+	if err != nil {
+		if errors.Is(err, gitlab.ErrUnknownEventType) {
+			logger.V(log.Debug).Info("Skipped unsupported event", "event", gitlab.WebhookType(r))
+
+			return httputil.JSON(w, http.StatusOK, &httputil.Response{})
+		}
+
+		logger.Error(err, "Invalid payload")
+
+		return httputil.JSON(w, http.StatusBadRequest, &httputil.Response{
+			Errors: []httputil.Error{
+				{Description: "Invalid payload"},
+			},
+		})
+	}
+
+	if err := h.handlePayload(r, payload); err != nil {
+		return err
+	}
+
+	return httputil.JSON(w, http.StatusOK, &httputil.Response{})
+}
+
+func (h *Handler) parsePayload(r *http.Request) (interface{}, error) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body: %w", err)
+	}
+
+	return gitlab.ParseWebhook(gitlab.WebhookType(r), body)
+}
+
+func (h *Handler) handlePayload(r *http.Request, payload interface{}) error {
+	ctx := r.Context()
+	token := r.Header.Get(gitlab.TokenHeader)
+
+	switch event := payload.(type) {
+	case *gitlab.PushEvent:
+		return h.handlePushEvent(ctx, token, event)
+	case *gitlab.MergeRequestEvent:
+		return h.handleMergeRequestEvent(ctx, token, event)
+	}
+
+	return nil
+}
+
+func (h *Handler) listWebhooks(ctx context.Context, token, name string) ([]v1beta1.GitLabWebhook, error) {
+	list := new(v1beta1.GitLabWebhookList)
+	err := h.Client.List(ctx, list, client.MatchingFields(map[string]string{
+		nameField: name,
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	// nolint: prealloc
+	var result []v1beta1.GitLabWebhook
+
+	for _, hook := range list.Items {
+		hook := hook
+		hook.SetGroupVersionKind(v1beta1.GroupVersion.WithKind("GitLabWebhook"))
+
+		if !h.validateToken(ctx, token, &hook) {
+			continue
+		}
+
+		result = append(result, hook)
+	}
+
+	if len(list.Items) > 0 && len(result) == 0 {
+		return nil, httputil.Response{
+			StatusCode: http.StatusForbidden,
+			Errors: []httputil.Error{
+				{Description: "Secret token mismatch"},
+			},
+		}
+	}
+
+	return result, nil
+}
+
+func (h *Handler) validateToken(ctx context.Context, token string, hook *v1beta1.GitLabWebhook) bool {
+	logger := logr.FromContextOrDiscard(ctx).WithValues("webhook", hook)
+
+	if hook.Spec.SecretToken == nil {
+		return true
+	}
+
+	secret, err := hookutil.GetSecretValue(ctx, h.Client, hook.Namespace, hook.Spec.SecretToken)
+	if err != nil {
+		logger.Error(err, "Failed to get the secret token")
+
+		return false
+	}
+
+	if subtle.ConstantTimeCompare([]byte(token), secret) != 1 {
+		logger.V(log.Debug).Info("Secret token mismatch")
+
+		return false
+	}
+
+	return true
+}
+
+func extractProject(hook *v1beta1.GitLabWebhook, name string) *v1beta1.GitLabProject {
+	for _, p := range hook.Spec.Projects {
+		p := p
+
+		if p.Name == name {
+			return &p
+		}
+	}
+
+	return nil
+}
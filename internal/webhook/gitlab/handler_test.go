@@ -0,0 +1,377 @@
+package gitlab
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tommy351/pullup/internal/fakegitlab"
+	"github.com/tommy351/pullup/internal/gitlab"
+	"github.com/tommy351/pullup/internal/k8s"
+	"github.com/tommy351/pullup/internal/random"
+	"github.com/tommy351/pullup/internal/testenv"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Handler", func() {
+	var (
+		handler      *Handler
+		req          *http.Request
+		recorder     *httptest.ResponseRecorder
+		mgr          *testenv.Manager
+		namespaceMap *random.NamespaceMap
+	)
+
+	newRequest := func(event string, body interface{}) *http.Request {
+		var buf bytes.Buffer
+		Expect(json.NewEncoder(&buf).Encode(body)).To(Succeed())
+		req := httptest.NewRequest(http.MethodPost, "/", &buf)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(gitlab.EventTypeHeader, event)
+
+		ctx := logr.NewContext(req.Context(), log.Log)
+
+		return req.WithContext(ctx)
+	}
+
+	loadTestData := func(name string) []client.Object {
+		data, err := k8s.LoadObjects(testenv.GetScheme(), fmt.Sprintf("testdata/%s.yml", name))
+		Expect(err).NotTo(HaveOccurred())
+
+		data, err = k8s.MapObjects(data, namespaceMap.SetObject)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testenv.CreateObjects(data)).To(Succeed())
+
+		return data
+	}
+
+	getChanges := func() []testenv.Change {
+		return testenv.GetChanges(handler.Client)
+	}
+
+	loadData := func(name string) {
+		var data []client.Object
+
+		BeforeEach(func() {
+			data = loadTestData(name)
+		})
+
+		AfterEach(func() {
+			Expect(testenv.DeleteObjects(data)).To(Succeed())
+		})
+	}
+
+	testSuccess := func(name string) {
+		loadData(name)
+
+		It("should respond 200", func() {
+			Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+		})
+	}
+
+	testTriggered := func() {
+		It("should change something", func() {
+			Expect(getChanges()).NotTo(BeEmpty())
+		})
+	}
+
+	testSkipped := func() {
+		It("should not change anything", func() {
+			Expect(getChanges()).To(BeEmpty())
+		})
+	}
+
+	setMergeRequestEvent := func(event *gitlab.MergeRequestEvent) {
+		BeforeEach(func() {
+			req = newRequest(gitlab.MergeRequestHook, event)
+		})
+	}
+
+	setPushEvent := func(event *gitlab.PushEvent) {
+		BeforeEach(func() {
+			req = newRequest(gitlab.PushHook, event)
+		})
+	}
+
+	setTagPushEvent := func(event *gitlab.PushEvent) {
+		BeforeEach(func() {
+			req = newRequest(gitlab.TagPushHook, event)
+		})
+	}
+
+	BeforeEach(func() {
+		var err error
+		mgr, err = testenv.NewManager()
+		Expect(err).NotTo(HaveOccurred())
+
+		handler, err = NewHandler(NewHandlerConfig(mgr), mgr)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(mgr.Initialize()).To(Succeed())
+
+		namespaceMap = random.NewNamespaceMap()
+	})
+
+	JustBeforeEach(func() {
+		recorder = httptest.NewRecorder()
+		hookutil.NewHandler(handler.Handle).ServeHTTP(recorder, req)
+	})
+
+	AfterEach(func() {
+		mgr.Stop()
+	})
+
+	When("payload is invalid", func() {
+		BeforeEach(func() {
+			req = httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte("{")))
+			req.Header.Set(gitlab.EventTypeHeader, gitlab.PushHook)
+		})
+
+		It("should respond 400", func() {
+			Expect(recorder).To(HaveHTTPStatus(http.StatusBadRequest))
+		})
+	})
+
+	When("event type is not supported", func() {
+		BeforeEach(func() {
+			req = newRequest("Note Hook", map[string]interface{}{})
+		})
+
+		It("should respond 200", func() {
+			Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+		})
+	})
+
+	When("event type = push", func() {
+		When("branch is pushed", func() {
+			setPushEvent(fakegitlab.NewPushEvent())
+
+			When("resource template exists", func() {
+				testSuccess("resource-exists")
+
+				It("should record Updated event", func() {
+					Expect(mgr.WaitForEvent(testenv.EventData{
+						Type:    v1.EventTypeNormal,
+						Reason:  hookutil.ReasonUpdated,
+						Message: "Updated resource template: foobar",
+					})).To(BeTrue())
+				})
+			})
+
+			When("resource template does not exist", func() {
+				testSuccess("resource-not-exist")
+
+				It("should record Created event", func() {
+					Expect(mgr.WaitForEvent(testenv.EventData{
+						Type:    v1.EventTypeNormal,
+						Reason:  hookutil.ReasonCreated,
+						Message: "Created resource template: foobar",
+					})).To(BeTrue())
+				})
+			})
+
+			When("push event filter is not set", func() {
+				testSuccess("without-event-filters")
+				testSkipped()
+			})
+
+			When("only tag filter is set", func() {
+				testSuccess("push-tag-include")
+				testSkipped()
+			})
+		})
+
+		When("branches.include is set", func() {
+			name := "push-branch-include"
+
+			When("exact match", func() {
+				setPushEvent(fakegitlab.NewPushEvent(fakegitlab.SetPushEventBranch("foo")))
+				testSuccess(name)
+				testTriggered()
+			})
+
+			When("match by regex", func() {
+				setPushEvent(fakegitlab.NewPushEvent(fakegitlab.SetPushEventBranch("bar-5")))
+				testSuccess(name)
+				testTriggered()
+			})
+
+			When("not match", func() {
+				setPushEvent(fakegitlab.NewPushEvent(fakegitlab.SetPushEventBranch("abc")))
+				testSuccess(name)
+				testSkipped()
+			})
+		})
+
+		When("tag is pushed", func() {
+			When("tag filter is not set", func() {
+				setTagPushEvent(fakegitlab.NewPushEvent(fakegitlab.SetPushEventTag("foo")))
+				testSuccess("resource-not-exist")
+				testSkipped()
+			})
+
+			When("tags.include matches", func() {
+				setTagPushEvent(fakegitlab.NewPushEvent(fakegitlab.SetPushEventTag("bar-5")))
+				testSuccess("push-tag-include")
+				testTriggered()
+			})
+
+			When("tags.include does not match", func() {
+				setTagPushEvent(fakegitlab.NewPushEvent(fakegitlab.SetPushEventTag("abc")))
+				testSuccess("push-tag-include")
+				testSkipped()
+			})
+		})
+
+		When("secret token is set", func() {
+			When("token matches", func() {
+				BeforeEach(func() {
+					req = newRequest(gitlab.PushHook, fakegitlab.NewPushEvent())
+					req.Header.Set(gitlab.TokenHeader, "secret")
+				})
+
+				testSuccess("secret-token")
+				testTriggered()
+			})
+
+			When("token does not match", func() {
+				BeforeEach(func() {
+					req = newRequest(gitlab.PushHook, fakegitlab.NewPushEvent())
+					req.Header.Set(gitlab.TokenHeader, "wrong")
+				})
+
+				loadData("secret-token")
+
+				It("should respond 403", func() {
+					Expect(recorder).To(HaveHTTPStatus(http.StatusForbidden))
+				})
+
+				testSkipped()
+			})
+
+			When("token is missing", func() {
+				setPushEvent(fakegitlab.NewPushEvent())
+				loadData("secret-token")
+
+				It("should respond 403", func() {
+					Expect(recorder).To(HaveHTTPStatus(http.StatusForbidden))
+				})
+
+				testSkipped()
+			})
+		})
+	})
+
+	When("event type = merge_request", func() {
+		When("no matching webhooks", func() {
+			setMergeRequestEvent(fakegitlab.NewMergeRequestEvent())
+
+			It("should respond 200", func() {
+				Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+			})
+
+			testSkipped()
+		})
+
+		When("merge request event filter is not set", func() {
+			setMergeRequestEvent(fakegitlab.NewMergeRequestEvent())
+			testSuccess("without-event-filters")
+			testSkipped()
+		})
+
+		for _, action := range []string{"open", "update", "reopen"} {
+			action := action
+
+			When(fmt.Sprintf("action = %s", action), func() {
+				setMergeRequestEvent(fakegitlab.NewMergeRequestEvent(fakegitlab.SetMergeRequestEventAction(action)))
+				testSuccess("resource-not-exist")
+
+				It("should record Created event", func() {
+					Expect(mgr.WaitForEvent(testenv.EventData{
+						Type:    v1.EventTypeNormal,
+						Reason:  hookutil.ReasonCreated,
+						Message: "Created resource template: foobar",
+					})).To(BeTrue())
+				})
+			})
+		}
+
+		for _, action := range []string{"merge", "close"} {
+			action := action
+
+			When(fmt.Sprintf("action = %s", action), func() {
+				setMergeRequestEvent(fakegitlab.NewMergeRequestEvent(fakegitlab.SetMergeRequestEventAction(action)))
+				testSuccess("resource-exists")
+
+				It("should delete the resource", func() {
+					Expect(getChanges()).To(ContainElement(testenv.Change{
+						Type: "delete",
+						NamespacedName: types.NamespacedName{
+							Name:      "foobar",
+							Namespace: namespaceMap.GetRandom("test"),
+						},
+						GroupVersionKind: v1beta1.GroupVersion.WithKind("ResourceTemplate"),
+					}))
+				})
+			})
+		}
+
+		When("branches.include is set", func() {
+			name := "merge-request-branch-include"
+
+			When("match", func() {
+				setMergeRequestEvent(fakegitlab.NewMergeRequestEvent(fakegitlab.SetMergeRequestEventBranch("bar-5")))
+				testSuccess(name)
+				testTriggered()
+			})
+
+			When("not match", func() {
+				setMergeRequestEvent(fakegitlab.NewMergeRequestEvent(fakegitlab.SetMergeRequestEventBranch("abc")))
+				testSuccess(name)
+				testSkipped()
+			})
+		})
+
+		When("labels.include is set", func() {
+			name := "merge-request-label-include"
+
+			When("match", func() {
+				setMergeRequestEvent(fakegitlab.NewMergeRequestEvent(fakegitlab.SetMergeRequestEventLabels([]string{"foo"})))
+				testSuccess(name)
+				testTriggered()
+			})
+
+			When("not match", func() {
+				setMergeRequestEvent(fakegitlab.NewMergeRequestEvent(fakegitlab.SetMergeRequestEventLabels([]string{"abc"})))
+				testSuccess(name)
+				testSkipped()
+			})
+		})
+
+		When("actions is set", func() {
+			name := "merge-request-action"
+
+			When("action = approved", func() {
+				setMergeRequestEvent(fakegitlab.NewMergeRequestEvent(fakegitlab.SetMergeRequestEventAction("approved")))
+				testSuccess(name)
+				testTriggered()
+			})
+
+			When("action = open", func() {
+				setMergeRequestEvent(fakegitlab.NewMergeRequestEvent(fakegitlab.SetMergeRequestEventAction("open")))
+				testSuccess(name)
+				testSkipped()
+			})
+		})
+	})
+})
@@ -0,0 +1,126 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/tommy351/pullup/internal/gitlab"
+	"github.com/tommy351/pullup/internal/log"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+)
+
+// nolint: gochecknoglobals
+var defaultMergeRequestActions = []v1beta1.GitLabMergeRequestEventAction{
+	"open", "update", "reopen", "close", "merge",
+}
+
+func (h *Handler) handleMergeRequestEvent(ctx context.Context, token string, event *gitlab.MergeRequestEvent) error {
+	if event.Project == nil || event.ObjectAttributes == nil {
+		return nil
+	}
+
+	projectName := event.Project.PathWithNamespace
+	hooks, err := h.listWebhooks(ctx, token, projectName)
+	if err != nil {
+		return err
+	}
+
+	logger := logr.FromContextOrDiscard(ctx).WithValues(
+		"project", projectName,
+		"action", event.ObjectAttributes.Action,
+	)
+	ctx = logr.NewContext(ctx, logger)
+
+	for _, hook := range hooks {
+		hook := hook
+
+		if err := h.handleMergeRequestEventHook(ctx, event, &hook); err != nil {
+			return fmt.Errorf("failed to handle merge request event: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (h *Handler) handleMergeRequestEventHook(ctx context.Context, event *gitlab.MergeRequestEvent, hook *v1beta1.GitLabWebhook) error {
+	eventAction := event.ObjectAttributes.Action
+	project := extractProject(hook, event.Project.PathWithNamespace)
+	logger := logr.FromContextOrDiscard(ctx).WithValues(
+		"webhook", hook,
+	)
+	ctx = logr.NewContext(ctx, logger)
+
+	if project == nil {
+		logger.V(log.Debug).Info("Project does not exist in the webhook")
+
+		return nil
+	}
+
+	if project.MergeRequest == nil {
+		logger.V(log.Debug).Info("Merge request event filter is not set")
+
+		return nil
+	}
+
+	if !filterByMergeRequestAction(project.MergeRequest.Actions, eventAction) {
+		logger.V(log.Debug).Info("Skipped for the action")
+
+		return nil
+	}
+
+	if branch := event.ObjectAttributes.TargetBranch; !hookutil.FilterWebhook(project.MergeRequest.Branches, []string{branch}) {
+		logger.V(log.Debug).Info("Skipped on this branch", "branch", branch)
+
+		return nil
+	}
+
+	if filter := project.MergeRequest.Labels; filter != nil {
+		labels := getMergeRequestLabels(event)
+
+		if !hookutil.FilterWebhook(filter, labels) {
+			logger.V(log.Debug).Info("Skipped on this label", "labels", labels)
+
+			return nil
+		}
+	}
+
+	options := &hookutil.TriggerOptions{
+		Action:        hook.Spec.Action,
+		DefaultAction: v1beta1.ActionApply,
+		Event:         event,
+		Source:        hook,
+		Triggers:      hook.Spec.Triggers,
+	}
+
+	if eventAction == "merge" || eventAction == "close" {
+		options.DefaultAction = v1beta1.ActionDelete
+	}
+
+	return h.TriggerHandler.Handle(ctx, options)
+}
+
+func filterByMergeRequestAction(actions []v1beta1.GitLabMergeRequestEventAction, action string) bool {
+	if len(actions) == 0 {
+		actions = defaultMergeRequestActions
+	}
+
+	for _, a := range actions {
+		if string(a) == action {
+			return true
+		}
+	}
+
+	return false
+}
+
+func getMergeRequestLabels(event *gitlab.MergeRequestEvent) (result []string) {
+	for _, label := range event.Labels {
+		if label != nil {
+			result = append(result, label.Title)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,98 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/tommy351/pullup/internal/gitlab"
+	"github.com/tommy351/pullup/internal/gitutil"
+	"github.com/tommy351/pullup/internal/log"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+)
+
+func (h *Handler) handlePushEvent(ctx context.Context, token string, event *gitlab.PushEvent) error {
+	if event.Project == nil {
+		return nil
+	}
+
+	projectName := event.Project.PathWithNamespace
+	hooks, err := h.listWebhooks(ctx, token, projectName)
+	if err != nil {
+		return err
+	}
+
+	logger := logr.FromContextOrDiscard(ctx).WithValues(
+		"project", projectName,
+		"ref", event.Ref,
+	)
+	ctx = logr.NewContext(ctx, logger)
+
+	for _, hook := range hooks {
+		hook := hook
+
+		if err := h.handlePushEventHook(ctx, event, &hook); err != nil {
+			return fmt.Errorf("failed to handle push event: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (h *Handler) handlePushEventHook(ctx context.Context, event *gitlab.PushEvent, hook *v1beta1.GitLabWebhook) error {
+	project := extractProject(hook, event.Project.PathWithNamespace)
+	logger := logr.FromContextOrDiscard(ctx).WithValues(
+		"webhook", hook,
+	)
+
+	if project == nil {
+		logger.V(log.Debug).Info("Project does not exist in the webhook")
+
+		return nil
+	}
+
+	if project.Push == nil {
+		logger.V(log.Debug).Info("Push event filter is not set")
+
+		return nil
+	}
+
+	ref, ok := gitutil.ParseRef(event.Ref)
+	if !ok {
+		logger.V(log.Debug).Info("Invalid ref")
+
+		return nil
+	}
+
+	switch ref.Type {
+	case gitutil.RefTypeBranch:
+		if (project.Push.Branches == nil && project.Push.Tags != nil) || !hookutil.FilterWebhook(project.Push.Branches, []string{ref.Name}) {
+			logger.V(log.Debug).Info("Skipped on this branch", "branch", ref.Name)
+
+			return nil
+		}
+
+	case gitutil.RefTypeTag:
+		if project.Push.Tags == nil || !hookutil.FilterWebhook(project.Push.Tags, []string{ref.Name}) {
+			logger.V(log.Debug).Info("Skipped on this tag", "tag", ref.Name)
+
+			return nil
+		}
+
+	default:
+		logger.V(log.Debug).Info("Unsupported ref type", "refType", ref.Type)
+
+		return nil
+	}
+
+	options := &hookutil.TriggerOptions{
+		DefaultAction: v1beta1.ActionApply,
+		Action:        hook.Spec.Action,
+		Event:         event,
+		Source:        hook,
+		Triggers:      hook.Spec.Triggers,
+	}
+
+	return h.TriggerHandler.Handle(logr.NewContext(ctx, logger), options)
+}
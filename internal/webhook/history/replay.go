@@ -0,0 +1,109 @@
+package history
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/wire"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrPayloadTruncated is returned when the payload of a record is omitted, so
+// it can't be replayed.
+var ErrPayloadTruncated = errors.New("payload of the event record is truncated")
+
+// ErrTriggersRemoved is returned when none of triggers of a record are still
+// listed on the event source.
+var ErrTriggersRemoved = errors.New("triggers of the event record are no longer listed on the source")
+
+// ReplayerSet provides a Replayer.
+// nolint: gochecknoglobals
+var ReplayerSet = wire.NewSet(
+	wire.Struct(new(Replayer), "*"),
+)
+
+// Replayer replays event records through TriggerHandler.
+type Replayer struct {
+	Client         client.Client
+	TriggerHandler hookutil.TriggerHandler
+}
+
+type ReplayOptions struct {
+	// Trigger replaces triggers of the record when it is set.
+	Trigger *v1beta1.EventSourceTrigger
+}
+
+func (r *Replayer) Replay(ctx context.Context, record *v1beta1.EventRecord, opts ReplayOptions) error {
+	spec := record.Spec
+
+	if spec.PayloadTruncated {
+		return ErrPayloadTruncated
+	}
+
+	source, err := hookutil.GetSource(ctx, r.Client, spec.SourceRef)
+	if err != nil {
+		return err
+	}
+
+	options := &hookutil.TriggerOptions{
+		Source:      source,
+		Action:      spec.Action,
+		Annotations: spec.Annotations,
+		Headers:     spec.Headers,
+		User:        spec.User,
+	}
+
+	if spec.Payload != nil {
+		options.Event = spec.Payload
+	}
+
+	if opts.Trigger != nil {
+		options.Triggers = []v1beta1.EventSourceTrigger{*opts.Trigger}
+	} else {
+		// Triggers may be removed from the source after the event is recorded,
+		// so only triggers which are still listed on the source are replayed.
+		current, err := hookutil.GetSourceTriggers(source)
+		if err != nil {
+			return err
+		}
+
+		options.Triggers = filterTriggers(source, spec.Triggers, current)
+
+		if len(options.Triggers) == 0 {
+			return ErrTriggersRemoved
+		}
+	}
+
+	return r.TriggerHandler.Handle(ctx, options)
+}
+
+func filterTriggers(source client.Object, triggers, current []v1beta1.EventSourceTrigger) []v1beta1.EventSourceTrigger {
+	getKey := func(t v1beta1.EventSourceTrigger) types.NamespacedName {
+		key := types.NamespacedName{Namespace: t.Namespace, Name: t.Name}
+
+		if key.Namespace == "" {
+			key.Namespace = source.GetNamespace()
+		}
+
+		return key
+	}
+
+	listed := make(map[types.NamespacedName]bool, len(current))
+
+	for _, t := range current {
+		listed[getKey(t)] = true
+	}
+
+	var result []v1beta1.EventSourceTrigger
+
+	for _, t := range triggers {
+		if listed[getKey(t)] {
+			result = append(result, t)
+		}
+	}
+
+	return result
+}
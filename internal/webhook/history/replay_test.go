@@ -0,0 +1,128 @@
+package history
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+)
+
+var _ = Describe("Replayer", func() {
+	var (
+		t              *testContext
+		conf           Config
+		record         v1beta1.EventRecord
+		options        ReplayOptions
+		sourceTriggers []v1beta1.EventSourceTrigger
+		err            error
+	)
+
+	BeforeEach(func() {
+		conf = Config{
+			Limit:          10,
+			TTL:            time.Hour,
+			MaxPayloadSize: 1024,
+		}
+		options = ReplayOptions{}
+		sourceTriggers = nil
+	})
+
+	JustBeforeEach(func() {
+		t = newTestContext(conf)
+		Expect(t.Handle("abc")).To(Succeed())
+
+		records := t.ListRecords()
+		Expect(records).To(HaveLen(1))
+		record = records[0]
+
+		if sourceTriggers != nil {
+			t.SetSourceTriggers(sourceTriggers)
+		}
+
+		err = t.replayer.Replay(context.Background(), &record, options)
+	})
+
+	AfterEach(func() {
+		t.Stop()
+	})
+
+	When("trigger is not given", func() {
+		It("should succeed", func() {
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should record the replayed event", func() {
+			records := t.ListRecords()
+			Expect(records).To(HaveLen(2))
+
+			for _, r := range records {
+				Expect(r.Spec.Payload.Raw).To(MatchJSON(`{"name":"abc"}`))
+				Expect(r.Spec.Action).To(Equal(v1beta1.ActionApply))
+			}
+		})
+	})
+
+	When("trigger is given", func() {
+		BeforeEach(func() {
+			options.Trigger = &v1beta1.EventSourceTrigger{Name: "other"}
+		})
+
+		It("should succeed", func() {
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should handle the given trigger", func() {
+			Expect(t.ResourceTemplateExists("other-abc")).To(BeTrue())
+		})
+	})
+
+	When("the trigger is removed from the source", func() {
+		BeforeEach(func() {
+			sourceTriggers = []v1beta1.EventSourceTrigger{
+				{Name: "other"},
+			}
+		})
+
+		It("should return the error", func() {
+			Expect(err).To(Equal(ErrTriggersRemoved))
+		})
+
+		It("should not record the replayed event", func() {
+			Expect(t.ListRecords()).To(HaveLen(1))
+		})
+
+		It("should not handle the removed trigger", func() {
+			Expect(t.ResourceTemplateExists("other-abc")).To(BeFalse())
+		})
+	})
+
+	When("the trigger is still listed on the source", func() {
+		BeforeEach(func() {
+			sourceTriggers = []v1beta1.EventSourceTrigger{
+				{Name: "other"},
+				{Name: "foobar"},
+			}
+		})
+
+		It("should succeed", func() {
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should only handle triggers of the record", func() {
+			Expect(t.ListRecords()).To(HaveLen(2))
+			Expect(t.ResourceTemplateExists("other-abc")).To(BeFalse())
+		})
+	})
+
+	When("payload is truncated", func() {
+		BeforeEach(func() {
+			conf.MaxPayloadSize = 4
+		})
+
+		It("should return the error", func() {
+			Expect(err).To(Equal(ErrPayloadTruncated))
+		})
+	})
+})
@@ -0,0 +1,282 @@
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/wire"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// +kubebuilder:rbac:groups=pullup.dev,resources=eventrecords,verbs=get;list;watch;create;delete
+
+const cleanupInterval = 10 * time.Minute
+
+// StoreConfigSet provides a StoreConfig.
+// nolint: gochecknoglobals
+var StoreConfigSet = wire.NewSet(
+	wire.Struct(new(StoreConfig), "*"),
+)
+
+// StoreSet provides a Store.
+// nolint: gochecknoglobals
+var StoreSet = wire.NewSet(
+	StoreConfigSet,
+	NewStore,
+	wire.Bind(new(hookutil.TriggerHistory), new(*Store)),
+)
+
+type Config struct {
+	// Limit is the max number of records of each event source. Records are
+	// disabled when it is zero.
+	Limit int `mapstructure:"limit"`
+
+	// TTL is how long records are kept. Records are kept until the limit is
+	// reached when it is zero.
+	TTL time.Duration `mapstructure:"ttl"`
+
+	// MaxPayloadSize is the max size of payloads in bytes. Payloads exceeding
+	// this size are omitted.
+	MaxPayloadSize int `mapstructure:"maxPayloadSize"`
+}
+
+type StoreConfig struct {
+	Config Config
+	Client client.Client
+	Reader client.Reader
+	Logger logr.Logger
+}
+
+// Store records events handled by TriggerHandler as EventRecord objects in
+// namespaces of event sources.
+type Store struct {
+	StoreConfig
+
+	now func() time.Time
+}
+
+func NewStore(conf StoreConfig) *Store {
+	return &Store{
+		StoreConfig: conf,
+		now:         time.Now,
+	}
+}
+
+func (s *Store) Enabled() bool {
+	return s.Config.Limit > 0
+}
+
+func (s *Store) Record(ctx context.Context, options *hookutil.TriggerOptions, action string, outcomes []hookutil.TriggerOutcome) {
+	if !s.Enabled() {
+		return
+	}
+
+	logger := s.Logger.WithValues("source", client.ObjectKeyFromObject(options.Source))
+
+	record, err := s.newRecord(options, action, outcomes)
+	if err != nil {
+		logger.Error(err, "Failed to build the event record")
+
+		return
+	}
+
+	if err := s.Client.Create(ctx, record); err != nil {
+		logger.Error(err, "Failed to create the event record")
+
+		return
+	}
+
+	if err := s.prune(ctx, record); err != nil {
+		logger.Error(err, "Failed to prune event records")
+	}
+}
+
+func (s *Store) newRecord(options *hookutil.TriggerOptions, action string, outcomes []hookutil.TriggerOutcome) (*v1beta1.EventRecord, error) {
+	source := options.Source
+	gvk, _, err := s.Client.Scheme().ObjectKinds(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the kind of the source: %w", err)
+	}
+
+	apiVersion, kind := gvk[0].ToAPIVersionAndKind()
+	record := &v1beta1.EventRecord{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1beta1.GroupVersion.String(),
+			Kind:       "EventRecord",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:    source.GetNamespace(),
+			GenerateName: source.GetName() + "-",
+			Labels: map[string]string{
+				v1beta1.LabelSourceKind: kind,
+			},
+		},
+		Spec: v1beta1.EventRecordSpec{
+			SourceRef: v1beta1.ObjectReference{
+				APIVersion: apiVersion,
+				Kind:       kind,
+				Namespace:  source.GetNamespace(),
+				Name:       source.GetName(),
+			},
+			Triggers:    options.Triggers,
+			Action:      action,
+			Annotations: options.Annotations,
+			Headers:     options.Headers,
+			User:        options.User,
+		},
+	}
+
+	if len(validation.IsValidLabelValue(source.GetName())) == 0 {
+		record.Labels[v1beta1.LabelSourceName] = source.GetName()
+	}
+
+	if options.Event != nil {
+		payload, err := json.Marshal(options.Event)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal the event: %w", err)
+		}
+
+		record.Spec.PayloadSize = len(payload)
+
+		if max := s.Config.MaxPayloadSize; max > 0 && len(payload) > max {
+			record.Spec.PayloadTruncated = true
+		} else {
+			record.Spec.Payload = &extv1.JSON{Raw: payload}
+		}
+	}
+
+	for _, outcome := range outcomes {
+		record.Status.Triggers = append(record.Status.Triggers, v1beta1.EventRecordTriggerStatus{
+			TriggerRef:       outcome.TriggerRef,
+			ResourceTemplate: outcome.ResourceTemplate,
+			Reason:           outcome.Reason,
+			Message:          outcome.Message,
+			Failed:           outcome.Failed,
+		})
+	}
+
+	// Records are deleted along with event sources.
+	if err := controllerutil.SetOwnerReference(source, record, s.Client.Scheme()); err != nil {
+		return nil, fmt.Errorf("failed to set owner reference: %w", err)
+	}
+
+	return record, nil
+}
+
+// prune deletes records of the same source as the latest record, which exceed
+// the limit or are expired.
+func (s *Store) prune(ctx context.Context, latest *v1beta1.EventRecord) error {
+	var list v1beta1.EventRecordList
+	ref := latest.Spec.SourceRef
+
+	labels := client.MatchingLabels{v1beta1.LabelSourceKind: ref.Kind}
+
+	// Only records of the source are listed. Sources whose names are not valid
+	// label values are not labeled, so records are filtered by references below.
+	if name, ok := latest.Labels[v1beta1.LabelSourceName]; ok {
+		labels[v1beta1.LabelSourceName] = name
+	}
+
+	err := s.Reader.List(ctx, &list, client.InNamespace(ref.Namespace), labels)
+	if err != nil {
+		return fmt.Errorf("failed to list event records: %w", err)
+	}
+
+	var records []*v1beta1.EventRecord
+
+	for i := range list.Items {
+		item := &list.Items[i]
+
+		if item.Spec.SourceRef.Name == ref.Name && item.Spec.SourceRef.APIVersion == ref.APIVersion {
+			records = append(records, item)
+		}
+	}
+
+	// Sort records from the newest to the oldest. Creation timestamps are in
+	// seconds, so the latest record is always sorted first.
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].Name == latest.Name {
+			return true
+		}
+
+		if records[j].Name == latest.Name {
+			return false
+		}
+
+		a, b := records[i].CreationTimestamp, records[j].CreationTimestamp
+
+		return b.Before(&a)
+	})
+
+	for i, record := range records {
+		if i < s.Config.Limit && !s.isExpired(record) {
+			continue
+		}
+
+		if err := s.delete(ctx, record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) isExpired(record *v1beta1.EventRecord) bool {
+	ttl := s.Config.TTL
+
+	return ttl > 0 && !s.now().Before(record.CreationTimestamp.Add(ttl))
+}
+
+func (s *Store) delete(ctx context.Context, record *v1beta1.EventRecord) error {
+	if err := s.Client.Delete(ctx, record); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete the event record: %w", err)
+	}
+
+	return nil
+}
+
+// Start deletes expired records periodically until the context is done.
+func (s *Store) Start(ctx context.Context) error {
+	if s.Config.TTL <= 0 {
+		<-ctx.Done()
+
+		return nil
+	}
+
+	wait.UntilWithContext(ctx, s.cleanup, cleanupInterval)
+
+	return nil
+}
+
+func (s *Store) cleanup(ctx context.Context) {
+	var list v1beta1.EventRecordList
+
+	if err := s.Reader.List(ctx, &list); err != nil {
+		s.Logger.Error(err, "Failed to list event records")
+
+		return
+	}
+
+	for i := range list.Items {
+		record := &list.Items[i]
+
+		if !s.isExpired(record) {
+			continue
+		}
+
+		if err := s.delete(ctx, record); err != nil {
+			s.Logger.Error(err, "Failed to delete the event record", "name", client.ObjectKeyFromObject(record))
+		}
+	}
+}
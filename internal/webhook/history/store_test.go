@@ -0,0 +1,274 @@
+package history
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tommy351/pullup/internal/k8s"
+	"github.com/tommy351/pullup/internal/random"
+	"github.com/tommy351/pullup/internal/testenv"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type testContext struct {
+	store        *Store
+	replayer     *Replayer
+	mgr          *testenv.Manager
+	namespaceMap *random.NamespaceMap
+	data         []client.Object
+}
+
+func newTestContext(conf Config) *testContext {
+	mgr, err := testenv.NewManager()
+	Expect(err).NotTo(HaveOccurred())
+
+	t := &testContext{
+		mgr:          mgr,
+		namespaceMap: random.NewNamespaceMap(),
+	}
+	t.store = NewStore(NewStoreConfig(mgr, conf, log.Log))
+	t.replayer = NewReplayer(mgr, t.store)
+
+	Expect(mgr.Initialize()).To(Succeed())
+
+	t.data, err = k8s.LoadObjects(testenv.GetScheme(), "testdata/webhook.yml")
+	Expect(err).NotTo(HaveOccurred())
+
+	t.data, err = k8s.MapObjects(t.data, t.namespaceMap.SetObject)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(testenv.CreateObjects(t.data)).To(Succeed())
+
+	return t
+}
+
+func (t *testContext) Stop() {
+	Expect(testenv.DeleteObjects(t.data)).To(Succeed())
+	t.mgr.Stop()
+}
+
+func (t *testContext) Namespace() string {
+	return t.namespaceMap.GetRandom("test")
+}
+
+func (t *testContext) GetSource() *v1beta1.HTTPWebhook {
+	source := new(v1beta1.HTTPWebhook)
+	Expect(t.store.Reader.Get(context.Background(), types.NamespacedName{
+		Namespace: t.Namespace(),
+		Name:      "foobar",
+	}, source)).To(Succeed())
+
+	return source
+}
+
+func (t *testContext) SetSourceTriggers(triggers []v1beta1.EventSourceTrigger) {
+	source := t.GetSource()
+	source.Spec.Triggers = triggers
+	Expect(t.store.Client.Update(context.Background(), source)).To(Succeed())
+
+	Eventually(func() ([]v1beta1.EventSourceTrigger, error) {
+		var current v1beta1.HTTPWebhook
+		err := t.replayer.Client.Get(context.Background(), types.NamespacedName{
+			Namespace: source.Namespace,
+			Name:      source.Name,
+		}, &current)
+
+		return current.Spec.Triggers, err
+	}).Should(Equal(triggers))
+}
+
+func (t *testContext) Handle(name string) error {
+	return t.replayer.TriggerHandler.Handle(context.Background(), &hookutil.TriggerOptions{
+		Source: t.GetSource(),
+		Triggers: []v1beta1.EventSourceTrigger{
+			{Name: "foobar"},
+		},
+		Action: v1beta1.ActionApply,
+		Event:  map[string]interface{}{"name": name},
+		Headers: map[string]string{
+			"X-Foo":                 "bar",
+			"Authorization":         "Bearer abc",
+			"Pullup-Webhook-Secret": "abc",
+		},
+		User: "foo",
+	})
+}
+
+func (t *testContext) ListRecords() []v1beta1.EventRecord {
+	var list v1beta1.EventRecordList
+	Expect(t.store.Reader.List(context.Background(), &list, client.InNamespace(t.Namespace()))).To(Succeed())
+
+	return list.Items
+}
+
+func (t *testContext) ResourceTemplateExists(name string) bool {
+	err := t.store.Reader.Get(context.Background(), types.NamespacedName{
+		Namespace: t.Namespace(),
+		Name:      name,
+	}, new(v1beta1.ResourceTemplate))
+
+	return err == nil
+}
+
+var _ = Describe("Store", func() {
+	var (
+		t    *testContext
+		conf Config
+	)
+
+	BeforeEach(func() {
+		conf = Config{
+			Limit:          2,
+			TTL:            time.Hour,
+			MaxPayloadSize: 1024,
+		}
+	})
+
+	JustBeforeEach(func() {
+		t = newTestContext(conf)
+	})
+
+	AfterEach(func() {
+		t.Stop()
+	})
+
+	When("event is handled", func() {
+		var record v1beta1.EventRecord
+
+		JustBeforeEach(func() {
+			Expect(t.Handle("abc")).To(Succeed())
+
+			records := t.ListRecords()
+			Expect(records).To(HaveLen(1))
+			record = records[0]
+		})
+
+		It("should record the source", func() {
+			Expect(record.Spec.SourceRef).To(Equal(v1beta1.ObjectReference{
+				APIVersion: v1beta1.GroupVersion.String(),
+				Kind:       "HTTPWebhook",
+				Namespace:  t.Namespace(),
+				Name:       "foobar",
+			}))
+			Expect(record.Labels).To(Equal(map[string]string{
+				v1beta1.LabelSourceKind: "HTTPWebhook",
+				v1beta1.LabelSourceName: "foobar",
+			}))
+			Expect(record.OwnerReferences).To(HaveLen(1))
+			Expect(record.OwnerReferences[0].Name).To(Equal("foobar"))
+		})
+
+		It("should record the action and the user", func() {
+			Expect(record.Spec.Action).To(Equal(v1beta1.ActionApply))
+			Expect(record.Spec.User).To(Equal("foo"))
+		})
+
+		It("should omit sensitive headers", func() {
+			Expect(record.Spec.Headers).To(Equal(map[string]string{"X-Foo": "bar"}))
+		})
+
+		It("should record the payload", func() {
+			Expect(record.Spec.Payload.Raw).To(MatchJSON(`{"name":"abc"}`))
+			Expect(record.Spec.PayloadSize).To(Equal(len(`{"name":"abc"}`)))
+			Expect(record.Spec.PayloadTruncated).To(BeFalse())
+		})
+
+		It("should record outcomes of triggers", func() {
+			Expect(record.Status.Triggers).To(HaveLen(1))
+
+			status := record.Status.Triggers[0]
+			Expect(status.TriggerRef.NamespacedName()).To(Equal(types.NamespacedName{
+				Namespace: t.Namespace(),
+				Name:      "foobar",
+			}))
+			Expect(status.ResourceTemplate).To(Equal("foobar-abc"))
+			Expect(status.Reason).To(Equal(hookutil.ReasonCreated))
+			Expect(status.Failed).To(BeFalse())
+		})
+	})
+
+	When("payload exceeds the max size", func() {
+		BeforeEach(func() {
+			conf.MaxPayloadSize = 4
+		})
+
+		It("should omit the payload", func() {
+			Expect(t.Handle("abc")).To(Succeed())
+
+			records := t.ListRecords()
+			Expect(records).To(HaveLen(1))
+			Expect(records[0].Spec.Payload).To(BeNil())
+			Expect(records[0].Spec.PayloadTruncated).To(BeTrue())
+			Expect(records[0].Spec.PayloadSize).To(Equal(len(`{"name":"abc"}`)))
+		})
+	})
+
+	When("records exceed the limit", func() {
+		It("should delete old records", func() {
+			for _, name := range []string{"a", "b", "c"} {
+				Expect(t.Handle(name)).To(Succeed())
+			}
+
+			records := t.ListRecords()
+			Expect(records).To(HaveLen(2))
+			Expect(records).To(ContainElement(WithTransform(func(r v1beta1.EventRecord) string {
+				return string(r.Spec.Payload.Raw)
+			}, MatchJSON(`{"name":"c"}`))))
+		})
+
+		It("should not delete records of other sources", func() {
+			other := new(v1beta1.HTTPWebhook)
+			Expect(t.store.Reader.Get(context.Background(), types.NamespacedName{
+				Namespace: t.Namespace(),
+				Name:      "other",
+			}, other)).To(Succeed())
+			Expect(t.replayer.TriggerHandler.Handle(context.Background(), &hookutil.TriggerOptions{
+				Source:   other,
+				Triggers: []v1beta1.EventSourceTrigger{{Name: "foobar"}},
+				Action:   v1beta1.ActionApply,
+				Event:    map[string]interface{}{"name": "other"},
+			})).To(Succeed())
+
+			for _, name := range []string{"a", "b", "c"} {
+				Expect(t.Handle(name)).To(Succeed())
+			}
+
+			Expect(t.ListRecords()).To(HaveLen(3))
+		})
+	})
+
+	When("records are disabled", func() {
+		BeforeEach(func() {
+			conf.Limit = 0
+		})
+
+		It("should not record events", func() {
+			Expect(t.Handle("abc")).To(Succeed())
+			Expect(t.ListRecords()).To(BeEmpty())
+		})
+	})
+
+	Describe("cleanup", func() {
+		JustBeforeEach(func() {
+			Expect(t.Handle("abc")).To(Succeed())
+		})
+
+		It("should keep records which are not expired", func() {
+			t.store.cleanup(context.Background())
+			Expect(t.ListRecords()).To(HaveLen(1))
+		})
+
+		It("should delete expired records", func() {
+			t.store.now = func() time.Time {
+				return time.Now().Add(2 * time.Hour)
+			}
+			t.store.cleanup(context.Background())
+			Expect(t.ListRecords()).To(BeEmpty())
+		})
+	})
+})
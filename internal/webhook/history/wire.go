@@ -0,0 +1,30 @@
+// +build wireinject
+
+package history
+
+import (
+	"github.com/go-logr/logr"
+	"github.com/google/wire"
+	"github.com/tommy351/pullup/internal/controller"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+func NewStoreConfig(mgr manager.Manager, conf Config, logger logr.Logger) StoreConfig {
+	wire.Build(
+		controller.NewClient,
+		controller.NewAPIReader,
+		StoreConfigSet,
+	)
+	return StoreConfig{}
+}
+
+func NewReplayer(mgr manager.Manager, history hookutil.TriggerHistory) *Replayer {
+	wire.Build(
+		controller.NewClient,
+		hookutil.NewEventRecorder,
+		hookutil.TriggerHandlerWithHistorySet,
+		ReplayerSet,
+	)
+	return nil
+}
@@ -0,0 +1,46 @@
+package hookutil
+
+import (
+	"context"
+	"sync"
+)
+
+type triggerCollectorKey struct{}
+
+// TriggerCollector collects options passed to TriggerHandler instead of
+// handling them, so they can be handled later, e.g. in a work queue.
+type TriggerCollector struct {
+	mu      sync.Mutex
+	options []*TriggerOptions
+}
+
+func NewTriggerCollector() *TriggerCollector {
+	return &TriggerCollector{}
+}
+
+func (c *TriggerCollector) add(options *TriggerOptions) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.options = append(c.options, options)
+}
+
+// Options returns collected options.
+func (c *TriggerCollector) Options() []*TriggerOptions {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]*TriggerOptions{}, c.options...)
+}
+
+// WithTriggerCollector returns a context which makes TriggerHandler collect
+// options in the collector.
+func WithTriggerCollector(ctx context.Context, c *TriggerCollector) context.Context {
+	return context.WithValue(ctx, triggerCollectorKey{}, c)
+}
+
+func getTriggerCollector(ctx context.Context) *TriggerCollector {
+	c, _ := ctx.Value(triggerCollectorKey{}).(*TriggerCollector)
+
+	return c
+}
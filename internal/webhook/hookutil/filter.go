@@ -52,3 +52,24 @@ func FilterWebhook(filter *v1beta1.EventSourceFilter, input []string) bool {
 
 	return true
 }
+
+// FilterPaths returns true when any of paths is included and not excluded by
+// the filter. Unlike FilterWebhook, an excluded path does not prevent other
+// paths from matching, so changes in ignored paths are simply skipped.
+func FilterPaths(filter *v1beta1.EventSourceFilter, paths []string) bool {
+	if filter == nil {
+		return true
+	}
+
+	for _, path := range paths {
+		if len(filter.Include) > 0 && !FilterByConditions(filter.Include, path) {
+			continue
+		}
+
+		if !FilterByConditions(filter.Exclude, path) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -63,3 +63,49 @@ var _ = Describe("FilterWebhook", func() {
 		Entry("Multi input include & exclude", []string{"a", "ab", "ac", "abc"}, false),
 	)
 })
+
+var _ = Describe("FilterPaths", func() {
+	It("should return true when filter is nil", func() {
+		Expect(FilterPaths(nil, []string{"a"})).To(BeTrue())
+	})
+
+	DescribeTable("include only", func(input []string, expected bool) {
+		filter := &v1beta1.EventSourceFilter{
+			Include: []string{"README.md", `/^foo\//`},
+		}
+		Expect(FilterPaths(filter, input)).To(Equal(expected))
+	},
+		Entry("Exact match - true", []string{"README.md"}, true),
+		Entry("Exact match - false", []string{"LICENSE"}, false),
+		Entry("Pattern - true", []string{"foo/main.go"}, true),
+		Entry("Pattern - false", []string{"bar/main.go"}, false),
+		Entry("Multi input - true", []string{"bar/main.go", "foo/main.go"}, true),
+		Entry("Empty input", []string{}, false),
+	)
+
+	DescribeTable("exclude only", func(input []string, expected bool) {
+		filter := &v1beta1.EventSourceFilter{
+			Exclude: []string{"README.md", `/^docs\//`},
+		}
+		Expect(FilterPaths(filter, input)).To(Equal(expected))
+	},
+		Entry("Exact match - true", []string{"main.go"}, true),
+		Entry("Exact match - false", []string{"README.md"}, false),
+		Entry("Pattern - false", []string{"docs/index.md"}, false),
+		Entry("Multi input - true", []string{"docs/index.md", "main.go"}, true),
+		Entry("Multi input - false", []string{"docs/index.md", "README.md"}, false),
+	)
+
+	DescribeTable("include & exclude", func(input []string, expected bool) {
+		filter := &v1beta1.EventSourceFilter{
+			Include: []string{`/^foo\//`},
+			Exclude: []string{`/\.md$/`},
+		}
+		Expect(FilterPaths(filter, input)).To(Equal(expected))
+	},
+		Entry("Include", []string{"foo/main.go"}, true),
+		Entry("Exclude", []string{"foo/README.md"}, false),
+		Entry("Multi input include & exclude", []string{"foo/README.md", "foo/main.go"}, true),
+		Entry("Multi input not included", []string{"foo/README.md", "bar/main.go"}, false),
+	)
+})
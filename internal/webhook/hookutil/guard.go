@@ -0,0 +1,38 @@
+package hookutil
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrDeliveryHandled is returned by DeliveryGuard when the event source has
+// handled the delivery, so the event is skipped.
+var ErrDeliveryHandled = errors.New("delivery has been handled")
+
+type deliveryGuardKey struct{}
+
+// DeliveryGuard is called by TriggerHandler before an event is handled. Webhook
+// handlers only call TriggerHandler after senders are authenticated, so guards
+// can safely record deliveries of the event source.
+type DeliveryGuard interface {
+	// Acquire returns an error when the event should not be handled, e.g. the
+	// delivery has been handled before. The event is skipped without errors
+	// when it returns ErrDeliveryHandled.
+	Acquire(ctx context.Context, options *TriggerOptions) error
+
+	// Done is called after triggers of an acquired event are handled. err is
+	// nil when all of triggers succeed.
+	Done(ctx context.Context, options *TriggerOptions, err error)
+}
+
+// WithDeliveryGuard returns a context which makes TriggerHandler call the guard
+// before events are handled.
+func WithDeliveryGuard(ctx context.Context, g DeliveryGuard) context.Context {
+	return context.WithValue(ctx, deliveryGuardKey{}, g)
+}
+
+func getDeliveryGuard(ctx context.Context) DeliveryGuard {
+	g, _ := ctx.Value(deliveryGuardKey{}).(DeliveryGuard)
+
+	return g
+}
@@ -36,6 +36,7 @@ func formatJSONSchemaValidationError(err *jsonschema.ValidationError) (output []
 func NewHandler(handler httputil.Handler) http.Handler {
 	return httputil.NewHandler(func(w http.ResponseWriter, r *http.Request) error {
 		logger := logr.FromContextOrDiscard(r.Context())
+		r = r.WithContext(withDeliveryHeaders(r.Context(), r.Header))
 
 		if err := handler(w, r); err != nil {
 			var (
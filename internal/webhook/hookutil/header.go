@@ -0,0 +1,103 @@
+package hookutil
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Headers which contain any of these words are dropped because they may
+// contain credentials, except delivery headers.
+// nolint: gochecknoglobals
+var sensitiveHeaderWords = []string{
+	"authorization",
+	"cookie",
+	"key",
+	"password",
+	"secret",
+	"signature",
+	"token",
+}
+
+// Headers which identify deliveries of webhooks. They are kept for webhooks
+// which do not pass request headers to TriggerHandler.
+// nolint: gochecknoglobals
+var deliveryHeaders = []string{
+	"Content-Type",
+	"User-Agent",
+	"Idempotency-Key",
+	"X-GitHub-Delivery",
+	"X-GitHub-Event",
+	"X-GitHub-Hook-ID",
+	"X-Gitlab-Event",
+	"X-Gitlab-Event-UUID",
+	"X-Gitea-Delivery",
+	"X-Gitea-Event",
+	"X-Event-Key",
+	"X-Request-UUID",
+	"X-Hook-UUID",
+	"Ce-Id",
+	"Ce-Source",
+	"Ce-Specversion",
+	"Ce-Type",
+}
+
+type deliveryHeadersKey struct{}
+
+func withDeliveryHeaders(ctx context.Context, header http.Header) context.Context {
+	headers := map[string]string{}
+
+	for _, name := range deliveryHeaders {
+		if value := header.Get(name); value != "" {
+			headers[name] = value
+		}
+	}
+
+	return context.WithValue(ctx, deliveryHeadersKey{}, headers)
+}
+
+func getDeliveryHeaders(ctx context.Context) map[string]string {
+	if headers, ok := ctx.Value(deliveryHeadersKey{}).(map[string]string); ok && len(headers) > 0 {
+		return headers
+	}
+
+	return nil
+}
+
+// FilterHeaders returns a copy of headers without headers which may contain
+// credentials.
+func FilterHeaders(headers map[string]string) map[string]string {
+	if headers == nil {
+		return nil
+	}
+
+	result := map[string]string{}
+
+	for k, v := range headers {
+		if !isSensitiveHeader(k) {
+			result[k] = v
+		}
+	}
+
+	return result
+}
+
+func isSensitiveHeader(name string) bool {
+	// Delivery headers such as Idempotency-Key and X-Event-Key do not contain
+	// credentials even though their names contain "key".
+	for _, h := range deliveryHeaders {
+		if strings.EqualFold(name, h) {
+			return false
+		}
+	}
+
+	name = strings.ToLower(name)
+
+	for _, word := range sensitiveHeaderWords {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+
+	return false
+}
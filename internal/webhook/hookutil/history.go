@@ -0,0 +1,22 @@
+package hookutil
+
+import (
+	"context"
+
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+)
+
+// TriggerOutcome is the result of handling a trigger.
+type TriggerOutcome struct {
+	TriggerRef       v1beta1.ObjectReference
+	ResourceTemplate string
+	Reason           string
+	Message          string
+	Failed           bool
+}
+
+// TriggerHistory records events handled by TriggerHandler. Errors are not
+// returned because they should not affect handling of events.
+type TriggerHistory interface {
+	Record(ctx context.Context, options *TriggerOptions, action string, outcomes []TriggerOutcome)
+}
@@ -0,0 +1,46 @@
+package hookutil
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type SecretKeyNotFoundError struct {
+	key  types.NamespacedName
+	name string
+}
+
+func (s SecretKeyNotFoundError) Error() string {
+	return fmt.Sprintf("key %q does not exist in secret %s/%s", s.name, s.key.Namespace, s.key.Name)
+}
+
+// GetSecretValue returns the value of the secret key referenced by value.
+// It returns nil if value does not reference any secret.
+func GetSecretValue(ctx context.Context, reader client.Reader, namespace string, value *v1beta1.SecretValue) ([]byte, error) {
+	if value == nil || value.SecretKeyRef == nil {
+		return nil, nil
+	}
+
+	ref := value.SecretKeyRef
+	secret := new(corev1.Secret)
+	key := types.NamespacedName{
+		Namespace: namespace,
+		Name:      ref.Name,
+	}
+
+	if err := reader.Get(ctx, key, secret); err != nil {
+		return nil, fmt.Errorf("failed to get secret: %w", err)
+	}
+
+	data, ok := secret.Data[ref.Key]
+	if !ok {
+		return nil, SecretKeyNotFoundError{key: key, name: ref.Key}
+	}
+
+	return data, nil
+}
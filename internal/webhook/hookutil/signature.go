@@ -0,0 +1,43 @@
+package hookutil
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" // nolint: gosec
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"strings"
+)
+
+// nolint: gochecknoglobals
+var signatureHashes = map[string]func() hash.Hash{
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// ValidateSignature reports whether signature is a valid HMAC of payload
+// signed with secret. The signature must be prefixed with the name of the
+// hash algorithm, e.g. "sha256=5257a869...".
+func ValidateSignature(signature string, payload, secret []byte) bool {
+	chunks := strings.SplitN(signature, "=", 2)
+	if len(chunks) != 2 {
+		return false
+	}
+
+	newHash, ok := signatureHashes[chunks[0]]
+	if !ok {
+		return false
+	}
+
+	actual, err := hex.DecodeString(chunks[1])
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(newHash, secret)
+	_, _ = mac.Write(payload)
+
+	return hmac.Equal(actual, mac.Sum(nil))
+}
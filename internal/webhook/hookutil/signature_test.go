@@ -0,0 +1,19 @@
+package hookutil
+
+import (
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = DescribeTable("ValidateSignature", func(signature string, expected bool) {
+	Expect(ValidateSignature(signature, []byte("foo"), []byte("secret"))).To(Equal(expected))
+},
+	Entry("sha1", "sha1=9baed91be7f58b57c824b60da7cb262b2ecafbd2", true),
+	Entry("sha256", "sha256=773ba44693c7553d6ee20f61ea5d2757a9a4f4a44d2841ae4e95b52e4cd62db4", true),
+	Entry("sha512", "sha512=82df7103de8d82de45e01c45fe642b5d13c6c2b47decafebc009431c665c6fa5f3d1af4e978ea1bde91426622073ebeac61a3461efd467e0971c788bc8ebdbbe", true),
+	Entry("mismatch", "sha256=0000000000000000000000000000000000000000000000000000000000000000", false),
+	Entry("unsupported algorithm", "md5=acbd18db4cc2f85cedef654fccc4a4d8", false),
+	Entry("invalid hex", "sha256=xyz", false),
+	Entry("without algorithm", "773ba44693c7553d6ee20f61ea5d2757a9a4f4a44d2841ae4e95b52e4cd62db4", false),
+	Entry("empty", "", false),
+)
@@ -0,0 +1,65 @@
+package hookutil
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetSourceRef returns a reference to the event source.
+func GetSourceRef(scheme *runtime.Scheme, source client.Object) (v1beta1.ObjectReference, error) {
+	gvk, _, err := scheme.ObjectKinds(source)
+	if err != nil {
+		return v1beta1.ObjectReference{}, fmt.Errorf("failed to get the kind of the source: %w", err)
+	}
+
+	apiVersion, kind := gvk[0].ToAPIVersionAndKind()
+
+	return v1beta1.ObjectReference{
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Namespace:  source.GetNamespace(),
+		Name:       source.GetName(),
+	}, nil
+}
+
+// GetSource gets the event source referenced by ref. The error of the scheme is
+// returned as is when the kind is not registered.
+func GetSource(ctx context.Context, c client.Client, ref v1beta1.ObjectReference) (client.Object, error) {
+	obj, err := c.Scheme().New(ref.GroupVersionKind())
+	if err != nil {
+		return nil, err
+	}
+
+	source, ok := obj.(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("unsupported source kind %q", ref.Kind)
+	}
+
+	if err := c.Get(ctx, ref.NamespacedName(), source); err != nil {
+		return nil, fmt.Errorf("failed to get the source %s: %w", ref.NamespacedName(), err)
+	}
+
+	return source, nil
+}
+
+// GetSourceTriggers returns triggers listed on the event source.
+func GetSourceTriggers(source client.Object) ([]v1beta1.EventSourceTrigger, error) {
+	data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert the source: %w", err)
+	}
+
+	var obj struct {
+		Spec v1beta1.EventSourceSpec `json:"spec"`
+	}
+
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(data, &obj); err != nil {
+		return nil, fmt.Errorf("failed to get triggers of the source: %w", err)
+	}
+
+	return obj.Spec.Triggers, nil
+}
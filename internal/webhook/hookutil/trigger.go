@@ -3,6 +3,7 @@ package hookutil
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/go-logr/logr"
@@ -15,7 +16,6 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -43,6 +43,13 @@ const (
 // TriggerHandlerSet provides a TriggerHandler.
 // nolint: gochecknoglobals
 var TriggerHandlerSet = wire.NewSet(
+	wire.Struct(new(TriggerHandler), "Client", "Recorder"),
+)
+
+// TriggerHandlerWithHistorySet provides a TriggerHandler which records events
+// in a TriggerHistory.
+// nolint: gochecknoglobals
+var TriggerHandlerWithHistorySet = wire.NewSet(
 	wire.Struct(new(TriggerHandler), "*"),
 )
 
@@ -57,25 +64,97 @@ type TriggerOptions struct {
 	DefaultAction string
 	Action        string
 	Event         interface{}
+	Annotations   map[string]string
+
+	// Headers of the request which delivers the event. They are available as
+	// "headers" variable in the action template when it is set. Headers which
+	// may contain credentials are dropped before the event is handled. When it
+	// is not set, headers which identify the delivery are used instead.
+	Headers map[string]string
+
+	// User is the authenticated identity which sends the event. It is appended
+	// to messages of recorded events when it is set.
+	User string
 }
 
 type TriggerHandler struct {
 	Client   client.Client
 	Recorder record.EventRecorder
+
+	// History records handled events when it is set.
+	History TriggerHistory
 }
 
-func (t *TriggerHandler) Handle(ctx context.Context, options *TriggerOptions) error {
+func (t *TriggerHandler) Handle(ctx context.Context, options *TriggerOptions) (err error) {
+	// Credentials must not be rendered in templates, nor stored in queued
+	// deliveries and records.
+	if options.Headers != nil {
+		filtered := *options
+		filtered.Headers = FilterHeaders(options.Headers)
+		options = &filtered
+	} else if headers := getDeliveryHeaders(ctx); headers != nil {
+		withHeaders := *options
+		withHeaders.Headers = headers
+		options = &withHeaders
+	}
+
 	action, err := t.renderAction(options)
 	if err != nil {
 		return err
 	}
 
+	g := getDeliveryGuard(ctx)
+
+	if g != nil {
+		if err := g.Acquire(ctx, options); err != nil {
+			if errors.Is(err, ErrDeliveryHandled) {
+				return nil
+			}
+
+			return err
+		}
+	}
+
+	// The action is rendered before it is collected, so invalid actions are
+	// reported to webhook senders immediately.
+	if c := getTriggerCollector(ctx); c != nil {
+		c.add(options)
+
+		return nil
+	}
+
+	if g != nil {
+		defer func() {
+			g.Done(ctx, options, err)
+		}()
+	}
+
+	var outcomes []TriggerOutcome
+
+	if t.History != nil {
+		defer func() {
+			t.History.Record(ctx, options, action, outcomes)
+		}()
+	}
+
 	triggers := make([]*RenderedTrigger, len(options.Triggers))
 
 	for i, trigger := range options.Triggers {
 		trigger := trigger
 		rt, err := t.renderTrigger(ctx, &trigger, options)
 		if err != nil {
+			outcomes = append(outcomes, TriggerOutcome{
+				TriggerRef: v1beta1.ObjectReference{
+					APIVersion: v1beta1.GroupVersion.String(),
+					Kind:       "Trigger",
+					Namespace:  getTriggerNamespace(&trigger, options),
+					Name:       trigger.Name,
+				},
+				Reason:  ReasonFailed,
+				Message: err.Error(),
+				Failed:  true,
+			})
+
 			return err
 		}
 
@@ -84,8 +163,17 @@ func (t *TriggerHandler) Handle(ctx context.Context, options *TriggerOptions) er
 
 	for _, trigger := range triggers {
 		trigger := trigger
+		result := t.handleTrigger(ctx, trigger, action, options)
+
+		outcomes = append(outcomes, TriggerOutcome{
+			TriggerRef:       *trigger.ResourceTemplate.Spec.TriggerRef,
+			ResourceTemplate: trigger.ResourceTemplate.Name,
+			Reason:           result.Reason,
+			Message:          result.GetMessage(),
+			Failed:           result.Error != nil,
+		})
 
-		if err := t.handleTrigger(ctx, trigger, action, options); err != nil {
+		if err := result.Error; err != nil {
 			return err
 		}
 	}
@@ -93,16 +181,30 @@ func (t *TriggerHandler) Handle(ctx context.Context, options *TriggerOptions) er
 	return nil
 }
 
+func getTriggerNamespace(st *v1beta1.EventSourceTrigger, options *TriggerOptions) string {
+	if st.Namespace != "" {
+		return st.Namespace
+	}
+
+	return options.Source.GetNamespace()
+}
+
 func (t *TriggerHandler) renderAction(options *TriggerOptions) (string, error) {
 	action := options.Action
 	if action == "" {
 		action = options.DefaultAction
 	}
 
-	buf, err := json.Marshal(map[string]interface{}{
+	data := map[string]interface{}{
 		v1beta1.DataKeyEvent:  options.Event,
 		v1beta1.DataKeyAction: options.DefaultAction,
-	})
+	}
+
+	if options.Headers != nil {
+		data[v1beta1.DataKeyHeaders] = options.Headers
+	}
+
+	buf, err := json.Marshal(data)
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal data: %w", err)
 	}
@@ -123,11 +225,7 @@ func (t *TriggerHandler) renderTrigger(ctx context.Context, st *v1beta1.EventSou
 	trigger := new(v1beta1.Trigger)
 	triggerKey := types.NamespacedName{
 		Name:      st.Name,
-		Namespace: st.Namespace,
-	}
-
-	if triggerKey.Namespace == "" {
-		triggerKey.Namespace = options.Source.GetNamespace()
+		Namespace: getTriggerNamespace(st, options),
 	}
 
 	if err := t.Client.Get(ctx, triggerKey, trigger); err != nil {
@@ -142,7 +240,8 @@ func (t *TriggerHandler) renderTrigger(ctx context.Context, st *v1beta1.EventSou
 		Trigger: trigger,
 		ResourceTemplate: &v1beta1.ResourceTemplate{
 			ObjectMeta: metav1.ObjectMeta{
-				Namespace: trigger.Namespace,
+				Namespace:   trigger.Namespace,
+				Annotations: options.Annotations,
 			},
 			Spec: v1beta1.ResourceTemplateSpec{
 				TriggerRef: &v1beta1.ObjectReference{
@@ -240,7 +339,7 @@ func (t *TriggerHandler) finalizeData(data extv1.JSON) (extv1.JSON, error) {
 	return extv1.JSON{Raw: buf}, nil
 }
 
-func (t *TriggerHandler) handleTrigger(ctx context.Context, trigger *RenderedTrigger, action string, options *TriggerOptions) error {
+func (t *TriggerHandler) handleTrigger(ctx context.Context, trigger *RenderedTrigger, action string, options *TriggerOptions) controller.Result {
 	var result controller.Result
 	logger := logr.FromContextOrDiscard(ctx)
 
@@ -254,25 +353,27 @@ func (t *TriggerHandler) handleTrigger(ctx context.Context, trigger *RenderedTri
 	case v1beta1.ActionDelete:
 		result = t.deleteResource(ctx, trigger.ResourceTemplate)
 	default:
-		return ErrInvalidAction
+		return controller.Result{Error: ErrInvalidAction, Reason: ReasonFailed}
+	}
+
+	if user := options.User; user != "" {
+		result.Message = fmt.Sprintf("%s (user: %s)", result.GetMessage(), user)
 	}
 
 	result.RecordEvent(t.Recorder, trigger.Trigger)
 
-	t.recordSourceEvent(options.Source, action, &result, trigger.ResourceTemplate.Spec.TriggerRef)
+	t.recordSourceEvent(options, action, &result, trigger.ResourceTemplate.Spec.TriggerRef)
 
 	if err := result.Error; err != nil {
 		logger.Error(err, result.GetMessage())
-
-		return err
+	} else {
+		logger.Info(result.GetMessage())
 	}
 
-	logger.Info(result.GetMessage())
-
-	return nil
+	return result
 }
 
-func (t *TriggerHandler) recordSourceEvent(object runtime.Object, action string, input *controller.Result, triggerRef *v1beta1.ObjectReference) {
+func (t *TriggerHandler) recordSourceEvent(options *TriggerOptions, action string, input *controller.Result, triggerRef *v1beta1.ObjectReference) {
 	var r controller.Result
 
 	if err := input.Error; err != nil {
@@ -287,7 +388,11 @@ func (t *TriggerHandler) recordSourceEvent(object runtime.Object, action string,
 		}
 	}
 
-	r.RecordEvent(t.Recorder, object)
+	if user := options.User; user != "" {
+		r.Message = fmt.Sprintf("%s (user: %s)", r.GetMessage(), user)
+	}
+
+	r.RecordEvent(t.Recorder, options.Source)
 }
 
 func (t *TriggerHandler) createResource(ctx context.Context, rt *v1beta1.ResourceTemplate) controller.Result {
@@ -312,6 +417,8 @@ func (t *TriggerHandler) createResource(ctx context.Context, rt *v1beta1.Resourc
 }
 
 func (t *TriggerHandler) updateResource(ctx context.Context, rt *v1beta1.ResourceTemplate) controller.Result {
+	// Copy annotations because rt is overwritten by the response of the patch.
+	annotations := rt.DeepCopy().Annotations
 	patchValue, err := json.Marshal(rt.Spec)
 	if err != nil {
 		return controller.Result{
@@ -348,12 +455,40 @@ func (t *TriggerHandler) updateResource(ctx context.Context, rt *v1beta1.Resourc
 		}
 	}
 
+	if err := t.patchAnnotations(ctx, rt, annotations); err != nil {
+		return controller.Result{
+			Error:  err,
+			Reason: ReasonUpdateFailed,
+		}
+	}
+
 	return controller.Result{
 		Message: fmt.Sprintf("Updated resource template: %s", rt.Name),
 		Reason:  ReasonUpdated,
 	}
 }
 
+func (t *TriggerHandler) patchAnnotations(ctx context.Context, rt *v1beta1.ResourceTemplate, annotations map[string]string) error {
+	if len(annotations) == 0 {
+		return nil
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": annotations,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal annotations: %w", err)
+	}
+
+	if err := t.Client.Patch(ctx, rt, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		return fmt.Errorf("failed to patch resource template annotations: %w", err)
+	}
+
+	return nil
+}
+
 func (t *TriggerHandler) applyResource(ctx context.Context, rt *v1beta1.ResourceTemplate) controller.Result {
 	result := t.createResource(ctx, rt)
 	if result.Reason != ReasonAlreadyExists {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -30,6 +31,7 @@ var _ = Describe("TriggerHandler", func() {
 		err          error
 		options      *TriggerOptions
 		webhook      *v1beta1.HTTPWebhook
+		ctx          context.Context
 	)
 
 	loadTestData := func(name string) []client.Object {
@@ -83,6 +85,7 @@ var _ = Describe("TriggerHandler", func() {
 		Expect(mgr.Initialize()).To(Succeed())
 
 		namespaceMap = random.NewNamespaceMap()
+		ctx = context.TODO()
 
 		webhook = &v1beta1.HTTPWebhook{
 			ObjectMeta: metav1.ObjectMeta{
@@ -94,7 +97,7 @@ var _ = Describe("TriggerHandler", func() {
 	})
 
 	JustBeforeEach(func() {
-		err = handler.Handle(context.TODO(), options)
+		err = handler.Handle(ctx, options)
 	})
 
 	AfterEach(func() {
@@ -519,6 +522,37 @@ var _ = Describe("TriggerHandler", func() {
 		})
 	})
 
+	When("user is given", func() {
+		BeforeEach(func() {
+			options = &TriggerOptions{
+				Action: v1beta1.ActionCreate,
+				Source: webhook,
+				Triggers: []v1beta1.EventSourceTrigger{
+					{Name: "trigger-a"},
+				},
+				User: "system:serviceaccount:test:ci",
+			}
+		})
+
+		testSuccess("resource-not-exist")
+
+		It("should record Created event with the user", func() {
+			Expect(mgr.WaitForEvent(testenv.EventData{
+				Type:    corev1.EventTypeNormal,
+				Reason:  ReasonCreated,
+				Message: "Created resource template: trigger-a (user: system:serviceaccount:test:ci)",
+			})).To(BeTrue())
+		})
+
+		It("should record Triggered event with the user", func() {
+			Expect(mgr.WaitForEvent(testenv.EventData{
+				Type:    corev1.EventTypeNormal,
+				Reason:  ReasonTriggered,
+				Message: fmt.Sprintf("Triggered: create %s/trigger-a (user: system:serviceaccount:test:ci)", namespaceMap.GetRandom("test")),
+			})).To(BeTrue())
+		})
+	})
+
 	When("action is a template string", func() {
 		BeforeEach(func() {
 			options = &TriggerOptions{
@@ -572,4 +606,261 @@ var _ = Describe("TriggerHandler", func() {
 			}))
 		})
 	})
+
+	When("history is given", func() {
+		var history *fakeTriggerHistory
+
+		BeforeEach(func() {
+			history = &fakeTriggerHistory{}
+			handler.History = history
+			options = &TriggerOptions{
+				Action: v1beta1.ActionCreate,
+				Source: webhook,
+				Triggers: []v1beta1.EventSourceTrigger{
+					{Name: "trigger-a"},
+					{Name: "trigger-xyz"},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			Expect(history.options).To(Equal(options))
+			Expect(history.action).To(Equal(v1beta1.ActionCreate))
+		})
+
+		When("all triggers are rendered", func() {
+			BeforeEach(func() {
+				options.Triggers = options.Triggers[:1]
+			})
+
+			testSuccess("resource-not-exist")
+
+			It("should record outcomes", func() {
+				Expect(history.outcomes).To(HaveLen(1))
+				Expect(history.outcomes[0].TriggerRef.NamespacedName()).To(Equal(types.NamespacedName{
+					Namespace: namespaceMap.GetRandom("test"),
+					Name:      "trigger-a",
+				}))
+				Expect(history.outcomes[0].ResourceTemplate).To(Equal("trigger-a"))
+				Expect(history.outcomes[0].Reason).To(Equal(ReasonCreated))
+				Expect(history.outcomes[0].Failed).To(BeFalse())
+			})
+		})
+
+		When("trigger not found", func() {
+			var objects []client.Object
+
+			BeforeEach(func() {
+				objects = loadTestData("resource-not-exist")
+			})
+
+			AfterEach(func() {
+				Expect(testenv.DeleteObjects(objects)).To(Succeed())
+			})
+
+			It("should record the failed trigger only", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(history.outcomes).To(Equal([]TriggerOutcome{{
+					TriggerRef: v1beta1.ObjectReference{
+						APIVersion: v1beta1.GroupVersion.String(),
+						Kind:       "Trigger",
+						Namespace:  namespaceMap.GetRandom("test"),
+						Name:       "trigger-xyz",
+					},
+					Reason:  ReasonFailed,
+					Message: err.Error(),
+					Failed:  true,
+				}}))
+			})
+		})
+	})
+
+	When("headers are given", func() {
+		var history *fakeTriggerHistory
+
+		BeforeEach(func() {
+			history = &fakeTriggerHistory{}
+			handler.History = history
+			options = &TriggerOptions{
+				Action: `{{ if or .headers.Authorization (index .headers "Pullup-Webhook-Secret") }}delete{{ else }}{{ .headers.Action }}{{ end }}`,
+				Source: webhook,
+				Triggers: []v1beta1.EventSourceTrigger{
+					{Name: "trigger-a"},
+				},
+				Headers: map[string]string{
+					"Action":                "create",
+					"Authorization":         "Bearer abc",
+					"Pullup-Webhook-Secret": "abc",
+					"X-Api-Key":             "abc",
+					"Idempotency-Key":       "123",
+					"X-Event-Key":           "repo:push",
+				},
+			}
+		})
+
+		testSuccess("resource-not-exist")
+
+		It("should not render sensitive headers", func() {
+			Expect(history.action).To(Equal(v1beta1.ActionCreate))
+		})
+
+		It("should not record sensitive headers", func() {
+			Expect(history.options.Headers).To(Equal(map[string]string{
+				"Action":          "create",
+				"Idempotency-Key": "123",
+				"X-Event-Key":     "repo:push",
+			}))
+		})
+
+		It("should not modify options", func() {
+			Expect(options.Headers).To(HaveLen(6))
+		})
+	})
+
+	When("delivery headers are given", func() {
+		var history *fakeTriggerHistory
+
+		BeforeEach(func() {
+			history = &fakeTriggerHistory{}
+			handler.History = history
+			ctx = withDeliveryHeaders(ctx, http.Header{
+				"X-Github-Event":    []string{"push"},
+				"X-Github-Delivery": []string{"abc"},
+				"X-Foo":             []string{"bar"},
+				"Authorization":     []string{"Bearer abc"},
+			})
+			options = &TriggerOptions{
+				Action: `{{ if eq (index .headers "X-GitHub-Event") "push" }}create{{ end }}`,
+				Source: webhook,
+				Triggers: []v1beta1.EventSourceTrigger{
+					{Name: "trigger-a"},
+				},
+			}
+		})
+
+		testSuccess("resource-not-exist")
+
+		It("should render delivery headers", func() {
+			Expect(history.action).To(Equal(v1beta1.ActionCreate))
+		})
+
+		It("should record delivery headers only", func() {
+			Expect(history.options.Headers).To(Equal(map[string]string{
+				"X-GitHub-Event":    "push",
+				"X-GitHub-Delivery": "abc",
+			}))
+		})
+	})
+
+	When("delivery guard is given", func() {
+		var guard *fakeDeliveryGuard
+
+		BeforeEach(func() {
+			guard = &fakeDeliveryGuard{}
+			ctx = WithDeliveryGuard(ctx, guard)
+			options = &TriggerOptions{
+				Action: v1beta1.ActionCreate,
+				Source: webhook,
+				Triggers: []v1beta1.EventSourceTrigger{
+					{Name: "trigger-a"},
+				},
+			}
+		})
+
+		When("guard accepts the event", func() {
+			testSuccess("resource-not-exist")
+
+			It("should pass options to the guard", func() {
+				Expect(guard.options).To(Equal(options))
+			})
+
+			It("should handle triggers", func() {
+				Expect(getChanges()).NotTo(BeEmpty())
+			})
+
+			It("should tell the guard that triggers succeed", func() {
+				Expect(guard.done).To(BeTrue())
+				Expect(guard.doneErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("triggers fail", func() {
+			It("should tell the guard the error", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(guard.done).To(BeTrue())
+				Expect(guard.doneErr).To(Equal(err))
+			})
+		})
+
+		When("delivery has been handled", func() {
+			var objects []client.Object
+
+			BeforeEach(func() {
+				guard.err = ErrDeliveryHandled
+				objects = loadTestData("resource-not-exist")
+			})
+
+			AfterEach(func() {
+				Expect(testenv.DeleteObjects(objects)).To(Succeed())
+			})
+
+			It("should skip the event without errors", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(getChanges()).To(BeEmpty())
+				Expect(guard.done).To(BeFalse())
+			})
+		})
+
+		When("guard rejects the event", func() {
+			var objects []client.Object
+
+			BeforeEach(func() {
+				guard.err = errors.New("rejected")
+				objects = loadTestData("resource-not-exist")
+			})
+
+			AfterEach(func() {
+				Expect(testenv.DeleteObjects(objects)).To(Succeed())
+			})
+
+			It("should return the error", func() {
+				Expect(err).To(Equal(guard.err))
+			})
+
+			It("should not handle triggers", func() {
+				Expect(getChanges()).To(BeEmpty())
+				Expect(guard.done).To(BeFalse())
+			})
+		})
+	})
 })
+
+type fakeDeliveryGuard struct {
+	options *TriggerOptions
+	err     error
+	done    bool
+	doneErr error
+}
+
+func (f *fakeDeliveryGuard) Acquire(_ context.Context, options *TriggerOptions) error {
+	f.options = options
+
+	return f.err
+}
+
+func (f *fakeDeliveryGuard) Done(_ context.Context, _ *TriggerOptions, err error) {
+	f.done = true
+	f.doneErr = err
+}
+
+type fakeTriggerHistory struct {
+	options  *TriggerOptions
+	action   string
+	outcomes []TriggerOutcome
+}
+
+func (f *fakeTriggerHistory) Record(_ context.Context, options *TriggerOptions, action string, outcomes []TriggerOutcome) {
+	f.options = options
+	f.action = action
+	f.outcomes = outcomes
+}
@@ -1,13 +1,17 @@
 package http
 
 import (
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/google/wire"
+	"github.com/gorilla/mux"
 	"github.com/tommy351/pullup/internal/httputil"
 	"github.com/tommy351/pullup/internal/webhook/hookutil"
 	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
@@ -22,6 +26,16 @@ import (
 // +kubebuilder:rbac:groups=pullup.dev,resources=httpwebhooks,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 
+const actionHeader = "Pullup-Webhook-Action"
+
+// nolint: gochecknoglobals
+var errInvalidJSON = httputil.Response{
+	StatusCode: http.StatusBadRequest,
+	Errors: []httputil.Error{
+		{Description: "Invalid JSON"},
+	},
+}
+
 // HandlerSet provides a handler.
 // nolint: gochecknoglobals
 var HandlerSet = wire.NewSet(
@@ -35,14 +49,20 @@ type Body struct {
 	Data      extv1.JSON `json:"data"`
 }
 
+type webhookRequest struct {
+	Key     types.NamespacedName
+	Action  string
+	Data    extv1.JSON
+	Payload []byte
+	Headers map[string]string
+}
+
 type Handler struct {
 	Client         client.Client
 	TriggerHandler hookutil.TriggerHandler
 }
 
-func (h *Handler) parseBody(r *http.Request) (*Body, error) {
-	logger := logr.FromContextOrDiscard(r.Context())
-
+func (h *Handler) readBody(r *http.Request) ([]byte, error) {
 	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
 		return nil, httputil.Response{
 			StatusCode: http.StatusBadRequest,
@@ -52,51 +72,73 @@ func (h *Handler) parseBody(r *http.Request) (*Body, error) {
 		}
 	}
 
+	payload, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body: %w", err)
+	}
+
+	return payload, nil
+}
+
+func (h *Handler) parseBody(r *http.Request, payload []byte) (*Body, error) {
+	logger := logr.FromContextOrDiscard(r.Context())
+
 	var body Body
 
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+	if err := json.Unmarshal(payload, &body); err != nil {
 		logger.Error(err, "invalid json")
 
+		return nil, errInvalidJSON
+	}
+
+	if err := validateKey(body.Namespace, body.Name); err != nil {
+		return nil, err
+	}
+
+	if !v1beta1.IsActionValid(body.Action) {
 		return nil, httputil.Response{
 			StatusCode: http.StatusBadRequest,
 			Errors: []httputil.Error{
-				{Description: "Invalid JSON"},
+				{Description: "Invalid action", Field: "action"},
 			},
 		}
 	}
 
-	if e := validation.ValidateNamespaceName(body.Namespace, false); len(e) > 0 {
-		return nil, httputil.Response{
+	return &body, nil
+}
+
+func validateKey(namespace, name string) error {
+	if e := validation.ValidateNamespaceName(namespace, false); len(e) > 0 {
+		return httputil.Response{
 			StatusCode: http.StatusBadRequest,
 			Errors:     httputil.NewValidationErrors("namespace", e),
 		}
 	}
 
-	if e := validation.NameIsDNSSubdomain(body.Name, false); len(e) > 0 {
-		return nil, httputil.Response{
+	if e := validation.NameIsDNSSubdomain(name, false); len(e) > 0 {
+		return httputil.Response{
 			StatusCode: http.StatusBadRequest,
 			Errors:     httputil.NewValidationErrors("name", e),
 		}
 	}
 
-	if !v1beta1.IsActionValid(body.Action) {
-		return nil, httputil.Response{
-			StatusCode: http.StatusBadRequest,
-			Errors: []httputil.Error{
-				{Description: "Invalid action", Field: "action"},
-			},
-		}
-	}
-
-	return &body, nil
+	return nil
 }
 
-func (h *Handler) validateSecretToken(r *http.Request, hook *v1beta1.HTTPWebhook) error {
+func (h *Handler) validateSecretToken(r *http.Request, hook *v1beta1.HTTPWebhook, payload []byte) error {
 	if hook.Spec.SecretToken == nil || hook.Spec.SecretToken.SecretKeyRef == nil {
+		if hook.Spec.Signature != nil {
+			return httputil.Response{
+				StatusCode: http.StatusForbidden,
+				Errors: []httputil.Error{
+					{Description: "Secret token is not set"},
+				},
+			}
+		}
+
 		return nil
 	}
 
-	header := r.Header.Get("Pullup-Webhook-Secret")
 	ref := hook.Spec.SecretToken.SecretKeyRef
 	secret := new(corev1.Secret)
 	secretName := types.NamespacedName{
@@ -127,7 +169,13 @@ func (h *Handler) validateSecretToken(r *http.Request, hook *v1beta1.HTTPWebhook
 		}
 	}
 
-	if header != string(value) {
+	if sig := hook.Spec.Signature; sig != nil {
+		return validateSignature(r, sig, payload, value, time.Now())
+	}
+
+	header := r.Header.Get("Pullup-Webhook-Secret")
+
+	if subtle.ConstantTimeCompare([]byte(header), value) != 1 {
 		return httputil.Response{
 			StatusCode: http.StatusForbidden,
 			Errors: []httputil.Error{
@@ -139,18 +187,88 @@ func (h *Handler) validateSecretToken(r *http.Request, hook *v1beta1.HTTPWebhook
 	return nil
 }
 
+// Handle handles requests which contain the namespace, the name and the action
+// of the webhook in the body.
 func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) error {
-	body, err := h.parseBody(r)
+	payload, err := h.readBody(r)
 	if err != nil {
 		return err
 	}
 
-	hook := new(v1beta1.HTTPWebhook)
-	err = h.Client.Get(r.Context(), types.NamespacedName{
-		Namespace: body.Namespace,
-		Name:      body.Name,
-	}, hook)
+	body, err := h.parseBody(r, payload)
+	if err != nil {
+		return err
+	}
+
+	return h.handleWebhook(w, r, &webhookRequest{
+		Key: types.NamespacedName{
+			Namespace: body.Namespace,
+			Name:      body.Name,
+		},
+		Action:  body.Action,
+		Data:    body.Data,
+		Payload: payload,
+	})
+}
+
+// HandleRoute handles requests sent to "/webhooks/http/{namespace}/{name}".
+// The whole body is the event data, and the default action is read from the
+// Pullup-Webhook-Action header, so the action can be rendered from headers or
+// the body in the action template of the webhook. Headers which may contain
+// credentials are dropped by TriggerHandler before the template is rendered.
+func (h *Handler) HandleRoute(w http.ResponseWriter, r *http.Request) error {
+	vars := mux.Vars(r)
+	key := types.NamespacedName{
+		Namespace: vars["namespace"],
+		Name:      vars["name"],
+	}
+
+	if err := validateKey(key.Namespace, key.Name); err != nil {
+		return err
+	}
+
+	payload, err := h.readBody(r)
 	if err != nil {
+		return err
+	}
+
+	if !json.Valid(payload) {
+		return errInvalidJSON
+	}
+
+	action := r.Header.Get(actionHeader)
+	if action == "" {
+		action = v1beta1.ActionApply
+	}
+
+	if !v1beta1.IsActionValid(action) {
+		return httputil.Response{
+			StatusCode: http.StatusBadRequest,
+			Errors: []httputil.Error{
+				{Description: "Invalid action", Field: actionHeader},
+			},
+		}
+	}
+
+	headers := make(map[string]string, len(r.Header))
+
+	for k := range r.Header {
+		headers[k] = r.Header.Get(k)
+	}
+
+	return h.handleWebhook(w, r, &webhookRequest{
+		Key:     key,
+		Action:  action,
+		Data:    extv1.JSON{Raw: payload},
+		Payload: payload,
+		Headers: headers,
+	})
+}
+
+func (h *Handler) handleWebhook(w http.ResponseWriter, r *http.Request, req *webhookRequest) error {
+	hook := new(v1beta1.HTTPWebhook)
+
+	if err := h.Client.Get(r.Context(), req.Key, hook); err != nil {
 		if kerrors.IsNotFound(err) {
 			return httputil.Response{
 				StatusCode: http.StatusBadRequest,
@@ -163,11 +281,22 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) error {
 		return fmt.Errorf("failed to get HTTPWebhook: %w", err)
 	}
 
-	if err := h.validateSecretToken(r, hook); err != nil {
+	if err := h.validateSecretToken(r, hook, req.Payload); err != nil {
 		return err
 	}
 
-	data, err := hookutil.ValidateJSONSchema(hook.Spec.Schema, &body.Data)
+	var (
+		user string
+		err  error
+	)
+
+	if hook.Spec.ServiceAccountToken != nil {
+		if user, err = h.authenticateServiceAccount(r, hook); err != nil {
+			return err
+		}
+	}
+
+	data, err := hookutil.ValidateJSONSchema(hook.Spec.Schema, &req.Data)
 	if err != nil {
 		return fmt.Errorf("validate failed: %w", err)
 	}
@@ -175,9 +304,11 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) error {
 	options := &hookutil.TriggerOptions{
 		Source:        hook,
 		Triggers:      hook.Spec.Triggers,
-		DefaultAction: body.Action,
+		DefaultAction: req.Action,
 		Action:        hook.Spec.Action,
 		Event:         data,
+		Headers:       req.Headers,
+		User:          user,
 	}
 
 	if err := h.TriggerHandler.Handle(r.Context(), options); err != nil {
@@ -3,12 +3,21 @@ package http
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"hash"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/tommy351/pullup/internal/golden"
@@ -19,6 +28,7 @@ import (
 	"github.com/tommy351/pullup/internal/testutil"
 	"github.com/tommy351/pullup/internal/webhook/hookutil"
 	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	authv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
 	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -30,6 +40,7 @@ import (
 var _ = Describe("Handler", func() {
 	var (
 		handler      *Handler
+		handle       func(w http.ResponseWriter, r *http.Request) error
 		req          *http.Request
 		recorder     *httptest.ResponseRecorder
 		mgr          *testenv.Manager
@@ -47,6 +58,17 @@ var _ = Describe("Handler", func() {
 		return req.WithContext(ctx)
 	}
 
+	signRequest := func(req *http.Request, mac hash.Hash, prefix string) {
+		body, err := ioutil.ReadAll(req.Body)
+		Expect(err).NotTo(HaveOccurred())
+
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		_, err = mac.Write([]byte(prefix))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = mac.Write(body)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
 	loadTestData := func(name string) []client.Object {
 		data, err := k8s.LoadObjects(testenv.GetScheme(), fmt.Sprintf("testdata/%s.yml", name))
 		Expect(err).NotTo(HaveOccurred())
@@ -95,6 +117,7 @@ var _ = Describe("Handler", func() {
 		Expect(err).NotTo(HaveOccurred())
 
 		handler = NewHandler(mgr)
+		handle = handler.Handle
 
 		Expect(mgr.Initialize()).To(Succeed())
 
@@ -103,7 +126,7 @@ var _ = Describe("Handler", func() {
 
 	JustBeforeEach(func() {
 		recorder = httptest.NewRecorder()
-		hookutil.NewHandler(handler.Handle).ServeHTTP(recorder, req)
+		hookutil.NewHandler(handle).ServeHTTP(recorder, req)
 	})
 
 	AfterEach(func() {
@@ -545,6 +568,316 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	When("signature is given", func() {
+		var data []client.Object
+
+		secret := []byte("some-thing-very-secret")
+
+		BeforeEach(func() {
+			req = newRequest(&Body{
+				Namespace: namespaceMap.GetRandom("test"),
+				Name:      "foobar",
+				Action:    v1beta1.ActionApply,
+			})
+		})
+
+		AfterEach(func() {
+			Expect(testenv.DeleteObjects(data)).To(Succeed())
+		})
+
+		testForbidden := func(description string) {
+			It("should respond 403", func() {
+				Expect(recorder.Code).To(Equal(http.StatusForbidden))
+			})
+
+			It("should respond errors", func() {
+				Expect(recorder.Body.Bytes()).To(MatchJSON(testutil.MustMarshalJSON(&httputil.Response{
+					Errors: []httputil.Error{
+						{Description: description},
+					},
+				})))
+			})
+		}
+
+		When("timestamp is not required", func() {
+			BeforeEach(func() {
+				data = loadTestData("signature")
+			})
+
+			When("signature is valid", func() {
+				BeforeEach(func() {
+					mac := hmac.New(sha512.New, secret)
+					signRequest(req, mac, "")
+					req.Header.Set("Pullup-Webhook-Signature", hex.EncodeToString(mac.Sum(nil)))
+				})
+
+				It("should respond 200", func() {
+					Expect(recorder.Code).To(Equal(http.StatusOK))
+				})
+			})
+
+			When("signature is prefixed with the algorithm", func() {
+				BeforeEach(func() {
+					mac := hmac.New(sha512.New, secret)
+					signRequest(req, mac, "")
+					req.Header.Set("Pullup-Webhook-Signature", "sha512="+hex.EncodeToString(mac.Sum(nil)))
+				})
+
+				It("should respond 200", func() {
+					Expect(recorder.Code).To(Equal(http.StatusOK))
+				})
+			})
+
+			When("signature is signed with another algorithm", func() {
+				BeforeEach(func() {
+					mac := hmac.New(sha256.New, secret)
+					signRequest(req, mac, "")
+					req.Header.Set("Pullup-Webhook-Signature", hex.EncodeToString(mac.Sum(nil)))
+				})
+
+				testForbidden("Signature mismatch")
+			})
+
+			When("signature is signed with another secret", func() {
+				BeforeEach(func() {
+					mac := hmac.New(sha512.New, []byte("foobar"))
+					signRequest(req, mac, "")
+					req.Header.Set("Pullup-Webhook-Signature", hex.EncodeToString(mac.Sum(nil)))
+				})
+
+				testForbidden("Signature mismatch")
+			})
+
+			When("secret token is given in the header", func() {
+				BeforeEach(func() {
+					req.Header.Set("Pullup-Webhook-Secret", string(secret))
+				})
+
+				testForbidden("Signature mismatch")
+			})
+		})
+
+		When("timestamp is required", func() {
+			BeforeEach(func() {
+				data = loadTestData("signature-timestamp")
+			})
+
+			sign := func(timestamp time.Time) {
+				ts := strconv.FormatInt(timestamp.Unix(), 10)
+				mac := hmac.New(sha256.New, secret)
+				signRequest(req, mac, ts+".")
+				req.Header.Set("X-Signature", hex.EncodeToString(mac.Sum(nil)))
+				req.Header.Set("X-Timestamp", ts)
+			}
+
+			When("signature is valid", func() {
+				BeforeEach(func() {
+					sign(time.Now())
+				})
+
+				It("should respond 200", func() {
+					Expect(recorder.Code).To(Equal(http.StatusOK))
+				})
+			})
+
+			When("timestamp is expired", func() {
+				BeforeEach(func() {
+					sign(time.Now().Add(-time.Hour))
+				})
+
+				testForbidden("Timestamp is out of tolerance")
+			})
+
+			When("timestamp is in the future", func() {
+				BeforeEach(func() {
+					sign(time.Now().Add(time.Hour))
+				})
+
+				testForbidden("Timestamp is out of tolerance")
+			})
+
+			When("timestamp is missing", func() {
+				BeforeEach(func() {
+					sign(time.Now())
+					req.Header.Del("X-Timestamp")
+				})
+
+				testForbidden("Invalid timestamp")
+			})
+
+			When("timestamp is modified", func() {
+				BeforeEach(func() {
+					sign(time.Now())
+					req.Header.Set("X-Timestamp", strconv.FormatInt(time.Now().Unix()-1, 10))
+				})
+
+				testForbidden("Signature mismatch")
+			})
+		})
+	})
+
+	When("serviceAccountToken is given", func() {
+		var data []client.Object
+
+		const (
+			userCI       = "system:serviceaccount:%s:ci"
+			userDeployer = "system:serviceaccount:other:deployer"
+			userGroup    = "system:serviceaccount:ci:builder"
+			userOther    = "system:serviceaccount:%s:other"
+		)
+
+		BeforeEach(func() {
+			ns := namespaceMap.GetRandom("test")
+			handler.Client = &tokenReviewClient{
+				Client: handler.Client,
+				tokens: map[string]tokenReviewResult{
+					"ci-token":       {Username: fmt.Sprintf(userCI, ns), Audiences: []string{"pullup"}},
+					"deployer-token": {Username: userDeployer, Audiences: []string{"pullup"}},
+					"group-token":    {Username: userGroup, Groups: []string{"system:serviceaccounts:ci"}, Audiences: []string{"pullup"}},
+					"other-token":    {Username: fmt.Sprintf(userOther, ns), Audiences: []string{"pullup"}},
+					"api-token":      {Username: fmt.Sprintf(userCI, ns), Audiences: []string{"https://kubernetes.default.svc"}},
+					"foreign-token":  {Username: userDeployer},
+				},
+			}
+
+			req = newRequest(&Body{
+				Namespace: ns,
+				Name:      "foobar",
+				Action:    v1beta1.ActionApply,
+			})
+		})
+
+		AfterEach(func() {
+			Expect(testenv.DeleteObjects(data)).To(Succeed())
+		})
+
+		testStatus := func(status int, description string) {
+			It(fmt.Sprintf("should respond %d", status), func() {
+				Expect(recorder.Code).To(Equal(status))
+			})
+
+			It("should respond errors", func() {
+				Expect(recorder.Body.Bytes()).To(MatchJSON(testutil.MustMarshalJSON(&httputil.Response{
+					Errors: []httputil.Error{
+						{Description: description},
+					},
+				})))
+			})
+		}
+
+		testUser := func(getUser func() string) {
+			It("should respond 200", func() {
+				Expect(recorder.Code).To(Equal(http.StatusOK))
+			})
+
+			It("should record Triggered event with the user", func() {
+				Expect(mgr.WaitForEvent(testenv.EventData{
+					Type:    corev1.EventTypeNormal,
+					Reason:  hookutil.ReasonTriggered,
+					Message: fmt.Sprintf("Triggered: apply %s/foobar (user: %s)", namespaceMap.GetRandom("test"), getUser()),
+				})).To(BeTrue())
+			})
+
+			It("should record Created event with the user", func() {
+				Expect(mgr.WaitForEvent(testenv.EventData{
+					Type:    corev1.EventTypeNormal,
+					Reason:  hookutil.ReasonCreated,
+					Message: fmt.Sprintf("Created resource template: foobar-rt (user: %s)", getUser()),
+				})).To(BeTrue())
+			})
+		}
+
+		When("allowlist is given", func() {
+			BeforeEach(func() {
+				data = loadTestData("service-account")
+			})
+
+			When("Authorization header is not given", func() {
+				testStatus(http.StatusUnauthorized, "Bearer token is required")
+			})
+
+			When("token is invalid", func() {
+				BeforeEach(func() {
+					req.Header.Set("Authorization", "Bearer foobar")
+				})
+
+				testStatus(http.StatusUnauthorized, "Invalid bearer token")
+			})
+
+			When("token is issued for other audiences", func() {
+				BeforeEach(func() {
+					req.Header.Set("Authorization", "Bearer api-token")
+				})
+
+				testStatus(http.StatusUnauthorized, "Invalid bearer token")
+			})
+
+			When("service account in the same namespace is allowed", func() {
+				BeforeEach(func() {
+					req.Header.Set("Authorization", "Bearer ci-token")
+				})
+
+				testUser(func() string {
+					return fmt.Sprintf(userCI, namespaceMap.GetRandom("test"))
+				})
+			})
+
+			When("service account in other namespace is allowed", func() {
+				BeforeEach(func() {
+					req.Header.Set("Authorization", "Bearer deployer-token")
+				})
+
+				testUser(func() string {
+					return userDeployer
+				})
+			})
+
+			When("group is allowed", func() {
+				BeforeEach(func() {
+					req.Header.Set("Authorization", "Bearer group-token")
+				})
+
+				testUser(func() string {
+					return userGroup
+				})
+			})
+
+			When("service account is not allowed", func() {
+				BeforeEach(func() {
+					req.Header.Set("Authorization", "Bearer other-token")
+				})
+
+				It("should respond 403", func() {
+					Expect(recorder.Code).To(Equal(http.StatusForbidden))
+				})
+			})
+		})
+
+		When("allowlist is empty", func() {
+			BeforeEach(func() {
+				data = loadTestData("service-account-default")
+			})
+
+			When("service account is in the same namespace", func() {
+				BeforeEach(func() {
+					req.Header.Set("Authorization", "Bearer other-token")
+				})
+
+				testUser(func() string {
+					return fmt.Sprintf(userOther, namespaceMap.GetRandom("test"))
+				})
+			})
+
+			When("service account is in other namespace", func() {
+				BeforeEach(func() {
+					req.Header.Set("Authorization", "Bearer foreign-token")
+				})
+
+				testStatus(http.StatusForbidden, fmt.Sprintf("User %q is not allowed", userDeployer))
+			})
+		})
+	})
+
 	When("action is given", func() {
 		BeforeEach(func() {
 			req = newRequest(&Body{
@@ -581,4 +914,248 @@ var _ = Describe("Handler", func() {
 			Expect(getChanges()).To(BeEmpty())
 		})
 	})
+
+	Describe("HandleRoute", func() {
+		newRouteRequest := func(namespace, name string, body interface{}) *http.Request {
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(testutil.MustMarshalJSON(body)))
+			req.Header.Set("Content-Type", "application/json")
+			req = mux.SetURLVars(req, map[string]string{
+				"namespace": namespace,
+				"name":      name,
+			})
+
+			return req.WithContext(logr.NewContext(req.Context(), log.Log))
+		}
+
+		BeforeEach(func() {
+			handle = handler.HandleRoute
+		})
+
+		When("namespace is invalid", func() {
+			BeforeEach(func() {
+				req = newRouteRequest("A_B", "foobar", map[string]interface{}{})
+			})
+
+			It("should respond 400", func() {
+				Expect(recorder).To(HaveHTTPStatus(http.StatusBadRequest))
+			})
+		})
+
+		When("body is not a valid JSON", func() {
+			BeforeEach(func() {
+				req = newRouteRequest("a", "b", nil)
+				req.Body = ioutil.NopCloser(bytes.NewReader([]byte("{")))
+			})
+
+			It("should respond 400", func() {
+				Expect(recorder).To(HaveHTTPStatus(http.StatusBadRequest))
+			})
+
+			It("should respond errors", func() {
+				Expect(recorder.Body.Bytes()).To(MatchJSON(testutil.MustMarshalJSON(&httputil.Response{
+					Errors: []httputil.Error{
+						{Description: "Invalid JSON"},
+					},
+				})))
+			})
+		})
+
+		When("no matching webhooks", func() {
+			BeforeEach(func() {
+				req = newRouteRequest("a", "b", map[string]interface{}{})
+			})
+
+			It("should respond 400", func() {
+				Expect(recorder).To(HaveHTTPStatus(http.StatusBadRequest))
+			})
+
+			It("should respond errors", func() {
+				Expect(recorder.Body.Bytes()).To(MatchJSON(testutil.MustMarshalJSON(&httputil.Response{
+					Errors: []httputil.Error{
+						{Description: "HTTPWebhook not found"},
+					},
+				})))
+			})
+		})
+
+		When("action is not given", func() {
+			BeforeEach(func() {
+				req = newRouteRequest(namespaceMap.GetRandom("test"), "foobar", map[string]interface{}{
+					"project": "foo",
+				})
+			})
+
+			testSuccess("resource-template-not-exist")
+
+			It("should apply the resource template", func() {
+				Expect(mgr.WaitForEvent(testenv.EventData{
+					Type:    corev1.EventTypeNormal,
+					Reason:  hookutil.ReasonCreated,
+					Message: "Created resource template: foobar-rt",
+				})).To(BeTrue())
+			})
+
+			It("should set the body as the event data", func() {
+				rt := new(v1beta1.ResourceTemplate)
+				Expect(handler.Client.Get(context.Background(), types.NamespacedName{
+					Namespace: namespaceMap.GetRandom("test"),
+					Name:      "foobar-rt",
+				}, rt)).To(Succeed())
+				Expect(rt.Spec.Data.Raw).To(MatchJSON(`{"event":{"project":"foo"}}`))
+			})
+		})
+
+		When("action is given in the header", func() {
+			BeforeEach(func() {
+				req = newRouteRequest(namespaceMap.GetRandom("test"), "foobar", map[string]interface{}{})
+				req.Header.Set("Pullup-Webhook-Action", v1beta1.ActionUpdate)
+			})
+
+			testSuccess("resource-template-not-exist")
+
+			It("should not have any changes", func() {
+				Expect(getChanges()).To(BeEmpty())
+			})
+		})
+
+		When("action in the header is invalid", func() {
+			BeforeEach(func() {
+				req = newRouteRequest(namespaceMap.GetRandom("test"), "foobar", map[string]interface{}{})
+				req.Header.Set("Pullup-Webhook-Action", "foo")
+			})
+
+			It("should respond 400", func() {
+				Expect(recorder).To(HaveHTTPStatus(http.StatusBadRequest))
+			})
+		})
+
+		When("action is a template string", func() {
+			var data []client.Object
+
+			BeforeEach(func() {
+				data = loadTestData("route-action-template")
+				req = newRouteRequest(namespaceMap.GetRandom("test"), "foobar", map[string]interface{}{
+					"status": "create",
+				})
+			})
+
+			AfterEach(func() {
+				Expect(testenv.DeleteObjects(data)).To(Succeed())
+			})
+
+			When("action is rendered from the body", func() {
+				It("should respond 200", func() {
+					Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+				})
+
+				It("should record Created event", func() {
+					Expect(mgr.WaitForEvent(testenv.EventData{
+						Type:    corev1.EventTypeNormal,
+						Reason:  hookutil.ReasonCreated,
+						Message: "Created resource template: foobar-rt",
+					})).To(BeTrue())
+				})
+			})
+
+			When("action is rendered from headers", func() {
+				BeforeEach(func() {
+					req.Header.Set("X-Event-Action", v1beta1.ActionDelete)
+				})
+
+				It("should respond 200", func() {
+					Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+				})
+
+				It("should record NotExist event", func() {
+					Expect(mgr.WaitForEvent(testenv.EventData{
+						Type:    corev1.EventTypeNormal,
+						Reason:  hookutil.ReasonNotExist,
+						Message: "Resource template does not exist: foobar-rt",
+					})).To(BeTrue())
+				})
+			})
+		})
+
+		When("action template uses sensitive headers", func() {
+			var data []client.Object
+
+			BeforeEach(func() {
+				data = loadTestData("route-sensitive-headers")
+				req = newRouteRequest(namespaceMap.GetRandom("test"), "foobar", map[string]interface{}{})
+				req.Header.Set("Authorization", "Bearer abc")
+				req.Header.Set("Pullup-Webhook-Secret", "abc")
+			})
+
+			AfterEach(func() {
+				Expect(testenv.DeleteObjects(data)).To(Succeed())
+			})
+
+			It("should respond 200", func() {
+				Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+			})
+
+			It("should not render sensitive headers", func() {
+				Expect(mgr.WaitForEvent(testenv.EventData{
+					Type:    corev1.EventTypeNormal,
+					Reason:  hookutil.ReasonCreated,
+					Message: "Created resource template: foobar-rt",
+				})).To(BeTrue())
+			})
+		})
+	})
 })
+
+type tokenReviewResult struct {
+	Username  string
+	Groups    []string
+	Audiences []string
+}
+
+// tokenReviewClient reviews tokens with the given results, because service
+// account tokens are not issued in the test environment.
+type tokenReviewClient struct {
+	client.Client
+
+	tokens map[string]tokenReviewResult
+}
+
+func (c *tokenReviewClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	review, ok := obj.(*authv1.TokenReview)
+	if !ok {
+		return c.Client.Create(ctx, obj, opts...)
+	}
+
+	result, ok := c.tokens[review.Spec.Token]
+	if !ok {
+		return nil
+	}
+
+	audiences := result.Audiences
+
+	if len(review.Spec.Audiences) > 0 {
+		audiences = nil
+
+		for _, a := range result.Audiences {
+			for _, b := range review.Spec.Audiences {
+				if a == b {
+					audiences = append(audiences, a)
+				}
+			}
+		}
+
+		if len(audiences) == 0 {
+			return nil
+		}
+	}
+
+	review.Status = authv1.TokenReviewStatus{
+		Authenticated: true,
+		Audiences:     audiences,
+		User: authv1.UserInfo{
+			Username: result.Username,
+			Groups:   result.Groups,
+		},
+	}
+
+	return nil
+}
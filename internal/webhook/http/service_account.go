@@ -0,0 +1,137 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/tommy351/pullup/internal/httputil"
+	"github.com/tommy351/pullup/internal/log"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	authv1 "k8s.io/api/authentication/v1"
+)
+
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+
+const (
+	bearerPrefix                 = "Bearer "
+	serviceAccountUsernamePrefix = "system:serviceaccount:"
+)
+
+// authenticateServiceAccount validates the bearer token in the Authorization
+// header with a TokenReview, and returns the username of the service account
+// if it is allowed to call the webhook.
+func (h *Handler) authenticateServiceAccount(r *http.Request, hook *v1beta1.HTTPWebhook) (string, error) {
+	ctx := r.Context()
+	conf := hook.Spec.ServiceAccountToken
+	logger := logr.FromContextOrDiscard(ctx)
+	header := r.Header.Get("Authorization")
+
+	if !strings.HasPrefix(header, bearerPrefix) {
+		return "", httputil.Response{
+			StatusCode: http.StatusUnauthorized,
+			Errors: []httputil.Error{
+				{Description: "Bearer token is required"},
+			},
+		}
+	}
+
+	review := &authv1.TokenReview{
+		Spec: authv1.TokenReviewSpec{
+			Token:     strings.TrimPrefix(header, bearerPrefix),
+			Audiences: conf.Audiences,
+		},
+	}
+
+	if err := h.Client.Create(ctx, review); err != nil {
+		return "", fmt.Errorf("failed to create token review: %w", err)
+	}
+
+	if !review.Status.Authenticated || !isAudienceAllowed(conf.Audiences, review.Status.Audiences) {
+		logger.V(log.Debug).Info("Token is not authenticated", "error", review.Status.Error)
+
+		return "", httputil.Response{
+			StatusCode: http.StatusUnauthorized,
+			Errors: []httputil.Error{
+				{Description: "Invalid bearer token"},
+			},
+		}
+	}
+
+	user := review.Status.User
+
+	if !isServiceAccountAllowed(conf, hook.Namespace, &user) {
+		return "", httputil.Response{
+			StatusCode: http.StatusForbidden,
+			Errors: []httputil.Error{
+				{Description: fmt.Sprintf("User %q is not allowed", user.Username)},
+			},
+		}
+	}
+
+	return user.Username, nil
+}
+
+// isAudienceAllowed returns true if the token is issued for any of the
+// expected audiences.
+func isAudienceAllowed(expected, actual []string) bool {
+	if len(expected) == 0 {
+		return true
+	}
+
+	for _, a := range actual {
+		for _, e := range expected {
+			if a == e {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func isServiceAccountAllowed(conf *v1beta1.HTTPWebhookServiceAccountToken, namespace string, user *authv1.UserInfo) bool {
+	saNamespace, saName := splitServiceAccountUsername(user.Username)
+
+	if len(conf.ServiceAccounts) == 0 && len(conf.Groups) == 0 {
+		return saName != "" && saNamespace == namespace
+	}
+
+	for _, ref := range conf.ServiceAccounts {
+		refNamespace := ref.Namespace
+		if refNamespace == "" {
+			refNamespace = namespace
+		}
+
+		if saName != "" && ref.Name == saName && refNamespace == saNamespace {
+			return true
+		}
+	}
+
+	for _, group := range user.Groups {
+		for _, g := range conf.Groups {
+			if group == g {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// splitServiceAccountUsername returns the namespace and the name of a service
+// account username, e.g. "system:serviceaccount:default:ci". It returns empty
+// strings when the user is not a service account.
+func splitServiceAccountUsername(username string) (string, string) {
+	if !strings.HasPrefix(username, serviceAccountUsernamePrefix) {
+		return "", ""
+	}
+
+	parts := strings.Split(strings.TrimPrefix(username, serviceAccountUsernamePrefix), ":")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", ""
+	}
+
+	return parts[0], parts[1]
+}
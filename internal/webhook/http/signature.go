@@ -0,0 +1,76 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tommy351/pullup/internal/httputil"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+)
+
+const (
+	defaultSignatureAlgorithm       = "sha256"
+	defaultSignatureHeader          = "Pullup-Webhook-Signature"
+	defaultSignatureTimestampHeader = "Pullup-Webhook-Timestamp"
+)
+
+// validateSignature validates the HMAC signature of the payload. The signature
+// can be either a hex string or prefixed with the algorithm, e.g.
+// "sha256=5257a869...". When the tolerance is set, the signed content is the
+// timestamp and the payload joined with a dot.
+func validateSignature(r *http.Request, conf *v1beta1.HTTPWebhookSignature, payload, secret []byte, now time.Time) error {
+	algorithm := conf.Algorithm
+	if algorithm == "" {
+		algorithm = defaultSignatureAlgorithm
+	}
+
+	header := conf.Header
+	if header == "" {
+		header = defaultSignatureHeader
+	}
+
+	if tolerance := conf.Tolerance; tolerance != nil {
+		timestampHeader := conf.TimestampHeader
+		if timestampHeader == "" {
+			timestampHeader = defaultSignatureTimestampHeader
+		}
+
+		timestamp := r.Header.Get(timestampHeader)
+		sec, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			return httputil.Response{
+				StatusCode: http.StatusForbidden,
+				Errors: []httputil.Error{
+					{Description: "Invalid timestamp"},
+				},
+			}
+		}
+
+		if diff := now.Sub(time.Unix(sec, 0)); diff > tolerance.Duration || diff < -tolerance.Duration {
+			return httputil.Response{
+				StatusCode: http.StatusForbidden,
+				Errors: []httputil.Error{
+					{Description: "Timestamp is out of tolerance"},
+				},
+			}
+		}
+
+		payload = append([]byte(timestamp+"."), payload...)
+	}
+
+	signature := strings.TrimPrefix(r.Header.Get(header), algorithm+"=")
+
+	if !hookutil.ValidateSignature(algorithm+"="+signature, payload, secret) {
+		return httputil.Response{
+			StatusCode: http.StatusForbidden,
+			Errors: []httputil.Error{
+				{Description: "Signature mismatch"},
+			},
+		}
+	}
+
+	return nil
+}
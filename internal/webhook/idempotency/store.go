@@ -0,0 +1,466 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/wire"
+	"github.com/tommy351/pullup/internal/httputil"
+	"github.com/tommy351/pullup/internal/k8s"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:rbac:groups=pullup.dev,resources=webhookreceipts,verbs=get;list;watch;create;update;delete
+
+const (
+	GitHubDeliveryHeader = "X-GitHub-Delivery"
+	IdempotencyKeyHeader = "Idempotency-Key"
+	ReplayedHeader       = "Idempotent-Replayed"
+
+	// A receipt of a delivery in progress expires after this timeout, so the
+	// delivery can be retried if the webhook server crashed while handling it.
+	inProgressTimeout = 5 * time.Minute
+
+	cleanupInterval = 10 * time.Minute
+)
+
+// StoreConfigSet provides a StoreConfig.
+// nolint: gochecknoglobals
+var StoreConfigSet = wire.NewSet(
+	wire.Struct(new(StoreConfig), "*"),
+)
+
+// StoreSet provides a Store.
+// nolint: gochecknoglobals
+var StoreSet = wire.NewSet(
+	StoreConfigSet,
+	NewStore,
+)
+
+type Config struct {
+	// Window is how long delivery IDs are recorded. Idempotency is disabled
+	// when it is zero.
+	Window time.Duration `mapstructure:"window"`
+}
+
+type StoreConfig struct {
+	Config           Config
+	KubernetesConfig k8s.Config
+	Client           client.Client
+	Reader           client.Reader
+	Logger           logr.Logger
+}
+
+// Store records responses of webhook deliveries as WebhookReceipt objects, and
+// replays them when deliveries with the same ID are received again.
+type Store struct {
+	StoreConfig
+
+	now func() time.Time
+}
+
+func NewStore(conf StoreConfig) *Store {
+	return &Store{
+		StoreConfig: conf,
+		now:         time.Now,
+	}
+}
+
+func (s *Store) Enabled() bool {
+	return s.Config.Window > 0
+}
+
+func (s *Store) namespace() string {
+	return s.KubernetesConfig.Namespace
+}
+
+// Wrap returns a handler which short-circuits requests whose delivery ID in the
+// given header has been handled successfully, and responds the original
+// response instead. Failed responses are not recorded, so the delivery can be
+// retried.
+//
+// Receipts are only recorded when the handler passes an event to
+// TriggerHandler, which happens after the sender is authenticated, and are
+// keyed by the event source as well as the delivery ID. A receipt is completed
+// as soon as triggers of its source succeed, so the source is skipped when a
+// delivery which fails on other sources is retried.
+func (s *Store) Wrap(header string, handler httputil.Handler) httputil.Handler {
+	if !s.Enabled() || header == "" {
+		return handler
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) error {
+		key := r.Header.Get(header)
+
+		if key == "" {
+			return handler(w, r)
+		}
+
+		ctx := r.Context()
+		d := &delivery{
+			store: s,
+			path:  r.URL.Path,
+			key:   key,
+		}
+		completed := false
+
+		defer func() {
+			if !completed {
+				d.releaseInProgress()
+			}
+		}()
+
+		buf := httputil.NewResponseBuffer()
+		err := handler(buf, r.WithContext(hookutil.WithDeliveryGuard(ctx, d)))
+
+		var replayErr *replayError
+
+		if errors.As(err, &replayErr) {
+			return replay(w, replayErr.receipt)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if buf.StatusCode >= 200 && buf.StatusCode < 300 {
+			if err := d.complete(ctx, buf); err != nil {
+				logr.FromContextOrDiscard(ctx).Error(err, "Failed to record the webhook delivery", "key", key)
+			} else {
+				completed = true
+			}
+		}
+
+		return buf.WriteTo(w)
+	}
+}
+
+// replayError is returned by delivery when the delivery has been received, so
+// the recorded response is replayed.
+type replayError struct {
+	receipt *v1beta1.WebhookReceipt
+}
+
+func (e *replayError) Error() string {
+	return fmt.Sprintf("delivery %q has been received", e.receipt.Spec.Key)
+}
+
+// delivery implements hookutil.DeliveryGuard. It acquires a receipt for each
+// event source which accepts the delivery.
+type delivery struct {
+	store *Store
+	path  string
+	key   string
+
+	mu       sync.Mutex
+	receipts []*v1beta1.WebhookReceipt
+}
+
+func (d *delivery) receiptSpec(options *hookutil.TriggerOptions) (*v1beta1.WebhookReceiptSpec, error) {
+	sourceRef, err := hookutil.GetSourceRef(d.store.Client.Scheme(), options.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1beta1.WebhookReceiptSpec{
+		Path:      d.path,
+		Key:       d.key,
+		SourceRef: sourceRef,
+		User:      options.User,
+	}, nil
+}
+
+// findReceipt returns the index of the receipt acquired in this delivery, or
+// -1 when it is not found. The caller must hold the lock.
+func (d *delivery) findReceipt(name string) int {
+	for i, receipt := range d.receipts {
+		if receipt.Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (d *delivery) Acquire(ctx context.Context, options *hookutil.TriggerOptions) error {
+	spec, err := d.receiptSpec(options)
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// A source may handle multiple events of a delivery.
+	if d.findReceipt(receiptName(spec)) >= 0 {
+		return nil
+	}
+
+	receipt, created, err := d.store.acquire(ctx, spec)
+	if err != nil {
+		return err
+	}
+
+	if !created {
+		// Triggers of the source succeeded in a delivery which failed on
+		// other sources, so only this source is skipped.
+		if isSourceCompleted(receipt) {
+			return hookutil.ErrDeliveryHandled
+		}
+
+		return &replayError{receipt: receipt}
+	}
+
+	d.receipts = append(d.receipts, receipt)
+
+	return nil
+}
+
+// Done completes the receipt of the source when its triggers succeed, or
+// releases it when they fail, so only the failed source is handled again when
+// the delivery is retried.
+func (d *delivery) Done(ctx context.Context, options *hookutil.TriggerOptions, err error) {
+	logger := logr.FromContextOrDiscard(ctx)
+
+	spec, specErr := d.receiptSpec(options)
+	if specErr != nil {
+		logger.Error(specErr, "Failed to get the source of the webhook delivery")
+
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	i := d.findReceipt(receiptName(spec))
+
+	if i < 0 {
+		return
+	}
+
+	receipt := d.receipts[i]
+
+	if err != nil {
+		d.store.release(receipt)
+		d.receipts = append(d.receipts[:i], d.receipts[i+1:]...)
+
+		return
+	}
+
+	if receipt.Status.Phase == v1beta1.ReceiptPhaseCompleted {
+		return
+	}
+
+	if err := d.store.complete(ctx, receipt, nil); err != nil {
+		logger.Error(err, "Failed to record the webhook delivery", "key", d.key)
+	}
+}
+
+func (d *delivery) complete(ctx context.Context, buf *httputil.ResponseBuffer) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, receipt := range d.receipts {
+		if err := d.store.complete(ctx, receipt, buf); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// releaseInProgress releases receipts whose sources have not been completed
+// when the delivery fails.
+func (d *delivery) releaseInProgress() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, receipt := range d.receipts {
+		if receipt.Status.Phase != v1beta1.ReceiptPhaseCompleted {
+			d.store.release(receipt)
+		}
+	}
+}
+
+// isSourceCompleted returns true when triggers of the source succeeded but
+// the response of the delivery was not recorded.
+func isSourceCompleted(receipt *v1beta1.WebhookReceipt) bool {
+	return receipt.Status.Phase == v1beta1.ReceiptPhaseCompleted && receipt.Status.StatusCode == 0
+}
+
+func receiptName(spec *v1beta1.WebhookReceiptSpec) string {
+	ref := spec.SourceRef
+	hash := sha256.Sum256([]byte(strings.Join([]string{
+		spec.Path,
+		spec.Key,
+		ref.APIVersion,
+		ref.Kind,
+		ref.Namespace,
+		ref.Name,
+		spec.User,
+	}, "\n")))
+
+	return hex.EncodeToString(hash[:])
+}
+
+// acquire creates a receipt for the delivery. It returns the existing receipt
+// instead when the delivery has been received and the receipt is not expired.
+func (s *Store) acquire(ctx context.Context, spec *v1beta1.WebhookReceiptSpec) (*v1beta1.WebhookReceipt, bool, error) {
+	name := receiptName(spec)
+
+	for {
+		receipt := &v1beta1.WebhookReceipt{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: v1beta1.GroupVersion.String(),
+				Kind:       "WebhookReceipt",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: s.namespace(),
+				Name:      name,
+			},
+			Spec: *spec,
+			Status: v1beta1.WebhookReceiptStatus{
+				Phase: v1beta1.ReceiptPhaseInProgress,
+			},
+		}
+		receipt.Spec.ExpireTime = metav1.NewTime(s.now().Add(inProgressTimeout))
+
+		err := s.Client.Create(ctx, receipt)
+
+		if err == nil {
+			return receipt, true, nil
+		}
+
+		if !kerrors.IsAlreadyExists(err) {
+			return nil, false, fmt.Errorf("failed to create webhook receipt: %w", err)
+		}
+
+		existing := new(v1beta1.WebhookReceipt)
+
+		if err := s.Reader.Get(ctx, types.NamespacedName{Namespace: s.namespace(), Name: name}, existing); err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+
+			return nil, false, fmt.Errorf("failed to get webhook receipt: %w", err)
+		}
+
+		if !s.isExpired(existing) {
+			return existing, false, nil
+		}
+
+		if err := s.delete(ctx, existing); err != nil {
+			return nil, false, err
+		}
+	}
+}
+
+// complete marks the receipt as completed with the response. The response is
+// not recorded when buf is nil, which means only the source is completed.
+// The receipt is not changed when it fails to be updated, so it is still
+// released when the delivery fails.
+func (s *Store) complete(ctx context.Context, receipt *v1beta1.WebhookReceipt, buf *httputil.ResponseBuffer) error {
+	updated := receipt.DeepCopy()
+	updated.Spec.ExpireTime = metav1.NewTime(s.now().Add(s.Config.Window))
+	updated.Status = v1beta1.WebhookReceiptStatus{
+		Phase: v1beta1.ReceiptPhaseCompleted,
+	}
+
+	if buf != nil {
+		updated.Status.StatusCode = buf.StatusCode
+		updated.Status.ContentType = buf.Header().Get("Content-Type")
+		updated.Status.Body = buf.Body.String()
+	}
+
+	if err := s.Client.Update(ctx, updated); err != nil {
+		return fmt.Errorf("failed to update webhook receipt: %w", err)
+	}
+
+	*receipt = *updated
+
+	return nil
+}
+
+// release deletes the receipt of a failed delivery. The background context is
+// used because the request context may be canceled.
+func (s *Store) release(receipt *v1beta1.WebhookReceipt) {
+	if err := s.delete(context.Background(), receipt); err != nil {
+		s.Logger.Error(err, "Failed to delete the webhook receipt", "name", receipt.Name)
+	}
+}
+
+func (s *Store) delete(ctx context.Context, receipt *v1beta1.WebhookReceipt) error {
+	err := s.Client.Delete(ctx, receipt, client.Preconditions{UID: &receipt.UID})
+
+	if err := client.IgnoreNotFound(err); err != nil && !kerrors.IsConflict(err) {
+		return fmt.Errorf("failed to delete webhook receipt: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Store) isExpired(receipt *v1beta1.WebhookReceipt) bool {
+	return !s.now().Before(receipt.Spec.ExpireTime.Time)
+}
+
+func replay(w http.ResponseWriter, receipt *v1beta1.WebhookReceipt) error {
+	if receipt.Status.Phase != v1beta1.ReceiptPhaseCompleted {
+		return httputil.Response{
+			StatusCode: http.StatusConflict,
+			Errors: []httputil.Error{
+				{Description: "Delivery is in progress"},
+			},
+		}
+	}
+
+	w.Header().Set(ReplayedHeader, "true")
+
+	if ct := receipt.Status.ContentType; ct != "" {
+		w.Header().Set("Content-Type", ct)
+	}
+
+	return httputil.String(w, receipt.Status.StatusCode, receipt.Status.Body)
+}
+
+// Start deletes expired receipts periodically until the context is done.
+func (s *Store) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, s.cleanup, cleanupInterval)
+
+	return nil
+}
+
+func (s *Store) cleanup(ctx context.Context) {
+	var list v1beta1.WebhookReceiptList
+
+	if err := s.Reader.List(ctx, &list, client.InNamespace(s.namespace())); err != nil {
+		s.Logger.Error(err, "Failed to list webhook receipts")
+
+		return
+	}
+
+	for i := range list.Items {
+		receipt := &list.Items[i]
+
+		if !s.isExpired(receipt) {
+			continue
+		}
+
+		if err := s.delete(ctx, receipt); err != nil {
+			s.Logger.Error(err, "Failed to delete the webhook receipt", "name", receipt.Name)
+		}
+	}
+}
@@ -0,0 +1,424 @@
+package idempotency
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tommy351/pullup/internal/httputil"
+	"github.com/tommy351/pullup/internal/k8s"
+	"github.com/tommy351/pullup/internal/random"
+	"github.com/tommy351/pullup/internal/testenv"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Store", func() {
+	var (
+		store        *Store
+		mgr          *testenv.Manager
+		namespaceMap *random.NamespaceMap
+		namespace    *corev1.Namespace
+		handler      httputil.Handler
+		calls        int
+		key          string
+		now          time.Time
+		source       *v1beta1.HTTPWebhook
+		user         string
+	)
+
+	window := time.Hour
+
+	serve := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/github", nil)
+
+		if key != "" {
+			req.Header.Set(GitHubDeliveryHeader, key)
+		}
+
+		recorder := httptest.NewRecorder()
+		httputil.NewHandler(store.Wrap(GitHubDeliveryHeader, handler)).ServeHTTP(recorder, req)
+
+		return recorder
+	}
+
+	getSourceReceipt := func(name string) (*v1beta1.WebhookReceipt, error) {
+		receipt := new(v1beta1.WebhookReceipt)
+		err := store.Reader.Get(context.Background(), types.NamespacedName{
+			Namespace: namespace.Name,
+			Name: receiptName(&v1beta1.WebhookReceiptSpec{
+				Path: "/webhooks/github",
+				Key:  key,
+				SourceRef: v1beta1.ObjectReference{
+					APIVersion: v1beta1.GroupVersion.String(),
+					Kind:       "HTTPWebhook",
+					Namespace:  namespace.Name,
+					Name:       name,
+				},
+				User: user,
+			}),
+		}, receipt)
+
+		return receipt, err
+	}
+
+	getReceipt := func() (*v1beta1.WebhookReceipt, error) {
+		return getSourceReceipt("foobar")
+	}
+
+	trigger := func(r *http.Request) error {
+		triggerHandler := &hookutil.TriggerHandler{
+			Client:   store.Client,
+			Recorder: mgr.GetEventRecorderFor("pullup-webhook"),
+		}
+
+		return triggerHandler.Handle(r.Context(), &hookutil.TriggerOptions{
+			Source:        source,
+			DefaultAction: v1beta1.ActionApply,
+			User:          user,
+		})
+	}
+
+	BeforeEach(func() {
+		var err error
+		mgr, err = testenv.NewManager()
+		Expect(err).NotTo(HaveOccurred())
+
+		namespaceMap = random.NewNamespaceMap()
+		namespace = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: namespaceMap.GetRandom("test"),
+			},
+		}
+		Expect(testenv.GetClient().Create(context.Background(), namespace)).To(Succeed())
+
+		now = time.Now()
+		calls = 0
+		key = "abc"
+		user = ""
+		source = &v1beta1.HTTPWebhook{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace.Name,
+				Name:      "foobar",
+			},
+		}
+		handler = func(w http.ResponseWriter, r *http.Request) error {
+			if err := trigger(r); err != nil {
+				return err
+			}
+
+			calls++
+
+			return httputil.JSON(w, http.StatusOK, map[string]int{"calls": calls})
+		}
+
+		store = NewStore(NewStoreConfig(mgr, Config{Window: window}, k8s.Config{
+			Namespace: namespace.Name,
+		}, log.Log))
+		store.now = func() time.Time {
+			return now
+		}
+
+		Expect(mgr.Initialize()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(testenv.DeleteObjects([]client.Object{namespace})).To(Succeed())
+		mgr.Stop()
+	})
+
+	When("delivery is received for the first time", func() {
+		var recorder *httptest.ResponseRecorder
+
+		BeforeEach(func() {
+			recorder = serve()
+		})
+
+		It("should call the handler", func() {
+			Expect(calls).To(Equal(1))
+			Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+			Expect(recorder.Body.Bytes()).To(MatchJSON(`{"calls":1}`))
+			Expect(recorder.Header().Get(ReplayedHeader)).To(BeEmpty())
+		})
+
+		It("should record the response", func() {
+			receipt, err := getReceipt()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(receipt.Spec.Key).To(Equal(key))
+			Expect(receipt.Spec.SourceRef.Name).To(Equal("foobar"))
+			Expect(receipt.Spec.ExpireTime.Unix()).To(Equal(now.Add(window).Unix()))
+			Expect(receipt.Status.Phase).To(Equal(v1beta1.ReceiptPhaseCompleted))
+			Expect(receipt.Status.StatusCode).To(Equal(http.StatusOK))
+			Expect(receipt.Status.ContentType).To(Equal("application/json"))
+		})
+	})
+
+	When("delivery is repeated", func() {
+		var recorder *httptest.ResponseRecorder
+
+		BeforeEach(func() {
+			serve()
+			recorder = serve()
+		})
+
+		It("should not call the handler again", func() {
+			Expect(calls).To(Equal(1))
+		})
+
+		It("should respond the original response", func() {
+			Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+			Expect(recorder.Body.Bytes()).To(MatchJSON(`{"calls":1}`))
+			Expect(recorder.Header().Get("Content-Type")).To(Equal("application/json"))
+			Expect(recorder.Header().Get(ReplayedHeader)).To(Equal("true"))
+		})
+	})
+
+	When("delivery is repeated after the window", func() {
+		var recorder *httptest.ResponseRecorder
+
+		BeforeEach(func() {
+			serve()
+			now = now.Add(window)
+			recorder = serve()
+		})
+
+		It("should call the handler again", func() {
+			Expect(calls).To(Equal(2))
+			Expect(recorder.Body.Bytes()).To(MatchJSON(`{"calls":2}`))
+		})
+	})
+
+	When("delivery is in progress", func() {
+		var recorder *httptest.ResponseRecorder
+
+		BeforeEach(func() {
+			inner := handler
+			handler = func(w http.ResponseWriter, r *http.Request) error {
+				if err := trigger(r); err != nil {
+					return err
+				}
+
+				if calls == 0 {
+					recorder = serve()
+				}
+
+				return inner(w, r)
+			}
+
+			serve()
+		})
+
+		It("should respond 409", func() {
+			Expect(recorder).To(HaveHTTPStatus(http.StatusConflict))
+			Expect(calls).To(Equal(1))
+		})
+	})
+
+	When("handler fails after triggers succeed", func() {
+		BeforeEach(func() {
+			handler = func(w http.ResponseWriter, r *http.Request) error {
+				if err := trigger(r); err != nil {
+					return err
+				}
+
+				calls++
+
+				return httputil.Response{StatusCode: http.StatusBadRequest}
+			}
+
+			serve()
+		})
+
+		It("should complete the source without the response", func() {
+			receipt, err := getReceipt()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(receipt.Status.Phase).To(Equal(v1beta1.ReceiptPhaseCompleted))
+			Expect(receipt.Status.StatusCode).To(BeZero())
+		})
+
+		It("should call the handler again", func() {
+			Expect(serve()).To(HaveHTTPStatus(http.StatusBadRequest))
+			Expect(calls).To(Equal(2))
+		})
+	})
+
+	When("a source fails", func() {
+		var (
+			other   *v1beta1.HTTPWebhook
+			history *fakeTriggerHistory
+			failed  bool
+		)
+
+		BeforeEach(func() {
+			other = source.DeepCopy()
+			other.Name = "other"
+			history = &fakeTriggerHistory{}
+			failed = true
+			handler = func(w http.ResponseWriter, r *http.Request) error {
+				triggerHandler := &hookutil.TriggerHandler{
+					Client:   store.Client,
+					Recorder: mgr.GetEventRecorderFor("pullup-webhook"),
+					History:  history,
+				}
+
+				if err := triggerHandler.Handle(r.Context(), &hookutil.TriggerOptions{
+					Source:        source,
+					DefaultAction: v1beta1.ActionApply,
+				}); err != nil {
+					return err
+				}
+
+				options := &hookutil.TriggerOptions{
+					Source:        other,
+					DefaultAction: v1beta1.ActionApply,
+				}
+
+				if failed {
+					options.Triggers = []v1beta1.EventSourceTrigger{
+						{Name: "not-exist"},
+					}
+				}
+
+				if err := triggerHandler.Handle(r.Context(), options); err != nil {
+					return err
+				}
+
+				calls++
+
+				return httputil.JSON(w, http.StatusOK, map[string]int{"calls": calls})
+			}
+
+			Expect(serve()).To(HaveHTTPStatus(http.StatusInternalServerError))
+		})
+
+		It("should complete the succeeded source", func() {
+			receipt, err := getReceipt()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(receipt.Status.Phase).To(Equal(v1beta1.ReceiptPhaseCompleted))
+		})
+
+		It("should release the failed source", func() {
+			_, err := getSourceReceipt("other")
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		When("delivery is retried", func() {
+			var recorder *httptest.ResponseRecorder
+
+			BeforeEach(func() {
+				failed = false
+				recorder = serve()
+			})
+
+			It("should only handle the failed source again", func() {
+				Expect(history.sources).To(Equal([]string{"foobar", "other", "other"}))
+			})
+
+			It("should respond the response", func() {
+				Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+				Expect(recorder.Body.Bytes()).To(MatchJSON(`{"calls":1}`))
+			})
+
+			It("should record the response", func() {
+				receipt, err := getSourceReceipt("other")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(receipt.Status.StatusCode).To(Equal(http.StatusOK))
+			})
+		})
+	})
+
+	When("delivery is repeated to another source", func() {
+		BeforeEach(func() {
+			serve()
+			source = source.DeepCopy()
+			source.Name = "other"
+			serve()
+		})
+
+		It("should call the handler again", func() {
+			Expect(calls).To(Equal(2))
+		})
+	})
+
+	When("delivery is repeated by another user", func() {
+		BeforeEach(func() {
+			serve()
+			user = "other"
+			serve()
+		})
+
+		It("should call the handler again", func() {
+			Expect(calls).To(Equal(2))
+		})
+	})
+
+	When("handler does not trigger anything", func() {
+		BeforeEach(func() {
+			handler = func(w http.ResponseWriter, r *http.Request) error {
+				calls++
+
+				return httputil.JSON(w, http.StatusOK, &httputil.Response{})
+			}
+
+			serve()
+		})
+
+		It("should not record the response", func() {
+			_, err := getReceipt()
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should call the handler again", func() {
+			serve()
+			Expect(calls).To(Equal(2))
+		})
+	})
+
+	When("delivery ID is not set", func() {
+		BeforeEach(func() {
+			key = ""
+			serve()
+			serve()
+		})
+
+		It("should call the handler every time", func() {
+			Expect(calls).To(Equal(2))
+		})
+	})
+
+	Describe("cleanup", func() {
+		BeforeEach(func() {
+			serve()
+		})
+
+		It("should keep receipts which are not expired", func() {
+			store.cleanup(context.Background())
+			_, err := getReceipt()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should delete expired receipts", func() {
+			now = now.Add(window)
+			store.cleanup(context.Background())
+			_, err := getReceipt()
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
+
+type fakeTriggerHistory struct {
+	sources []string
+}
+
+func (f *fakeTriggerHistory) Record(_ context.Context, options *hookutil.TriggerOptions, _ string, _ []hookutil.TriggerOutcome) {
+	f.sources = append(f.sources, options.Source.GetName())
+}
@@ -0,0 +1,20 @@
+// +build wireinject
+
+package idempotency
+
+import (
+	"github.com/go-logr/logr"
+	"github.com/google/wire"
+	"github.com/tommy351/pullup/internal/controller"
+	"github.com/tommy351/pullup/internal/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+func NewStoreConfig(mgr manager.Manager, conf Config, k8sConf k8s.Config, logger logr.Logger) StoreConfig {
+	wire.Build(
+		controller.NewClient,
+		controller.NewAPIReader,
+		StoreConfigSet,
+	)
+	return StoreConfig{}
+}
@@ -0,0 +1,138 @@
+package queue
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/tommy351/pullup/internal/httputil"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const statusPathPrefix = "/webhooks/deliveries/"
+
+type DeliveryResponse struct {
+	ID        string `json:"id"`
+	StatusURL string `json:"statusUrl"`
+}
+
+type DeliveryStatusResponse struct {
+	ID              string              `json:"id"`
+	Phase           string              `json:"phase"`
+	Attempts        int                 `json:"attempts"`
+	CreationTime    time.Time           `json:"creationTime"`
+	LastAttemptTime *time.Time          `json:"lastAttemptTime,omitempty"`
+	CompletionTime  *time.Time          `json:"completionTime,omitempty"`
+	Jobs            []JobStatusResponse `json:"jobs"`
+}
+
+type JobStatusResponse struct {
+	Source  v1beta1.ObjectReference `json:"source"`
+	Phase   string                  `json:"phase"`
+	Message string                  `json:"message,omitempty"`
+}
+
+// Wrap returns a handler which collects triggers of the given handler and
+// enqueues them as a delivery, then responds 202 instead of waiting for
+// triggers to be handled. Responses are passed through unchanged when the queue
+// is disabled, the handler fails, or no triggers are collected.
+func (q *Queue) Wrap(handler httputil.Handler) httputil.Handler {
+	if !q.Config.Enabled {
+		return handler
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) error {
+		collector := hookutil.NewTriggerCollector()
+		buf := httputil.NewResponseBuffer()
+
+		if err := handler(buf, r.WithContext(hookutil.WithTriggerCollector(r.Context(), collector))); err != nil {
+			return err
+		}
+
+		options := collector.Options()
+
+		if len(options) == 0 {
+			return buf.WriteTo(w)
+		}
+
+		delivery, err := q.Enqueue(r.Context(), options)
+		if err != nil {
+			return err
+		}
+
+		return httputil.JSON(w, http.StatusAccepted, &DeliveryResponse{
+			ID:        delivery.Name,
+			StatusURL: statusPathPrefix + delivery.Name,
+		})
+	}
+}
+
+// HandleStatus responds the status of a delivery.
+func (q *Queue) HandleStatus(w http.ResponseWriter, r *http.Request) error {
+	var delivery v1beta1.WebhookDelivery
+
+	key := types.NamespacedName{
+		Namespace: q.namespace(),
+		Name:      mux.Vars(r)["id"],
+	}
+
+	if err := q.Reader.Get(r.Context(), key, &delivery); err != nil {
+		if kerrors.IsNotFound(err) {
+			return httputil.Response{
+				StatusCode: http.StatusNotFound,
+				Errors: []httputil.Error{
+					{Description: "Delivery not found"},
+				},
+			}
+		}
+
+		return err
+	}
+
+	return httputil.JSON(w, http.StatusOK, newDeliveryStatusResponse(&delivery))
+}
+
+func newDeliveryStatusResponse(delivery *v1beta1.WebhookDelivery) *DeliveryStatusResponse {
+	status := delivery.Status
+	res := &DeliveryStatusResponse{
+		ID:           delivery.Name,
+		Phase:        status.Phase,
+		Attempts:     status.Attempts,
+		CreationTime: delivery.CreationTimestamp.Time,
+		Jobs:         make([]JobStatusResponse, len(delivery.Spec.Jobs)),
+	}
+
+	if res.Phase == "" {
+		res.Phase = v1beta1.DeliveryPhasePending
+	}
+
+	if t := status.LastAttemptTime; t != nil {
+		res.LastAttemptTime = &t.Time
+	}
+
+	if t := status.CompletionTime; t != nil {
+		res.CompletionTime = &t.Time
+	}
+
+	for i, job := range delivery.Spec.Jobs {
+		jobRes := JobStatusResponse{
+			Source: job.SourceRef,
+			Phase:  v1beta1.DeliveryPhasePending,
+		}
+
+		if i < len(status.Jobs) {
+			if phase := status.Jobs[i].Phase; phase != "" {
+				jobRes.Phase = phase
+			}
+
+			jobRes.Message = status.Jobs[i].Message
+		}
+
+		res.Jobs[i] = jobRes
+	}
+
+	return res
+}
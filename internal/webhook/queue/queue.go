@@ -0,0 +1,409 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/wire"
+	"github.com/santhosh-tekuri/jsonschema/v2"
+	"github.com/tommy351/pullup/internal/k8s"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:rbac:groups=pullup.dev,resources=webhookdeliveries,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=pullup.dev,resources=webhookdeliveries/status,verbs=get;update;patch
+
+// A running delivery is considered abandoned, e.g. the webhook server was
+// restarted while handling it, after this timeout.
+const runningTimeout = 5 * time.Minute
+
+// QueueConfigSet provides a QueueConfig.
+// nolint: gochecknoglobals
+var QueueConfigSet = wire.NewSet(
+	wire.Struct(new(QueueConfig), "*"),
+)
+
+// QueueSet provides a Queue.
+// nolint: gochecknoglobals
+var QueueSet = wire.NewSet(
+	QueueConfigSet,
+	NewQueue,
+)
+
+type Config struct {
+	Enabled     bool          `mapstructure:"enabled"`
+	Workers     int           `mapstructure:"workers"`
+	MaxAttempts int           `mapstructure:"maxAttempts"`
+	MinBackoff  time.Duration `mapstructure:"minBackoff"`
+	MaxBackoff  time.Duration `mapstructure:"maxBackoff"`
+	TTL         time.Duration `mapstructure:"ttl"`
+}
+
+type QueueConfig struct {
+	Config           Config
+	KubernetesConfig k8s.Config
+	Client           client.Client
+	Reader           client.Reader
+	Logger           logr.Logger
+	TriggerHandler   hookutil.TriggerHandler
+}
+
+// Queue stores webhook deliveries as WebhookDelivery objects and handles them
+// with a pool of workers. Failed deliveries are retried with exponential
+// backoff.
+type Queue struct {
+	QueueConfig
+
+	queue workqueue.RateLimitingInterface
+	now   func() time.Time
+}
+
+func NewQueue(conf QueueConfig) *Queue {
+	return &Queue{
+		QueueConfig: conf,
+		queue: workqueue.NewNamedRateLimitingQueue(
+			workqueue.NewItemExponentialFailureRateLimiter(conf.Config.MinBackoff, conf.Config.MaxBackoff),
+			"webhook-delivery",
+		),
+		now: time.Now,
+	}
+}
+
+func (q *Queue) namespace() string {
+	return q.KubernetesConfig.Namespace
+}
+
+// Enqueue creates a delivery with the given options and adds it to the queue.
+func (q *Queue) Enqueue(ctx context.Context, options []*hookutil.TriggerOptions) (*v1beta1.WebhookDelivery, error) {
+	delivery := &v1beta1.WebhookDelivery{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1beta1.GroupVersion.String(),
+			Kind:       "WebhookDelivery",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: q.namespace(),
+			Name:      string(uuid.NewUUID()),
+		},
+	}
+
+	for _, opts := range options {
+		job, err := q.newJob(opts)
+		if err != nil {
+			return nil, err
+		}
+
+		delivery.Spec.Jobs = append(delivery.Spec.Jobs, job)
+	}
+
+	if err := q.Client.Create(ctx, delivery); err != nil {
+		return nil, fmt.Errorf("failed to create webhook delivery: %w", err)
+	}
+
+	q.queue.Add(delivery.Name)
+
+	return delivery, nil
+}
+
+func (q *Queue) newJob(options *hookutil.TriggerOptions) (v1beta1.WebhookDeliveryJob, error) {
+	gvk, _, err := q.Client.Scheme().ObjectKinds(options.Source)
+	if err != nil {
+		return v1beta1.WebhookDeliveryJob{}, fmt.Errorf("failed to get the kind of the source: %w", err)
+	}
+
+	apiVersion, kind := gvk[0].ToAPIVersionAndKind()
+	job := v1beta1.WebhookDeliveryJob{
+		SourceRef: v1beta1.ObjectReference{
+			APIVersion: apiVersion,
+			Kind:       kind,
+			Namespace:  options.Source.GetNamespace(),
+			Name:       options.Source.GetName(),
+		},
+		Triggers:      options.Triggers,
+		DefaultAction: options.DefaultAction,
+		Action:        options.Action,
+		Annotations:   options.Annotations,
+		Headers:       options.Headers,
+		User:          options.User,
+	}
+
+	if options.Event != nil {
+		buf, err := json.Marshal(options.Event)
+		if err != nil {
+			return v1beta1.WebhookDeliveryJob{}, fmt.Errorf("failed to marshal the event: %w", err)
+		}
+
+		job.Event = &extv1.JSON{Raw: buf}
+	}
+
+	return job, nil
+}
+
+// Start resumes unfinished deliveries and runs workers until the context is
+// done.
+func (q *Queue) Start(ctx context.Context) error {
+	defer q.queue.ShutDown()
+
+	if err := q.resume(ctx); err != nil {
+		return err
+	}
+
+	workers := q.Config.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for q.processNextItem(ctx) {
+			}
+		}()
+	}
+
+	q.Logger.Info("Webhook delivery queue started", "workers", workers)
+	<-ctx.Done()
+	q.queue.ShutDown()
+	wg.Wait()
+
+	return nil
+}
+
+func (q *Queue) resume(ctx context.Context) error {
+	var list v1beta1.WebhookDeliveryList
+
+	if err := q.Reader.List(ctx, &list, client.InNamespace(q.namespace())); err != nil {
+		return fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	for _, item := range list.Items {
+		q.queue.Add(item.Name)
+	}
+
+	return nil
+}
+
+func (q *Queue) processNextItem(ctx context.Context) bool {
+	item, shutdown := q.queue.Get()
+	if shutdown {
+		return false
+	}
+
+	defer q.queue.Done(item)
+
+	name := item.(string)
+	logger := q.Logger.WithValues("delivery", name)
+	requeueAfter, err := q.process(ctx, name)
+
+	switch {
+	case errors.Is(err, errRetry):
+		q.queue.AddRateLimited(item)
+	case err != nil:
+		logger.Error(err, "Failed to process the webhook delivery")
+		q.queue.AddRateLimited(item)
+	case requeueAfter > 0:
+		q.queue.Forget(item)
+		q.queue.AddAfter(item, requeueAfter)
+	default:
+		q.queue.Forget(item)
+	}
+
+	return true
+}
+
+var errRetry = errors.New("retry")
+
+// process handles a delivery. It returns errRetry when the delivery should be
+// retried with backoff, or a duration when the delivery should be processed
+// again later.
+func (q *Queue) process(ctx context.Context, name string) (time.Duration, error) {
+	var delivery v1beta1.WebhookDelivery
+
+	if err := q.Reader.Get(ctx, types.NamespacedName{Namespace: q.namespace(), Name: name}, &delivery); err != nil {
+		return 0, client.IgnoreNotFound(err)
+	}
+
+	now := q.now()
+	status := &delivery.Status
+
+	switch status.Phase {
+	case v1beta1.DeliveryPhaseSucceeded, v1beta1.DeliveryPhaseFailed:
+		return q.expire(ctx, &delivery, now)
+
+	case v1beta1.DeliveryPhaseRunning:
+		if status.LastAttemptTime != nil {
+			if d := status.LastAttemptTime.Add(runningTimeout).Sub(now); d > 0 {
+				return d, nil
+			}
+		}
+	}
+
+	// Claim the delivery. The update fails with a conflict when the delivery is
+	// claimed by another worker.
+	status.Phase = v1beta1.DeliveryPhaseRunning
+	status.Attempts++
+	status.LastAttemptTime = &metav1.Time{Time: now}
+
+	if len(status.Jobs) != len(delivery.Spec.Jobs) {
+		status.Jobs = make([]v1beta1.WebhookDeliveryJobStatus, len(delivery.Spec.Jobs))
+	}
+
+	if err := q.Client.Status().Update(ctx, &delivery); err != nil {
+		if kerrors.IsConflict(err) || kerrors.IsNotFound(err) {
+			return 0, nil
+		}
+
+		return 0, fmt.Errorf("failed to update the webhook delivery status: %w", err)
+	}
+
+	retry := false
+	logger := q.Logger.WithValues("delivery", name)
+
+	for i, job := range delivery.Spec.Jobs {
+		jobStatus := &status.Jobs[i]
+
+		if jobStatus.Phase == v1beta1.DeliveryPhaseSucceeded || jobStatus.Phase == v1beta1.DeliveryPhaseFailed {
+			continue
+		}
+
+		err := q.runJob(ctx, &job)
+
+		switch {
+		case err == nil:
+			jobStatus.Phase = v1beta1.DeliveryPhaseSucceeded
+			jobStatus.Message = ""
+		case isPermanentError(err):
+			logger.Info("Webhook delivery job failed", "source", job.SourceRef, "error", err.Error())
+			jobStatus.Phase = v1beta1.DeliveryPhaseFailed
+			jobStatus.Message = err.Error()
+		default:
+			logger.Error(err, "Webhook delivery job failed", "source", job.SourceRef, "attempts", status.Attempts)
+			jobStatus.Phase = v1beta1.DeliveryPhasePending
+			jobStatus.Message = err.Error()
+			retry = true
+		}
+	}
+
+	if retry && status.Attempts < q.Config.MaxAttempts {
+		status.Phase = v1beta1.DeliveryPhasePending
+	} else {
+		status.Phase = v1beta1.DeliveryPhaseSucceeded
+		status.CompletionTime = &metav1.Time{Time: q.now()}
+
+		for i := range status.Jobs {
+			if status.Jobs[i].Phase != v1beta1.DeliveryPhaseSucceeded {
+				status.Jobs[i].Phase = v1beta1.DeliveryPhaseFailed
+				status.Phase = v1beta1.DeliveryPhaseFailed
+			}
+		}
+
+		retry = false
+	}
+
+	if err := q.Client.Status().Update(ctx, &delivery); err != nil {
+		return 0, fmt.Errorf("failed to update the webhook delivery status: %w", err)
+	}
+
+	if retry {
+		return 0, errRetry
+	}
+
+	// Completed deliveries are deleted immediately when TTL is not positive.
+	if q.Config.TTL <= 0 {
+		return q.expire(ctx, &delivery, q.now())
+	}
+
+	return q.Config.TTL, nil
+}
+
+func (q *Queue) expire(ctx context.Context, delivery *v1beta1.WebhookDelivery, now time.Time) (time.Duration, error) {
+	if delivery.Status.CompletionTime == nil {
+		return 0, nil
+	}
+
+	if d := delivery.Status.CompletionTime.Add(q.Config.TTL).Sub(now); d > 0 {
+		return d, nil
+	}
+
+	if err := q.Client.Delete(ctx, delivery); err != nil {
+		return 0, client.IgnoreNotFound(err)
+	}
+
+	return 0, nil
+}
+
+func (q *Queue) runJob(ctx context.Context, job *v1beta1.WebhookDeliveryJob) error {
+	source, err := hookutil.GetSource(ctx, q.Client, job.SourceRef)
+	if err != nil {
+		if kerrors.IsNotFound(err) || runtime.IsNotRegisteredError(err) {
+			return permanentError{err: err}
+		}
+
+		return err
+	}
+
+	options := &hookutil.TriggerOptions{
+		Source:        source,
+		Triggers:      job.Triggers,
+		DefaultAction: job.DefaultAction,
+		Action:        job.Action,
+		Annotations:   job.Annotations,
+		Headers:       job.Headers,
+		User:          job.User,
+	}
+
+	if job.Event != nil {
+		options.Event = job.Event
+	}
+
+	return q.TriggerHandler.Handle(ctx, options)
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e permanentError) Unwrap() error {
+	return e.err
+}
+
+// isPermanentError returns true if the error would not be resolved by
+// retrying. These errors are usually caused by invalid resources.
+func isPermanentError(err error) bool {
+	var (
+		pe   permanentError
+		ve   hookutil.ValidationErrors
+		tnfe hookutil.TriggerNotFoundError
+		jsse *jsonschema.SchemaError
+		jsve *jsonschema.ValidationError
+	)
+
+	return errors.Is(err, hookutil.ErrInvalidAction) ||
+		errors.As(err, &pe) ||
+		errors.As(err, &ve) ||
+		errors.As(err, &tnfe) ||
+		errors.As(err, &jsse) ||
+		errors.As(err, &jsve)
+}
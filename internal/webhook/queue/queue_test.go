@@ -0,0 +1,379 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tommy351/pullup/internal/httputil"
+	"github.com/tommy351/pullup/internal/k8s"
+	"github.com/tommy351/pullup/internal/random"
+	"github.com/tommy351/pullup/internal/testenv"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Queue", func() {
+	var (
+		queue        *Queue
+		mgr          *testenv.Manager
+		namespaceMap *random.NamespaceMap
+		data         []client.Object
+		triggers     []v1beta1.EventSourceTrigger
+		recorder     *httptest.ResponseRecorder
+		delivery     *v1beta1.WebhookDelivery
+		now          time.Time
+	)
+
+	getSource := func() *v1beta1.HTTPWebhook {
+		source := new(v1beta1.HTTPWebhook)
+		Expect(queue.Reader.Get(context.Background(), types.NamespacedName{
+			Namespace: namespaceMap.GetRandom("test"),
+			Name:      "foobar",
+		}, source)).To(Succeed())
+
+		return source
+	}
+
+	getDelivery := func(name string) (*v1beta1.WebhookDelivery, error) {
+		delivery := new(v1beta1.WebhookDelivery)
+		err := queue.Reader.Get(context.Background(), types.NamespacedName{
+			Namespace: namespaceMap.GetRandom("test"),
+			Name:      name,
+		}, delivery)
+
+		return delivery, err
+	}
+
+	serve := func(handler httputil.Handler) {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req = req.WithContext(logr.NewContext(req.Context(), log.Log))
+		recorder = httptest.NewRecorder()
+		hookutil.NewHandler(queue.Wrap(handler)).ServeHTTP(recorder, req)
+	}
+
+	triggerHandler := func(w http.ResponseWriter, r *http.Request) error {
+		err := queue.TriggerHandler.Handle(r.Context(), &hookutil.TriggerOptions{
+			Source:        getSource(),
+			Triggers:      triggers,
+			DefaultAction: v1beta1.ActionApply,
+			Event:         map[string]interface{}{"name": "abc"},
+			Headers: map[string]string{
+				"X-Foo":                 "bar",
+				"Authorization":         "Bearer abc",
+				"Pullup-Webhook-Secret": "abc",
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		return httputil.JSON(w, http.StatusOK, &httputil.Response{})
+	}
+
+	BeforeEach(func() {
+		var err error
+		mgr, err = testenv.NewManager()
+		Expect(err).NotTo(HaveOccurred())
+
+		namespaceMap = random.NewNamespaceMap()
+		now = time.Now()
+		triggers = []v1beta1.EventSourceTrigger{{Name: "foobar"}}
+
+		queue = NewQueue(NewQueueConfig(mgr, Config{
+			Enabled:     true,
+			Workers:     1,
+			MaxAttempts: 3,
+			MinBackoff:  time.Millisecond,
+			MaxBackoff:  time.Second,
+			TTL:         time.Hour,
+		}, k8s.Config{
+			Namespace: namespaceMap.GetRandom("test"),
+		}, log.Log))
+		queue.now = func() time.Time {
+			return now
+		}
+
+		Expect(mgr.Initialize()).To(Succeed())
+
+		data, err = k8s.LoadObjects(testenv.GetScheme(), "testdata/webhook.yml")
+		Expect(err).NotTo(HaveOccurred())
+
+		data, err = k8s.MapObjects(data, namespaceMap.SetObject)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testenv.CreateObjects(data)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(testenv.DeleteObjects(data)).To(Succeed())
+		mgr.Stop()
+	})
+
+	Describe("Wrap", func() {
+		When("triggers are collected", func() {
+			var res DeliveryResponse
+
+			JustBeforeEach(func() {
+				serve(triggerHandler)
+				Expect(json.NewDecoder(recorder.Body).Decode(&res)).To(Succeed())
+			})
+
+			It("should respond 202", func() {
+				Expect(recorder).To(HaveHTTPStatus(http.StatusAccepted))
+			})
+
+			It("should respond the status URL", func() {
+				Expect(res.StatusURL).To(Equal("/webhooks/deliveries/" + res.ID))
+			})
+
+			It("should create a delivery", func() {
+				delivery, err := getDelivery(res.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(delivery.Spec.Jobs).To(HaveLen(1))
+
+				job := delivery.Spec.Jobs[0]
+				Expect(job.SourceRef).To(Equal(v1beta1.ObjectReference{
+					APIVersion: v1beta1.GroupVersion.String(),
+					Kind:       "HTTPWebhook",
+					Namespace:  namespaceMap.GetRandom("test"),
+					Name:       "foobar",
+				}))
+				Expect(job.Triggers).To(Equal(triggers))
+				Expect(job.DefaultAction).To(Equal(v1beta1.ActionApply))
+				Expect(job.Event.Raw).To(MatchJSON(`{"name":"abc"}`))
+				Expect(job.Headers).To(Equal(map[string]string{"X-Foo": "bar"}))
+			})
+
+			It("should not handle triggers", func() {
+				err := queue.Reader.Get(context.Background(), types.NamespacedName{
+					Namespace: namespaceMap.GetRandom("test"),
+					Name:      "foobar-abc",
+				}, new(v1beta1.ResourceTemplate))
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+
+		When("no triggers are collected", func() {
+			JustBeforeEach(func() {
+				serve(func(w http.ResponseWriter, r *http.Request) error {
+					w.Header().Set("X-Foo", "bar")
+
+					return httputil.JSON(w, http.StatusOK, &httputil.Response{})
+				})
+			})
+
+			It("should pass the response through", func() {
+				Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+				Expect(recorder.Header().Get("X-Foo")).To(Equal("bar"))
+				Expect(recorder.Body.Bytes()).To(MatchJSON(`{}`))
+			})
+		})
+
+		When("the action is invalid", func() {
+			JustBeforeEach(func() {
+				serve(func(w http.ResponseWriter, r *http.Request) error {
+					return queue.TriggerHandler.Handle(r.Context(), &hookutil.TriggerOptions{
+						Source:   getSource(),
+						Triggers: triggers,
+						Action:   "foo",
+					})
+				})
+			})
+
+			It("should respond 400", func() {
+				Expect(recorder).To(HaveHTTPStatus(http.StatusBadRequest))
+			})
+		})
+
+		When("the queue is disabled", func() {
+			BeforeEach(func() {
+				queue.Config.Enabled = false
+			})
+
+			JustBeforeEach(func() {
+				serve(triggerHandler)
+			})
+
+			It("should handle triggers synchronously", func() {
+				Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+
+				rt := new(v1beta1.ResourceTemplate)
+				Expect(queue.Reader.Get(context.Background(), types.NamespacedName{
+					Namespace: namespaceMap.GetRandom("test"),
+					Name:      "foobar-abc",
+				}, rt)).To(Succeed())
+			})
+		})
+	})
+
+	Describe("process", func() {
+		var (
+			requeueAfter time.Duration
+			err          error
+		)
+
+		BeforeEach(func() {
+			delivery = nil
+		})
+
+		JustBeforeEach(func() {
+			if delivery == nil {
+				var res DeliveryResponse
+				serve(triggerHandler)
+				Expect(json.NewDecoder(recorder.Body).Decode(&res)).To(Succeed())
+
+				delivery, err = getDelivery(res.ID)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			requeueAfter, err = queue.process(context.Background(), delivery.Name)
+		})
+
+		When("triggers succeed", func() {
+			It("should requeue after TTL", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(requeueAfter).To(Equal(time.Hour))
+			})
+
+			It("should create the resource template", func() {
+				rt := new(v1beta1.ResourceTemplate)
+				Expect(queue.Reader.Get(context.Background(), types.NamespacedName{
+					Namespace: namespaceMap.GetRandom("test"),
+					Name:      "foobar-abc",
+				}, rt)).To(Succeed())
+			})
+
+			It("should update the status", func() {
+				delivery, err := getDelivery(delivery.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(delivery.Status.Phase).To(Equal(v1beta1.DeliveryPhaseSucceeded))
+				Expect(delivery.Status.Attempts).To(Equal(1))
+				Expect(delivery.Status.CompletionTime).NotTo(BeNil())
+				Expect(delivery.Status.Jobs).To(Equal([]v1beta1.WebhookDeliveryJobStatus{
+					{Phase: v1beta1.DeliveryPhaseSucceeded},
+				}))
+			})
+		})
+
+		When("TTL is zero", func() {
+			BeforeEach(func() {
+				queue.Config.TTL = 0
+			})
+
+			It("should delete the delivery on completion", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(requeueAfter).To(BeZero())
+
+				_, err := getDelivery(delivery.Name)
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			})
+
+			It("should create the resource template", func() {
+				rt := new(v1beta1.ResourceTemplate)
+				Expect(queue.Reader.Get(context.Background(), types.NamespacedName{
+					Namespace: namespaceMap.GetRandom("test"),
+					Name:      "foobar-abc",
+				}, rt)).To(Succeed())
+			})
+		})
+
+		When("trigger does not exist", func() {
+			BeforeEach(func() {
+				triggers = []v1beta1.EventSourceTrigger{{Name: "not-exist"}}
+			})
+
+			It("should not retry", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(requeueAfter).To(Equal(time.Hour))
+			})
+
+			It("should fail the delivery", func() {
+				delivery, err := getDelivery(delivery.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(delivery.Status.Phase).To(Equal(v1beta1.DeliveryPhaseFailed))
+				Expect(delivery.Status.Jobs).To(HaveLen(1))
+				Expect(delivery.Status.Jobs[0].Phase).To(Equal(v1beta1.DeliveryPhaseFailed))
+				Expect(delivery.Status.Jobs[0].Message).To(ContainSubstring("trigger not found"))
+			})
+		})
+
+		When("delivery is completed and expired", func() {
+			JustBeforeEach(func() {
+				now = now.Add(2 * time.Hour)
+				requeueAfter, err = queue.process(context.Background(), delivery.Name)
+			})
+
+			It("should delete the delivery", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(requeueAfter).To(BeZero())
+
+				_, err := getDelivery(delivery.Name)
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+
+		When("delivery does not exist", func() {
+			BeforeEach(func() {
+				delivery = &v1beta1.WebhookDelivery{}
+				delivery.Name = "not-exist"
+			})
+
+			It("should do nothing", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(requeueAfter).To(BeZero())
+			})
+		})
+	})
+
+	Describe("HandleStatus", func() {
+		var id string
+
+		JustBeforeEach(func() {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req = mux.SetURLVars(req, map[string]string{"id": id})
+			recorder = httptest.NewRecorder()
+			hookutil.NewHandler(queue.HandleStatus).ServeHTTP(recorder, req)
+		})
+
+		When("delivery exists", func() {
+			BeforeEach(func() {
+				var res DeliveryResponse
+				serve(triggerHandler)
+				Expect(json.NewDecoder(recorder.Body).Decode(&res)).To(Succeed())
+				id = res.ID
+			})
+
+			It("should respond 200", func() {
+				Expect(recorder).To(HaveHTTPStatus(http.StatusOK))
+			})
+
+			It("should respond the status", func() {
+				var res DeliveryStatusResponse
+				Expect(json.NewDecoder(recorder.Body).Decode(&res)).To(Succeed())
+				Expect(res.ID).To(Equal(id))
+				Expect(res.Phase).To(Equal(v1beta1.DeliveryPhasePending))
+				Expect(res.Jobs).To(HaveLen(1))
+			})
+		})
+
+		When("delivery does not exist", func() {
+			BeforeEach(func() {
+				id = "not-exist"
+			})
+
+			It("should respond 404", func() {
+				Expect(recorder).To(HaveHTTPStatus(http.StatusNotFound))
+			})
+		})
+	})
+})
@@ -0,0 +1,23 @@
+// +build wireinject
+
+package queue
+
+import (
+	"github.com/go-logr/logr"
+	"github.com/google/wire"
+	"github.com/tommy351/pullup/internal/controller"
+	"github.com/tommy351/pullup/internal/k8s"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+func NewQueueConfig(mgr manager.Manager, conf Config, k8sConf k8s.Config, logger logr.Logger) QueueConfig {
+	wire.Build(
+		controller.NewClient,
+		controller.NewAPIReader,
+		hookutil.NewEventRecorder,
+		hookutil.TriggerHandlerSet,
+		QueueConfigSet,
+	)
+	return QueueConfig{}
+}
@@ -0,0 +1,172 @@
+package registry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	ActionPush   = "push"
+	ActionDelete = "delete"
+
+	harborPushArtifact   = "PUSH_ARTIFACT"
+	harborDeleteArtifact = "DELETE_ARTIFACT"
+)
+
+var ErrUnknownPayload = errors.New("unknown payload")
+
+// Event is an image tag pushed to or deleted from a registry.
+type Event struct {
+	Action     string          `json:"action"`
+	Registry   string          `json:"registry,omitempty"`
+	Repository string          `json:"repository"`
+	Tag        string          `json:"tag"`
+	Digest     string          `json:"digest,omitempty"`
+	Image      string          `json:"image"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+// distributionEnvelope is a notification of Docker Distribution.
+// See: https://distribution.github.io/distribution/about/notifications/
+type distributionEnvelope struct {
+	Events []json.RawMessage `json:"events"`
+}
+
+type distributionEvent struct {
+	ID     string `json:"id"`
+	Action string `json:"action"`
+	Target struct {
+		MediaType  string `json:"mediaType"`
+		Digest     string `json:"digest"`
+		Repository string `json:"repository"`
+		URL        string `json:"url"`
+		Tag        string `json:"tag"`
+	} `json:"target"`
+	Request struct {
+		Host string `json:"host"`
+	} `json:"request"`
+}
+
+// harborPayload is a webhook payload of Harbor.
+// See: https://goharbor.io/docs/main/working-with-projects/project-configuration/configure-webhooks/
+type harborPayload struct {
+	Type      string `json:"type"`
+	EventData *struct {
+		Resources []struct {
+			Digest      string `json:"digest"`
+			Tag         string `json:"tag"`
+			ResourceURL string `json:"resource_url"`
+		} `json:"resources"`
+		Repository struct {
+			Name         string `json:"name"`
+			Namespace    string `json:"namespace"`
+			RepoFullName string `json:"repo_full_name"`
+		} `json:"repository"`
+	} `json:"event_data"`
+}
+
+// ParseEvents parses a Docker Distribution notification envelope or a Harbor
+// webhook payload. Events without tags and unsupported actions are skipped.
+func ParseEvents(body []byte) ([]*Event, error) {
+	var keys map[string]json.RawMessage
+
+	if err := json.Unmarshal(body, &keys); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	if _, ok := keys["events"]; ok {
+		return parseDistributionEnvelope(body)
+	}
+
+	if _, ok := keys["event_data"]; ok {
+		return parseHarborPayload(body)
+	}
+
+	return nil, ErrUnknownPayload
+}
+
+func parseDistributionEnvelope(body []byte) ([]*Event, error) {
+	var envelope distributionEnvelope
+
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Docker Distribution envelope: %w", err)
+	}
+
+	var result []*Event
+
+	for _, raw := range envelope.Events {
+		var e distributionEvent
+
+		if err := json.Unmarshal(raw, &e); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal Docker Distribution event: %w", err)
+		}
+
+		if e.Target.Tag == "" || (e.Action != ActionPush && e.Action != ActionDelete) {
+			continue
+		}
+
+		result = append(result, newEvent(e.Action, e.Request.Host, e.Target.Repository, e.Target.Tag, e.Target.Digest, raw))
+	}
+
+	return result, nil
+}
+
+func parseHarborPayload(body []byte) ([]*Event, error) {
+	var payload harborPayload
+
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Harbor payload: %w", err)
+	}
+
+	var action string
+
+	switch payload.Type {
+	case harborPushArtifact:
+		action = ActionPush
+	case harborDeleteArtifact:
+		action = ActionDelete
+	default:
+		return nil, nil
+	}
+
+	if payload.EventData == nil {
+		return nil, nil
+	}
+
+	var result []*Event
+
+	repo := payload.EventData.Repository.RepoFullName
+
+	for _, res := range payload.EventData.Resources {
+		if res.Tag == "" {
+			continue
+		}
+
+		// Resource URLs look like "harbor.example.com/library/nginx:latest".
+		registry := strings.SplitN(res.ResourceURL, "/", 2)[0]
+
+		result = append(result, newEvent(action, registry, repo, res.Tag, res.Digest, json.RawMessage(body)))
+	}
+
+	return result, nil
+}
+
+func newEvent(action, registry, repo, tag, digest string, payload json.RawMessage) *Event {
+	image := repo + ":" + tag
+
+	if registry != "" {
+		image = registry + "/" + image
+	}
+
+	return &Event{
+		Action:     action,
+		Registry:   registry,
+		Repository: repo,
+		Tag:        tag,
+		Digest:     digest,
+		Image:      image,
+		Payload:    payload,
+	}
+}
@@ -0,0 +1,156 @@
+package registry
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tommy351/pullup/internal/testutil"
+)
+
+func newDistributionEvent(action, repo, tag string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":     "asdf-asdf-asdf-asdf-0",
+		"action": action,
+		"target": map[string]interface{}{
+			"mediaType":  "application/vnd.docker.distribution.manifest.v2+json",
+			"digest":     "sha256:fea8895f450959fa676bcc1df0611ea93823a735a01205fd8622846041d0c7cf",
+			"repository": repo,
+			"url":        "https://registry.example.com/v2/" + repo + "/manifests/" + tag,
+			"tag":        tag,
+		},
+		"request": map[string]interface{}{
+			"host": "registry.example.com",
+		},
+	}
+}
+
+func newDistributionEnvelope(events ...map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"events": events,
+	}
+}
+
+func newHarborPayload(eventType, repo, tag string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":     eventType,
+		"occur_at": 1586922308,
+		"operator": "admin",
+		"event_data": map[string]interface{}{
+			"resources": []interface{}{
+				map[string]interface{}{
+					"digest":       "sha256:8a9e9863dbb6e10edb5adfe917c00da84e1700fa76e7ed02476aa6e6fb8ee0d8",
+					"tag":          tag,
+					"resource_url": "harbor.example.com/" + repo + ":" + tag,
+				},
+			},
+			"repository": map[string]interface{}{
+				"name":           "nginx",
+				"namespace":      "library",
+				"repo_full_name": repo,
+			},
+		},
+	}
+}
+
+var _ = Describe("ParseEvents", func() {
+	var (
+		payload interface{}
+		events  []*Event
+		err     error
+	)
+
+	JustBeforeEach(func() {
+		events, err = ParseEvents(testutil.MustMarshalJSON(payload))
+	})
+
+	When("Docker Distribution envelope", func() {
+		BeforeEach(func() {
+			payload = newDistributionEnvelope(
+				newDistributionEvent(ActionPush, "library/nginx", "v1"),
+				newDistributionEvent("pull", "library/nginx", "v1"),
+				newDistributionEvent(ActionPush, "library/nginx", ""),
+				newDistributionEvent(ActionDelete, "library/redis", "v2"),
+			)
+		})
+
+		It("should parse tagged push and delete events", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(2))
+			Expect(events[0].Action).To(Equal(ActionPush))
+			Expect(events[0].Registry).To(Equal("registry.example.com"))
+			Expect(events[0].Repository).To(Equal("library/nginx"))
+			Expect(events[0].Tag).To(Equal("v1"))
+			Expect(events[0].Digest).To(Equal("sha256:fea8895f450959fa676bcc1df0611ea93823a735a01205fd8622846041d0c7cf"))
+			Expect(events[0].Image).To(Equal("registry.example.com/library/nginx:v1"))
+			Expect(events[1].Action).To(Equal(ActionDelete))
+			Expect(events[1].Image).To(Equal("registry.example.com/library/redis:v2"))
+		})
+
+		It("should keep the original event as the payload", func() {
+			Expect([]byte(events[0].Payload)).To(MatchJSON(testutil.MustMarshalJSON(
+				newDistributionEvent(ActionPush, "library/nginx", "v1"),
+			)))
+		})
+	})
+
+	When("Harbor payload", func() {
+		BeforeEach(func() {
+			payload = newHarborPayload(harborPushArtifact, "library/nginx", "v1")
+		})
+
+		It("should parse push events", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].Action).To(Equal(ActionPush))
+			Expect(events[0].Registry).To(Equal("harbor.example.com"))
+			Expect(events[0].Repository).To(Equal("library/nginx"))
+			Expect(events[0].Tag).To(Equal("v1"))
+			Expect(events[0].Digest).To(Equal("sha256:8a9e9863dbb6e10edb5adfe917c00da84e1700fa76e7ed02476aa6e6fb8ee0d8"))
+			Expect(events[0].Image).To(Equal("harbor.example.com/library/nginx:v1"))
+		})
+	})
+
+	When("Harbor delete payload", func() {
+		BeforeEach(func() {
+			payload = newHarborPayload(harborDeleteArtifact, "library/nginx", "v1")
+		})
+
+		It("should parse delete events", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].Action).To(Equal(ActionDelete))
+		})
+	})
+
+	When("Harbor payload of other types", func() {
+		BeforeEach(func() {
+			payload = newHarborPayload("PULL_ARTIFACT", "library/nginx", "v1")
+		})
+
+		It("should skip the payload", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(BeEmpty())
+		})
+	})
+
+	When("unknown payload", func() {
+		BeforeEach(func() {
+			payload = map[string]interface{}{"foo": "bar"}
+		})
+
+		It("should return ErrUnknownPayload", func() {
+			Expect(err).To(MatchError(ErrUnknownPayload))
+		})
+	})
+
+	When("payload is not an object", func() {
+		BeforeEach(func() {
+			payload = json.RawMessage("[]")
+		})
+
+		It("should return an error", func() {
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,270 @@
+package registry
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/google/wire"
+	"github.com/tommy351/pullup/internal/httputil"
+	"github.com/tommy351/pullup/internal/log"
+	"github.com/tommy351/pullup/internal/webhook/hookutil"
+	"github.com/tommy351/pullup/pkg/apis/pullup/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// +kubebuilder:rbac:groups=pullup.dev,resources=imageregistrywebhooks,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+const (
+	// repositoryField indexes webhooks by included repositories. Webhooks
+	// without repository filters or with regular expressions are indexed as
+	// anyKey.
+	repositoryField = "spec.repositories.include"
+	anyKey          = "*"
+)
+
+// HandlerConfigSet provides a handler config.
+// nolint: gochecknoglobals
+var HandlerConfigSet = wire.NewSet(
+	wire.Struct(new(HandlerConfig), "*"),
+)
+
+// HandlerSet provides a handler.
+// nolint: gochecknoglobals
+var HandlerSet = wire.NewSet(
+	HandlerConfigSet,
+	NewHandler,
+)
+
+type HandlerConfig struct {
+	Client         client.Client
+	TriggerHandler hookutil.TriggerHandler
+}
+
+type Handler struct {
+	HandlerConfig
+}
+
+func NewHandler(conf HandlerConfig, mgr manager.Manager) (*Handler, error) {
+	err := mgr.GetFieldIndexer().IndexField(context.TODO(), &v1beta1.ImageRegistryWebhook{}, repositoryField, func(obj client.Object) []string {
+		filter := obj.(*v1beta1.ImageRegistryWebhook).Spec.Repositories
+
+		if filter == nil || len(filter.Include) == 0 {
+			return []string{anyKey}
+		}
+
+		var result []string
+
+		for _, repo := range filter.Include {
+			if strings.HasPrefix(repo, "/") && strings.HasSuffix(repo, "/") {
+				return []string{anyKey}
+			}
+
+			result = append(result, repo)
+		}
+
+		return result
+	})
+	if err != nil {
+		return nil, fmt.Errorf("index failed: %w", err)
+	}
+
+	return &Handler{
+		HandlerConfig: conf,
+	}, nil
+}
+
+// webhook is an ImageRegistryWebhook whose Authorization header is validated
+// at most once for each request.
+type webhook struct {
+	*v1beta1.ImageRegistryWebhook
+
+	authorized *bool
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) error {
+	logger := logr.FromContextOrDiscard(r.Context())
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read body: %w", err)
+	}
+
+	events, err := ParseEvents(body)
+	if err != nil {
+		if !errors.Is(err, ErrUnknownPayload) {
+			logger.Error(err, "Invalid payload")
+		}
+
+		return httputil.Response{
+			StatusCode: http.StatusBadRequest,
+			Errors: []httputil.Error{
+				{Description: "Invalid payload"},
+			},
+		}
+	}
+
+	if len(events) == 0 {
+		logger.V(log.Debug).Info("Skipped payload without tagged push or delete events")
+
+		return httputil.JSON(w, http.StatusOK, &httputil.Response{})
+	}
+
+	hooks, err := h.listWebhooks(r.Context(), events)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		if err := h.handleEvent(r, hooks, event); err != nil {
+			return err
+		}
+	}
+
+	return httputil.JSON(w, http.StatusOK, &httputil.Response{})
+}
+
+// listWebhooks returns webhooks which may include repositories of events.
+// Webhooks are filtered by handleEvent later.
+func (h *Handler) listWebhooks(ctx context.Context, events []*Event) ([]*webhook, error) {
+	keys := []string{anyKey}
+	seenKeys := map[string]bool{anyKey: true}
+
+	for _, event := range events {
+		if !seenKeys[event.Repository] {
+			seenKeys[event.Repository] = true
+			keys = append(keys, event.Repository)
+		}
+	}
+
+	var result []*webhook
+
+	seen := map[types.UID]bool{}
+
+	for _, key := range keys {
+		list := new(v1beta1.ImageRegistryWebhookList)
+
+		if err := h.Client.List(ctx, list, client.MatchingFields{repositoryField: key}); err != nil {
+			return nil, fmt.Errorf("failed to list ImageRegistryWebhook: %w", err)
+		}
+
+		for i := range list.Items {
+			hook := &list.Items[i]
+
+			if seen[hook.UID] {
+				continue
+			}
+
+			seen[hook.UID] = true
+			hook.SetGroupVersionKind(v1beta1.GroupVersion.WithKind("ImageRegistryWebhook"))
+			result = append(result, &webhook{ImageRegistryWebhook: hook})
+		}
+	}
+
+	return result, nil
+}
+
+func (h *Handler) handleEvent(r *http.Request, hooks []*webhook, event *Event) error {
+	logger := logr.FromContextOrDiscard(r.Context()).WithValues(
+		"action", event.Action,
+		"image", event.Image,
+	)
+	ctx := logr.NewContext(r.Context(), logger)
+
+	var (
+		matched    []*webhook
+		authorized int
+	)
+
+	for _, hook := range hooks {
+		if !hookutil.FilterWebhook(hook.Spec.Repositories, []string{event.Repository}) {
+			continue
+		}
+
+		if !hookutil.FilterWebhook(hook.Spec.Tags, []string{event.Tag}) {
+			logger.V(log.Debug).Info("Skipped on this tag", "webhook", hook.ImageRegistryWebhook)
+
+			continue
+		}
+
+		matched = append(matched, hook)
+
+		if h.validateAuthorization(ctx, r, hook) {
+			authorized++
+		}
+	}
+
+	if len(matched) > 0 && authorized == 0 {
+		return httputil.Response{
+			StatusCode: http.StatusForbidden,
+			Errors: []httputil.Error{
+				{Description: "Authorization mismatch"},
+			},
+		}
+	}
+
+	for _, hook := range matched {
+		if !*hook.authorized {
+			continue
+		}
+
+		options := &hookutil.TriggerOptions{
+			DefaultAction: v1beta1.ActionApply,
+			Action:        hook.Spec.Action,
+			Event:         event,
+			Source:        hook.ImageRegistryWebhook,
+			Triggers:      hook.Spec.Triggers,
+		}
+
+		if event.Action == ActionDelete {
+			options.DefaultAction = v1beta1.ActionDelete
+		}
+
+		ctx := logr.NewContext(ctx, logger.WithValues("webhook", hook.ImageRegistryWebhook))
+
+		if err := h.TriggerHandler.Handle(ctx, options); err != nil {
+			return fmt.Errorf("failed to handle image registry event: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (h *Handler) validateAuthorization(ctx context.Context, r *http.Request, hook *webhook) bool {
+	if hook.authorized == nil {
+		ok := h.compareAuthorization(ctx, r, hook.ImageRegistryWebhook)
+		hook.authorized = &ok
+	}
+
+	return *hook.authorized
+}
+
+func (h *Handler) compareAuthorization(ctx context.Context, r *http.Request, hook *v1beta1.ImageRegistryWebhook) bool {
+	logger := logr.FromContextOrDiscard(ctx).WithValues("webhook", hook)
+
+	if hook.Spec.SecretToken == nil {
+		return true
+	}
+
+	secret, err := hookutil.GetSecretValue(ctx, h.Client, hook.Namespace, hook.Spec.SecretToken)
+	if err != nil {
+		logger.Error(err, "Failed to get the secret token")
+
+		return false
+	}
+
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), secret) != 1 {
+		logger.V(log.Debug).Info("Authorization mismatch")
+
+		return false
+	}
+
+	return true
+}
@@ -10,9 +10,17 @@ import (
 	"github.com/tommy351/pullup/internal/controller"
 	"github.com/tommy351/pullup/internal/httputil"
 	"github.com/tommy351/pullup/internal/middleware"
+	"github.com/tommy351/pullup/internal/webhook/bitbucket"
+	"github.com/tommy351/pullup/internal/webhook/cloudevents"
+	"github.com/tommy351/pullup/internal/webhook/gitea"
 	"github.com/tommy351/pullup/internal/webhook/github"
+	"github.com/tommy351/pullup/internal/webhook/gitlab"
+	"github.com/tommy351/pullup/internal/webhook/history"
 	"github.com/tommy351/pullup/internal/webhook/hookutil"
 	httphook "github.com/tommy351/pullup/internal/webhook/http"
+	"github.com/tommy351/pullup/internal/webhook/idempotency"
+	"github.com/tommy351/pullup/internal/webhook/queue"
+	"github.com/tommy351/pullup/internal/webhook/registry"
 )
 
 // ServerSet provides handlers and the server.
@@ -22,9 +30,19 @@ var ServerSet = wire.NewSet(
 	hookutil.NewEventRecorder,
 	hookutil.NewFieldIndexer,
 	controller.NewClient,
-	hookutil.TriggerHandlerSet,
+	controller.NewAPIReader,
+	hookutil.TriggerHandlerWithHistorySet,
+	wire.FieldsOf(new(Config), "Queue", "Idempotency", "History"),
+	history.StoreSet,
+	queue.QueueSet,
+	idempotency.StoreSet,
+	bitbucket.HandlerSet,
+	cloudevents.HandlerSet,
+	gitea.HandlerSet,
 	github.HandlerSet,
+	gitlab.HandlerSet,
 	httphook.HandlerSet,
+	registry.HandlerSet,
 	wire.Struct(new(Server), "*"),
 )
 
@@ -39,14 +57,24 @@ type Handler interface {
 }
 
 type Config struct {
-	Address string `mapstructure:"address"`
+	Address     string             `mapstructure:"address"`
+	Queue       queue.Config       `mapstructure:"queue"`
+	Idempotency idempotency.Config `mapstructure:"idempotency"`
+	History     history.Config     `mapstructure:"history"`
 }
 
 type Server struct {
-	Config        Config
-	Logger        logr.Logger
-	GithubHandler *github.Handler
-	HTTPHandler   *httphook.Handler
+	Config             Config
+	Logger             logr.Logger
+	BitbucketHandler   *bitbucket.Handler
+	CloudEventsHandler *cloudevents.Handler
+	GiteaHandler       *gitea.Handler
+	GithubHandler      *github.Handler
+	GitLabHandler      *gitlab.Handler
+	HTTPHandler        *httphook.Handler
+	RegistryHandler    *registry.Handler
+	Queue              *queue.Queue
+	Receipts           *idempotency.Store
 }
 
 func (s *Server) Start(ctx context.Context) error {
@@ -74,16 +102,37 @@ func (s *Server) Start(ctx context.Context) error {
 	}))
 
 	handlers := map[string]Handler{
-		"github": s.GithubHandler,
-		"http":   s.HTTPHandler,
+		"bitbucket":   s.BitbucketHandler,
+		"cloudevents": s.CloudEventsHandler,
+		"gitea":       s.GiteaHandler,
+		"github":      s.GithubHandler,
+		"gitlab":      s.GitLabHandler,
+		"http":        s.HTTPHandler,
+		"registry":    s.RegistryHandler,
+	}
+
+	// Headers which contain delivery IDs of webhooks.
+	idempotencyHeaders := map[string]string{
+		"github": idempotency.GitHubDeliveryHeader,
+		"http":   idempotency.IdempotencyKeyHeader,
 	}
 
 	for name, handler := range handlers {
 		router.
-			Handle("/webhooks/"+name, hookutil.NewHandler(handler.Handle)).
+			Handle("/webhooks/"+name, s.newHandler(idempotencyHeaders[name], handler.Handle)).
 			Methods(http.MethodPost)
 	}
 
+	router.
+		Handle("/webhooks/http/{namespace}/{name}", s.newHandler(idempotency.IdempotencyKeyHeader, s.HTTPHandler.HandleRoute)).
+		Methods(http.MethodPost)
+
+	if s.Config.Queue.Enabled {
+		router.
+			Handle("/webhooks/deliveries/{id}", hookutil.NewHandler(s.Queue.HandleStatus)).
+			Methods(http.MethodGet)
+	}
+
 	router.PathPrefix("/").Handler(httputil.NewHandler(func(w http.ResponseWriter, r *http.Request) error {
 		return httputil.JSON(w, http.StatusNotFound, &httputil.Response{
 			Errors: []httputil.Error{
@@ -99,3 +148,7 @@ func (s *Server) Start(ctx context.Context) error {
 		Logger:  s.Logger,
 	})
 }
+
+func (s *Server) newHandler(idempotencyHeader string, handler httputil.Handler) http.Handler {
+	return hookutil.NewHandler(s.Receipts.Wrap(idempotencyHeader, s.Queue.Wrap(handler)))
+}
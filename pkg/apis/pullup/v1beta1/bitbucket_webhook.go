@@ -0,0 +1,56 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories=all;pullup
+
+type BitbucketWebhook struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status BitbucketWebhookStatus `json:"status,omitempty"`
+	Spec   BitbucketWebhookSpec   `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+type BitbucketWebhookList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []BitbucketWebhook `json:"items"`
+}
+
+type BitbucketWebhookSpec struct {
+	EventSourceSpec `json:",inline"`
+
+	SecretToken  *SecretValue          `json:"secretToken,omitempty"`
+	Repositories []BitbucketRepository `json:"repositories"`
+}
+
+type BitbucketRepository struct {
+	Name        string                           `json:"name"`
+	Push        *BitbucketPushEventFilter        `json:"push,omitempty"`
+	PullRequest *BitbucketPullRequestEventFilter `json:"pullRequest,omitempty"`
+}
+
+type BitbucketPushEventFilter struct {
+	Branches *EventSourceFilter `json:"branches,omitempty"`
+	Tags     *EventSourceFilter `json:"tags,omitempty"`
+}
+
+type BitbucketPullRequestEventFilter struct {
+	Branches *EventSourceFilter              `json:"branches,omitempty"`
+	Types    []BitbucketPullRequestEventType `json:"types,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=created;updated;fulfilled;rejected
+type BitbucketPullRequestEventType string
+
+type BitbucketWebhookStatus struct {
+	EventSourceStatus `json:",inline"`
+}
@@ -0,0 +1,45 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories=all;pullup
+
+type CloudEventSource struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status CloudEventSourceStatus `json:"status,omitempty"`
+	Spec   CloudEventSourceSpec   `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+type CloudEventSourceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []CloudEventSource `json:"items"`
+}
+
+type CloudEventSourceSpec struct {
+	EventSourceSpec `json:",inline"`
+
+	SecretToken *SecretValue `json:"secretToken,omitempty"`
+
+	// Types filters events by the type attribute.
+	Types *EventSourceFilter `json:"types,omitempty"`
+
+	// Sources filters events by the source attribute.
+	Sources *EventSourceFilter `json:"sources,omitempty"`
+
+	// Subjects filters events by the subject attribute.
+	Subjects *EventSourceFilter `json:"subjects,omitempty"`
+}
+
+type CloudEventSourceStatus struct {
+	EventSourceStatus `json:",inline"`
+}
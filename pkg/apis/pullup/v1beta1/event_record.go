@@ -0,0 +1,67 @@
+package v1beta1
+
+import (
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// LabelSourceKind is the kind of the event source of an event record.
+	LabelSourceKind = "pullup.dev/source-kind"
+
+	// LabelSourceName is the name of the event source of an event record.
+	LabelSourceName = "pullup.dev/source-name"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories=pullup
+
+// EventRecord records an event handled by an event source, and outcomes of
+// its triggers.
+type EventRecord struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status EventRecordStatus `json:"status,omitempty"`
+	Spec   EventRecordSpec   `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+type EventRecordList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []EventRecord `json:"items"`
+}
+
+type EventRecordSpec struct {
+	SourceRef ObjectReference      `json:"sourceRef"`
+	Triggers  []EventSourceTrigger `json:"triggers,omitempty"`
+
+	// Action is the rendered action.
+	Action      string            `json:"action"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Headers of the request which delivers the event. Headers which may
+	// contain credentials are omitted.
+	Headers map[string]string `json:"headers,omitempty"`
+	User    string            `json:"user,omitempty"`
+
+	// Payload is the event. It is omitted when its size exceeds the limit.
+	Payload          *extv1.JSON `json:"payload,omitempty"`
+	PayloadSize      int         `json:"payloadSize"`
+	PayloadTruncated bool        `json:"payloadTruncated,omitempty"`
+}
+
+type EventRecordStatus struct {
+	Triggers []EventRecordTriggerStatus `json:"triggers,omitempty"`
+}
+
+type EventRecordTriggerStatus struct {
+	TriggerRef       ObjectReference `json:"triggerRef"`
+	ResourceTemplate string          `json:"resourceTemplate,omitempty"`
+	Reason           string          `json:"reason,omitempty"`
+	Message          string          `json:"message,omitempty"`
+	Failed           bool            `json:"failed,omitempty"`
+}
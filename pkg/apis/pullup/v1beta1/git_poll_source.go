@@ -0,0 +1,63 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories=all;pullup
+
+type GitPollSource struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status GitPollSourceStatus `json:"status,omitempty"`
+	Spec   GitPollSourceSpec   `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+type GitPollSourceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []GitPollSource `json:"items"`
+}
+
+type GitPollSourceSpec struct {
+	EventSourceSpec `json:",inline"`
+
+	// URL of the git remote, e.g. "https://github.com/foo/bar.git".
+	URL string `json:"url"`
+
+	// Interval between polls. The default value is 5 minutes.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Username and Password are credentials of HTTP basic authentication.
+	// Password can be a personal access token.
+	Username string       `json:"username,omitempty"`
+	Password *SecretValue `json:"password,omitempty"`
+
+	Branches *EventSourceFilter `json:"branches,omitempty"`
+	Tags     *EventSourceFilter `json:"tags,omitempty"`
+}
+
+type GitPollSourceStatus struct {
+	EventSourceStatus `json:",inline"`
+
+	// URL of the git remote when refs are polled. Refs are not compared when
+	// the URL is changed.
+	URL string `json:"url,omitempty"`
+
+	// Branches and Tags are filters of refs when refs are polled. Refs are not
+	// compared when filters are changed.
+	Branches *EventSourceFilter `json:"branches,omitempty"`
+	Tags     *EventSourceFilter `json:"tags,omitempty"`
+
+	LastPollTime *metav1.Time `json:"lastPollTime,omitempty"`
+
+	// Refs are commit hashes of matched refs in the last poll, e.g.
+	// "refs/heads/main".
+	Refs map[string]string `json:"refs,omitempty"`
+}
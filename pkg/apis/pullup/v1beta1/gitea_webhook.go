@@ -0,0 +1,57 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories=all;pullup
+
+type GiteaWebhook struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status GiteaWebhookStatus `json:"status,omitempty"`
+	Spec   GiteaWebhookSpec   `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+type GiteaWebhookList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []GiteaWebhook `json:"items"`
+}
+
+type GiteaWebhookSpec struct {
+	EventSourceSpec `json:",inline"`
+
+	SecretToken  *SecretValue      `json:"secretToken,omitempty"`
+	Repositories []GiteaRepository `json:"repositories"`
+}
+
+type GiteaRepository struct {
+	Name        string                       `json:"name"`
+	Push        *GiteaPushEventFilter        `json:"push,omitempty"`
+	PullRequest *GiteaPullRequestEventFilter `json:"pullRequest,omitempty"`
+}
+
+type GiteaPushEventFilter struct {
+	Branches *EventSourceFilter `json:"branches,omitempty"`
+	Tags     *EventSourceFilter `json:"tags,omitempty"`
+}
+
+type GiteaPullRequestEventFilter struct {
+	Branches *EventSourceFilter          `json:"branches,omitempty"`
+	Labels   *EventSourceFilter          `json:"labels,omitempty"`
+	Types    []GiteaPullRequestEventType `json:"types,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=opened;closed;reopened;edited;assigned;unassigned;label_updated;label_cleared;synchronized;milestoned;demilestoned;reviewed;review_requested;review_request_removed
+type GiteaPullRequestEventType string
+
+type GiteaWebhookStatus struct {
+	EventSourceStatus `json:",inline"`
+}
@@ -28,29 +28,122 @@ type GitHubWebhookList struct {
 type GitHubWebhookSpec struct {
 	EventSourceSpec `json:",inline"`
 
-	Repositories []GitHubRepository `json:"repositories"`
+	SecretToken   *SecretValue         `json:"secretToken,omitempty"`
+	Repositories  []GitHubRepository   `json:"repositories,omitempty"`
+	Installations []GitHubInstallation `json:"installations,omitempty"`
+
+	// Deployments reports pull request environments as GitHub deployments.
+	Deployments bool `json:"deployments,omitempty"`
+
+	// Comment keeps a comment on pull requests updated with the status of
+	// resource templates.
+	Comment bool `json:"comment,omitempty"`
 }
 
 type GitHubRepository struct {
-	Name        string                        `json:"name"`
-	Push        *GitHubPushEventFilter        `json:"push,omitempty"`
-	PullRequest *GitHubPullRequestEventFilter `json:"pullRequest,omitempty"`
+	Name         string                         `json:"name"`
+	SecretToken  *SecretValue                   `json:"secretToken,omitempty"`
+	Push         *GitHubPushEventFilter         `json:"push,omitempty"`
+	PullRequest  *GitHubPullRequestEventFilter  `json:"pullRequest,omitempty"`
+	IssueComment *GitHubIssueCommentEventFilter `json:"issueComment,omitempty"`
+	WorkflowRun  *GitHubCheckEventFilter        `json:"workflowRun,omitempty"`
+	CheckSuite   *GitHubCheckEventFilter        `json:"checkSuite,omitempty"`
+	Release      *GitHubReleaseEventFilter      `json:"release,omitempty"`
+}
+
+// GitHubInstallation matches events delivered to a GitHub App installation.
+// Events are matched by installation ID or by the owner of repositories.
+type GitHubInstallation struct {
+	ID           int64                          `json:"id,omitempty"`
+	Organization string                         `json:"organization,omitempty"`
+	Push         *GitHubPushEventFilter         `json:"push,omitempty"`
+	PullRequest  *GitHubPullRequestEventFilter  `json:"pullRequest,omitempty"`
+	IssueComment *GitHubIssueCommentEventFilter `json:"issueComment,omitempty"`
+	WorkflowRun  *GitHubCheckEventFilter        `json:"workflowRun,omitempty"`
+	CheckSuite   *GitHubCheckEventFilter        `json:"checkSuite,omitempty"`
+	Release      *GitHubReleaseEventFilter      `json:"release,omitempty"`
 }
 
 type GitHubPushEventFilter struct {
 	Branches *EventSourceFilter `json:"branches,omitempty"`
 	Tags     *EventSourceFilter `json:"tags,omitempty"`
+
+	// Paths filters changed files. An event is handled when any of changed
+	// files is included and not excluded.
+	Paths *EventSourceFilter `json:"paths,omitempty"`
 }
 
 type GitHubPullRequestEventFilter struct {
 	Branches *EventSourceFilter           `json:"branches,omitempty"`
 	Labels   *EventSourceFilter           `json:"labels,omitempty"`
 	Types    []GitHubPullRequestEventType `json:"types,omitempty"`
+
+	// Paths filters changed files. An event is handled when any of changed
+	// files is included and not excluded.
+	Paths *EventSourceFilter `json:"paths,omitempty"`
+
+	// Policy decides which pull requests are trusted to be deployed.
+	Policy *GitHubPullRequestPolicy `json:"policy,omitempty"`
+}
+
+// GitHubPullRequestPolicy decides which pull requests are trusted. A pull
+// request which is not trusted is skipped unless it has the approval label.
+type GitHubPullRequestPolicy struct {
+	// AllowForks allows pull requests whose head repository is different from
+	// the base repository.
+	AllowForks bool `json:"allowForks,omitempty"`
+
+	// AuthorAssociations and Logins allow pull requests opened by these
+	// authors. All authors are allowed when both of them are empty.
+	AuthorAssociations []GitHubAuthorAssociation `json:"authorAssociations,omitempty"`
+	Logins             []string                  `json:"logins,omitempty"`
+
+	// ApprovalLabel is a label which maintainers can add to approve pull
+	// requests which are not allowed by the policy.
+	ApprovalLabel string `json:"approvalLabel,omitempty"`
 }
 
 // +kubebuilder:validation:Enum=assigned;unassigned;labeled;unlabeled;opened;edited;closed;reopened;synchronize;ready_for_review;locked;unlocked;review_requested;review_request_removed
 type GitHubPullRequestEventType string
 
+// GitHubIssueCommentEventFilter handles commands in pull request comments,
+// such as "/pullup deploy".
+type GitHubIssueCommentEventFilter struct {
+	Prefix             string                    `json:"prefix,omitempty"`
+	AuthorAssociations []GitHubAuthorAssociation `json:"authorAssociations,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=OWNER;MEMBER;COLLABORATOR;CONTRIBUTOR;FIRST_TIMER;FIRST_TIME_CONTRIBUTOR;MANNEQUIN;NONE
+type GitHubAuthorAssociation string
+
+// GitHubCheckEventFilter handles completed workflow runs or check suites of
+// pull requests, so environments are only deployed after CI passes.
+type GitHubCheckEventFilter struct {
+	// Names filters workflow names of workflow_run events, or app slugs of
+	// check_suite events.
+	Names *EventSourceFilter `json:"names,omitempty"`
+
+	// Branches filters head branches.
+	Branches    *EventSourceFilter      `json:"branches,omitempty"`
+	Conclusions []GitHubCheckConclusion `json:"conclusions,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=success;failure;neutral;cancelled;skipped;timed_out;action_required;stale
+type GitHubCheckConclusion string
+
+type GitHubReleaseEventFilter struct {
+	Tags  *EventSourceFilter       `json:"tags,omitempty"`
+	Types []GitHubReleaseEventType `json:"types,omitempty"`
+
+	// Draft and Prerelease filter releases by flags. Releases are not filtered
+	// by a flag when it is not set.
+	Draft      *bool `json:"draft,omitempty"`
+	Prerelease *bool `json:"prerelease,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=published;unpublished;created;edited;deleted;prereleased;released
+type GitHubReleaseEventType string
+
 type GitHubWebhookStatus struct {
 	EventSourceStatus `json:",inline"`
 }
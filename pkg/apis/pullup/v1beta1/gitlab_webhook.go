@@ -0,0 +1,57 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories=all;pullup
+
+type GitLabWebhook struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status GitLabWebhookStatus `json:"status,omitempty"`
+	Spec   GitLabWebhookSpec   `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+type GitLabWebhookList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []GitLabWebhook `json:"items"`
+}
+
+type GitLabWebhookSpec struct {
+	EventSourceSpec `json:",inline"`
+
+	SecretToken *SecretValue    `json:"secretToken,omitempty"`
+	Projects    []GitLabProject `json:"projects"`
+}
+
+type GitLabProject struct {
+	Name         string                         `json:"name"`
+	Push         *GitLabPushEventFilter         `json:"push,omitempty"`
+	MergeRequest *GitLabMergeRequestEventFilter `json:"mergeRequest,omitempty"`
+}
+
+type GitLabPushEventFilter struct {
+	Branches *EventSourceFilter `json:"branches,omitempty"`
+	Tags     *EventSourceFilter `json:"tags,omitempty"`
+}
+
+type GitLabMergeRequestEventFilter struct {
+	Branches *EventSourceFilter              `json:"branches,omitempty"`
+	Labels   *EventSourceFilter              `json:"labels,omitempty"`
+	Actions  []GitLabMergeRequestEventAction `json:"actions,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=open;close;reopen;update;approved;unapproved;approval;unapproval;merge
+type GitLabMergeRequestEventAction string
+
+type GitLabWebhookStatus struct {
+	EventSourceStatus `json:",inline"`
+}
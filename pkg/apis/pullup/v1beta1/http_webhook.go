@@ -31,6 +31,52 @@ type HTTPWebhookSpec struct {
 
 	Schema      *extv1.JSON  `json:"schema,omitempty"`
 	SecretToken *SecretValue `json:"secretToken,omitempty"`
+
+	// Signature verifies HMAC signatures of request bodies signed with the
+	// secret token, instead of comparing the secret token in the header.
+	Signature *HTTPWebhookSignature `json:"signature,omitempty"`
+
+	// ServiceAccountToken authenticates callers with Kubernetes service
+	// account tokens in the Authorization header.
+	ServiceAccountToken *HTTPWebhookServiceAccountToken `json:"serviceAccountToken,omitempty"`
+}
+
+type HTTPWebhookSignature struct {
+	// +kubebuilder:validation:Enum=sha1;sha256;sha512
+	Algorithm string `json:"algorithm,omitempty"`
+
+	// Header is the name of the header which contains the signature.
+	Header string `json:"header,omitempty"`
+
+	// TimestampHeader is the name of the header which contains the Unix time
+	// when the request is signed.
+	TimestampHeader string `json:"timestampHeader,omitempty"`
+
+	// Tolerance is the maximum difference between the timestamp and the
+	// current time. The timestamp is signed along with the body when it is
+	// set, which prevents replay attacks.
+	Tolerance *metav1.Duration `json:"tolerance,omitempty"`
+}
+
+type HTTPWebhookServiceAccountToken struct {
+	// ServiceAccounts which are allowed to call the webhook. When both service
+	// accounts and groups are empty, only service accounts in the namespace of
+	// the webhook are allowed.
+	ServiceAccounts []ServiceAccountReference `json:"serviceAccounts,omitempty"`
+
+	// Groups which are allowed to call the webhook, e.g.
+	// "system:serviceaccounts:ci".
+	Groups []string `json:"groups,omitempty"`
+
+	// Audiences which the token must be issued for. The default audiences of
+	// the API server are used when it is empty.
+	Audiences []string `json:"audiences,omitempty"`
+}
+
+type ServiceAccountReference struct {
+	// Namespace defaults to the namespace of the webhook.
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
 }
 
 type HTTPWebhookStatus struct {
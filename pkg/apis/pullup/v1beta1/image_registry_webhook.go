@@ -0,0 +1,43 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories=all;pullup
+
+type ImageRegistryWebhook struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status ImageRegistryWebhookStatus `json:"status,omitempty"`
+	Spec   ImageRegistryWebhookSpec   `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+type ImageRegistryWebhookList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ImageRegistryWebhook `json:"items"`
+}
+
+type ImageRegistryWebhookSpec struct {
+	EventSourceSpec `json:",inline"`
+
+	// SecretToken must be equal to the Authorization header of requests.
+	SecretToken *SecretValue `json:"secretToken,omitempty"`
+
+	// Repositories filters events by repository names, e.g. "library/nginx".
+	Repositories *EventSourceFilter `json:"repositories,omitempty"`
+
+	// Tags filters events by image tags.
+	Tags *EventSourceFilter `json:"tags,omitempty"`
+}
+
+type ImageRegistryWebhookStatus struct {
+	EventSourceStatus `json:",inline"`
+}
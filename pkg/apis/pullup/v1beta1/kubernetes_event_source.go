@@ -0,0 +1,68 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	KubernetesEventAdded    = "added"
+	KubernetesEventModified = "modified"
+	KubernetesEventDeleted  = "deleted"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories=all;pullup
+
+type KubernetesEventSource struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status KubernetesEventSourceStatus `json:"status,omitempty"`
+	Spec   KubernetesEventSourceSpec   `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+type KubernetesEventSourceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []KubernetesEventSource `json:"items"`
+}
+
+type KubernetesEventSourceSpec struct {
+	EventSourceSpec `json:",inline"`
+
+	// APIVersion and Kind of watched objects. Namespaced objects are only
+	// watched in the namespace of the event source.
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+
+	// FieldSelector filters objects by fields, e.g. "metadata.name=foo".
+	FieldSelector string `json:"fieldSelector,omitempty"`
+
+	// EventTypes are types of events to handle. All types are handled when it
+	// is empty.
+	EventTypes []KubernetesEventType `json:"eventTypes,omitempty"`
+}
+
+func (in KubernetesEventSourceSpec) GroupVersionKind() schema.GroupVersionKind {
+	return schema.FromAPIVersionAndKind(in.APIVersion, in.Kind)
+}
+
+type KubernetesEventType struct {
+	// +kubebuilder:validation:Enum=added;modified;deleted
+	Type string `json:"type"`
+
+	// Action is the default action of the event type. The default value is
+	// "delete" for deleted events and "apply" for others.
+	Action string `json:"action,omitempty"`
+}
+
+type KubernetesEventSourceStatus struct {
+	EventSourceStatus `json:",inline"`
+}
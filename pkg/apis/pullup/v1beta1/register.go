@@ -22,8 +22,30 @@ func AddKnownTypes(scheme *runtime.Scheme) error {
 		&GitHubWebhookList{},
 		&HTTPWebhook{},
 		&HTTPWebhookList{},
+		&GitLabWebhook{},
+		&GitLabWebhookList{},
+		&BitbucketWebhook{},
+		&BitbucketWebhookList{},
+		&GiteaWebhook{},
+		&GiteaWebhookList{},
+		&GitPollSource{},
+		&GitPollSourceList{},
+		&CloudEventSource{},
+		&CloudEventSourceList{},
+		&ImageRegistryWebhook{},
+		&ImageRegistryWebhookList{},
+		&KubernetesEventSource{},
+		&KubernetesEventSourceList{},
+		&ScheduleSource{},
+		&ScheduleSourceList{},
 		&Trigger{},
 		&TriggerList{},
+		&WebhookDelivery{},
+		&WebhookDeliveryList{},
+		&WebhookReceipt{},
+		&WebhookReceiptList{},
+		&EventRecord{},
+		&EventRecordList{},
 	)
 	metav1.AddToGroupVersion(scheme, GroupVersion)
 
@@ -10,6 +10,30 @@ const (
 	DataKeyResource = "resource"
 	DataKeyTrigger  = "trigger"
 	DataKeyAction   = "action"
+	DataKeyHeaders  = "headers"
+)
+
+const (
+	// AnnotationGitHubRepository is the full name of the GitHub repository
+	// where deployments of a resource template are reported.
+	AnnotationGitHubRepository = "pullup.dev/github-repository"
+
+	// AnnotationGitHubSHA is the commit SHA of the GitHub deployment.
+	AnnotationGitHubSHA = "pullup.dev/github-sha"
+
+	// AnnotationGitHubPullRequest is the number of the pull request where the
+	// status of a resource template is commented.
+	AnnotationGitHubPullRequest = "pullup.dev/github-pull-request"
+
+	// FinalizerGitHubDeployment is added to resource templates which have an
+	// active GitHub deployment, so the deployment can be marked as inactive
+	// before the resource template is deleted.
+	FinalizerGitHubDeployment = "pullup.dev/github-deployment"
+
+	// FinalizerGitHubComment is added to resource templates which have a pull
+	// request comment, so the comment can be updated before the resource
+	// template is deleted.
+	FinalizerGitHubComment = "pullup.dev/github-comment"
 )
 
 // +kubebuilder:object:root=true
@@ -45,4 +69,21 @@ type ResourceTemplateSpec struct {
 type ResourceTemplateStatus struct {
 	LastUpdateTime *metav1.Time      `json:"lastUpdateTime,omitempty"`
 	Active         []ObjectReference `json:"active,omitempty"`
+
+	GitHubDeployment *GitHubDeploymentStatus `json:"githubDeployment,omitempty"`
+	GitHubComment    *GitHubCommentStatus    `json:"githubComment,omitempty"`
+}
+
+// GitHubDeploymentStatus is the last reported state of a GitHub deployment.
+type GitHubDeploymentStatus struct {
+	ID             int64  `json:"id"`
+	SHA            string `json:"sha"`
+	Environment    string `json:"environment"`
+	State          string `json:"state,omitempty"`
+	EnvironmentURL string `json:"environmentURL,omitempty"`
+}
+
+// GitHubCommentStatus is the pull request comment of a resource template.
+type GitHubCommentStatus struct {
+	ID int64 `json:"id"`
 }
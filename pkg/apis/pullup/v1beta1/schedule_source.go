@@ -0,0 +1,53 @@
+package v1beta1
+
+import (
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories=all;pullup
+
+type ScheduleSource struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status ScheduleSourceStatus `json:"status,omitempty"`
+	Spec   ScheduleSourceSpec   `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+type ScheduleSourceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ScheduleSource `json:"items"`
+}
+
+type ScheduleSourceSpec struct {
+	EventSourceSpec `json:",inline"`
+
+	// Schedule is a cron expression in the standard five-field format, or one
+	// of the predefined schedules such as "@daily".
+	Schedule string `json:"schedule"`
+
+	// TimeZone is the IANA time zone name of the schedule. UTC is used when it
+	// is not set.
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Data is passed to triggers as the event.
+	Data *extv1.JSON `json:"data,omitempty"`
+}
+
+type ScheduleSourceStatus struct {
+	EventSourceStatus `json:",inline"`
+
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+
+	// LastSkipTime is the time when missed runs are skipped because too many
+	// runs are missed.
+	LastSkipTime *metav1.Time `json:"lastSkipTime,omitempty"`
+}
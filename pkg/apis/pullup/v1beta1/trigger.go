@@ -30,6 +30,15 @@ type TriggerSpec struct {
 	ResourceName string         `json:"resourceName"`
 	Patches      []TriggerPatch `json:"patches,omitempty"`
 	Schema       *extv1.JSON    `json:"schema,omitempty"`
+
+	Environment *TriggerEnvironment `json:"environment,omitempty"`
+}
+
+// TriggerEnvironment describes the environment created by a trigger. Both
+// fields are templates rendered with the data of resource templates.
+type TriggerEnvironment struct {
+	Name string `json:"name,omitempty"`
+	URL  string `json:"url,omitempty"`
 }
 
 type TriggerStatus struct{}
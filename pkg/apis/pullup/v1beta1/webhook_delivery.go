@@ -0,0 +1,70 @@
+package v1beta1
+
+import (
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	DeliveryPhasePending   = "Pending"
+	DeliveryPhaseRunning   = "Running"
+	DeliveryPhaseSucceeded = "Succeeded"
+	DeliveryPhaseFailed    = "Failed"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories=pullup
+
+// WebhookDelivery is a webhook delivery which is accepted and waiting to be
+// handled by the webhook server.
+type WebhookDelivery struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status WebhookDeliveryStatus `json:"status,omitempty"`
+	Spec   WebhookDeliverySpec   `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+type WebhookDeliveryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []WebhookDelivery `json:"items"`
+}
+
+type WebhookDeliverySpec struct {
+	Jobs []WebhookDeliveryJob `json:"jobs,omitempty"`
+}
+
+// WebhookDeliveryJob contains options of triggering an event source.
+type WebhookDeliveryJob struct {
+	SourceRef     ObjectReference      `json:"sourceRef"`
+	Triggers      []EventSourceTrigger `json:"triggers,omitempty"`
+	DefaultAction string               `json:"defaultAction,omitempty"`
+	Action        string               `json:"action,omitempty"`
+	Event         *extv1.JSON          `json:"event,omitempty"`
+	Annotations   map[string]string    `json:"annotations,omitempty"`
+	Headers       map[string]string    `json:"headers,omitempty"`
+	User          string               `json:"user,omitempty"`
+}
+
+type WebhookDeliveryStatus struct {
+	// +kubebuilder:validation:Enum=Pending;Running;Succeeded;Failed
+	Phase    string `json:"phase,omitempty"`
+	Attempts int    `json:"attempts,omitempty"`
+
+	LastAttemptTime *metav1.Time `json:"lastAttemptTime,omitempty"`
+	CompletionTime  *metav1.Time `json:"completionTime,omitempty"`
+
+	// Jobs are status of jobs in the same order as spec.jobs.
+	Jobs []WebhookDeliveryJobStatus `json:"jobs,omitempty"`
+}
+
+type WebhookDeliveryJobStatus struct {
+	// +kubebuilder:validation:Enum=Pending;Running;Succeeded;Failed
+	Phase   string `json:"phase,omitempty"`
+	Message string `json:"message,omitempty"`
+}
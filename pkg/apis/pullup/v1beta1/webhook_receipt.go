@@ -0,0 +1,63 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ReceiptPhaseInProgress = "InProgress"
+	ReceiptPhaseCompleted  = "Completed"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories=pullup
+
+// WebhookReceipt records the response of a webhook delivery identified by its
+// delivery ID and the authenticated event source, so repeated deliveries can be
+// short-circuited.
+type WebhookReceipt struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status WebhookReceiptStatus `json:"status,omitempty"`
+	Spec   WebhookReceiptSpec   `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+type WebhookReceiptList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []WebhookReceipt `json:"items"`
+}
+
+type WebhookReceiptSpec struct {
+	// Path is the request path of the delivery.
+	Path string `json:"path"`
+
+	// Key is the delivery ID, e.g. the value of X-GitHub-Delivery header.
+	Key string `json:"key"`
+
+	// SourceRef is the event source which accepted the delivery.
+	SourceRef ObjectReference `json:"sourceRef"`
+
+	// User is the authenticated identity which sent the delivery.
+	User string `json:"user,omitempty"`
+
+	// ExpireTime is when the receipt is expired and the delivery ID can be
+	// delivered again.
+	ExpireTime metav1.Time `json:"expireTime"`
+}
+
+type WebhookReceiptStatus struct {
+	// +kubebuilder:validation:Enum=InProgress;Completed
+	Phase string `json:"phase,omitempty"`
+
+	// StatusCode, ContentType and Body are the recorded response. They are
+	// not set when triggers of the source succeeded but the delivery failed
+	// on other sources.
+	StatusCode  int    `json:"statusCode,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+	Body        string `json:"body,omitempty"`
+}
@@ -23,123 +23,37 @@ package v1beta1
 import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EventSourceFilter) DeepCopyInto(out *EventSourceFilter) {
-	*out = *in
-	if in.Include != nil {
-		in, out := &in.Include, &out.Include
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Exclude != nil {
-		in, out := &in.Exclude, &out.Exclude
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventSourceFilter.
-func (in *EventSourceFilter) DeepCopy() *EventSourceFilter {
-	if in == nil {
-		return nil
-	}
-	out := new(EventSourceFilter)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EventSourceSpec) DeepCopyInto(out *EventSourceSpec) {
-	*out = *in
-	if in.Triggers != nil {
-		in, out := &in.Triggers, &out.Triggers
-		*out = make([]EventSourceTrigger, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventSourceSpec.
-func (in *EventSourceSpec) DeepCopy() *EventSourceSpec {
-	if in == nil {
-		return nil
-	}
-	out := new(EventSourceSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EventSourceStatus) DeepCopyInto(out *EventSourceStatus) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventSourceStatus.
-func (in *EventSourceStatus) DeepCopy() *EventSourceStatus {
-	if in == nil {
-		return nil
-	}
-	out := new(EventSourceStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EventSourceTrigger) DeepCopyInto(out *EventSourceTrigger) {
-	*out = *in
-	if in.Transform != nil {
-		in, out := &in.Transform, &out.Transform
-		*out = new(v1.JSON)
-		(*in).DeepCopyInto(*out)
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventSourceTrigger.
-func (in *EventSourceTrigger) DeepCopy() *EventSourceTrigger {
-	if in == nil {
-		return nil
-	}
-	out := new(EventSourceTrigger)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GitHubPullRequestEventFilter) DeepCopyInto(out *GitHubPullRequestEventFilter) {
+func (in *BitbucketPullRequestEventFilter) DeepCopyInto(out *BitbucketPullRequestEventFilter) {
 	*out = *in
 	if in.Branches != nil {
 		in, out := &in.Branches, &out.Branches
 		*out = new(EventSourceFilter)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = new(EventSourceFilter)
-		(*in).DeepCopyInto(*out)
-	}
 	if in.Types != nil {
 		in, out := &in.Types, &out.Types
-		*out = make([]GitHubPullRequestEventType, len(*in))
+		*out = make([]BitbucketPullRequestEventType, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubPullRequestEventFilter.
-func (in *GitHubPullRequestEventFilter) DeepCopy() *GitHubPullRequestEventFilter {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BitbucketPullRequestEventFilter.
+func (in *BitbucketPullRequestEventFilter) DeepCopy() *BitbucketPullRequestEventFilter {
 	if in == nil {
 		return nil
 	}
-	out := new(GitHubPullRequestEventFilter)
+	out := new(BitbucketPullRequestEventFilter)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GitHubPushEventFilter) DeepCopyInto(out *GitHubPushEventFilter) {
+func (in *BitbucketPushEventFilter) DeepCopyInto(out *BitbucketPushEventFilter) {
 	*out = *in
 	if in.Branches != nil {
 		in, out := &in.Branches, &out.Branches
@@ -153,43 +67,43 @@ func (in *GitHubPushEventFilter) DeepCopyInto(out *GitHubPushEventFilter) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubPushEventFilter.
-func (in *GitHubPushEventFilter) DeepCopy() *GitHubPushEventFilter {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BitbucketPushEventFilter.
+func (in *BitbucketPushEventFilter) DeepCopy() *BitbucketPushEventFilter {
 	if in == nil {
 		return nil
 	}
-	out := new(GitHubPushEventFilter)
+	out := new(BitbucketPushEventFilter)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GitHubRepository) DeepCopyInto(out *GitHubRepository) {
+func (in *BitbucketRepository) DeepCopyInto(out *BitbucketRepository) {
 	*out = *in
 	if in.Push != nil {
 		in, out := &in.Push, &out.Push
-		*out = new(GitHubPushEventFilter)
+		*out = new(BitbucketPushEventFilter)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.PullRequest != nil {
 		in, out := &in.PullRequest, &out.PullRequest
-		*out = new(GitHubPullRequestEventFilter)
+		*out = new(BitbucketPullRequestEventFilter)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubRepository.
-func (in *GitHubRepository) DeepCopy() *GitHubRepository {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BitbucketRepository.
+func (in *BitbucketRepository) DeepCopy() *BitbucketRepository {
 	if in == nil {
 		return nil
 	}
-	out := new(GitHubRepository)
+	out := new(BitbucketRepository)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GitHubWebhook) DeepCopyInto(out *GitHubWebhook) {
+func (in *BitbucketWebhook) DeepCopyInto(out *BitbucketWebhook) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -197,18 +111,18 @@ func (in *GitHubWebhook) DeepCopyInto(out *GitHubWebhook) {
 	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubWebhook.
-func (in *GitHubWebhook) DeepCopy() *GitHubWebhook {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BitbucketWebhook.
+func (in *BitbucketWebhook) DeepCopy() *BitbucketWebhook {
 	if in == nil {
 		return nil
 	}
-	out := new(GitHubWebhook)
+	out := new(BitbucketWebhook)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GitHubWebhook) DeepCopyObject() runtime.Object {
+func (in *BitbucketWebhook) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -216,31 +130,31 @@ func (in *GitHubWebhook) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GitHubWebhookList) DeepCopyInto(out *GitHubWebhookList) {
+func (in *BitbucketWebhookList) DeepCopyInto(out *BitbucketWebhookList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]GitHubWebhook, len(*in))
+		*out = make([]BitbucketWebhook, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubWebhookList.
-func (in *GitHubWebhookList) DeepCopy() *GitHubWebhookList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BitbucketWebhookList.
+func (in *BitbucketWebhookList) DeepCopy() *BitbucketWebhookList {
 	if in == nil {
 		return nil
 	}
-	out := new(GitHubWebhookList)
+	out := new(BitbucketWebhookList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GitHubWebhookList) DeepCopyObject() runtime.Object {
+func (in *BitbucketWebhookList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -248,46 +162,51 @@ func (in *GitHubWebhookList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GitHubWebhookSpec) DeepCopyInto(out *GitHubWebhookSpec) {
+func (in *BitbucketWebhookSpec) DeepCopyInto(out *BitbucketWebhookSpec) {
 	*out = *in
 	in.EventSourceSpec.DeepCopyInto(&out.EventSourceSpec)
+	if in.SecretToken != nil {
+		in, out := &in.SecretToken, &out.SecretToken
+		*out = new(SecretValue)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Repositories != nil {
 		in, out := &in.Repositories, &out.Repositories
-		*out = make([]GitHubRepository, len(*in))
+		*out = make([]BitbucketRepository, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubWebhookSpec.
-func (in *GitHubWebhookSpec) DeepCopy() *GitHubWebhookSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BitbucketWebhookSpec.
+func (in *BitbucketWebhookSpec) DeepCopy() *BitbucketWebhookSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GitHubWebhookSpec)
+	out := new(BitbucketWebhookSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GitHubWebhookStatus) DeepCopyInto(out *GitHubWebhookStatus) {
+func (in *BitbucketWebhookStatus) DeepCopyInto(out *BitbucketWebhookStatus) {
 	*out = *in
 	out.EventSourceStatus = in.EventSourceStatus
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubWebhookStatus.
-func (in *GitHubWebhookStatus) DeepCopy() *GitHubWebhookStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BitbucketWebhookStatus.
+func (in *BitbucketWebhookStatus) DeepCopy() *BitbucketWebhookStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(GitHubWebhookStatus)
+	out := new(BitbucketWebhookStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HTTPWebhook) DeepCopyInto(out *HTTPWebhook) {
+func (in *CloudEventSource) DeepCopyInto(out *CloudEventSource) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -295,18 +214,18 @@ func (in *HTTPWebhook) DeepCopyInto(out *HTTPWebhook) {
 	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPWebhook.
-func (in *HTTPWebhook) DeepCopy() *HTTPWebhook {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventSource.
+func (in *CloudEventSource) DeepCopy() *CloudEventSource {
 	if in == nil {
 		return nil
 	}
-	out := new(HTTPWebhook)
+	out := new(CloudEventSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *HTTPWebhook) DeepCopyObject() runtime.Object {
+func (in *CloudEventSource) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -314,31 +233,31 @@ func (in *HTTPWebhook) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HTTPWebhookList) DeepCopyInto(out *HTTPWebhookList) {
+func (in *CloudEventSourceList) DeepCopyInto(out *CloudEventSourceList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]HTTPWebhook, len(*in))
+		*out = make([]CloudEventSource, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPWebhookList.
-func (in *HTTPWebhookList) DeepCopy() *HTTPWebhookList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventSourceList.
+func (in *CloudEventSourceList) DeepCopy() *CloudEventSourceList {
 	if in == nil {
 		return nil
 	}
-	out := new(HTTPWebhookList)
+	out := new(CloudEventSourceList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *HTTPWebhookList) DeepCopyObject() runtime.Object {
+func (in *CloudEventSourceList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -346,84 +265,59 @@ func (in *HTTPWebhookList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HTTPWebhookSpec) DeepCopyInto(out *HTTPWebhookSpec) {
+func (in *CloudEventSourceSpec) DeepCopyInto(out *CloudEventSourceSpec) {
 	*out = *in
 	in.EventSourceSpec.DeepCopyInto(&out.EventSourceSpec)
-	if in.Schema != nil {
-		in, out := &in.Schema, &out.Schema
-		*out = new(v1.JSON)
-		(*in).DeepCopyInto(*out)
-	}
 	if in.SecretToken != nil {
 		in, out := &in.SecretToken, &out.SecretToken
 		*out = new(SecretValue)
 		(*in).DeepCopyInto(*out)
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPWebhookSpec.
-func (in *HTTPWebhookSpec) DeepCopy() *HTTPWebhookSpec {
-	if in == nil {
-		return nil
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = new(EventSourceFilter)
+		(*in).DeepCopyInto(*out)
 	}
-	out := new(HTTPWebhookSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HTTPWebhookStatus) DeepCopyInto(out *HTTPWebhookStatus) {
-	*out = *in
-	out.EventSourceStatus = in.EventSourceStatus
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPWebhookStatus.
-func (in *HTTPWebhookStatus) DeepCopy() *HTTPWebhookStatus {
-	if in == nil {
-		return nil
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = new(EventSourceFilter)
+		(*in).DeepCopyInto(*out)
 	}
-	out := new(HTTPWebhookStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JSONPatch) DeepCopyInto(out *JSONPatch) {
-	*out = *in
-	if in.Value != nil {
-		in, out := &in.Value, &out.Value
-		*out = new(v1.JSON)
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = new(EventSourceFilter)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JSONPatch.
-func (in *JSONPatch) DeepCopy() *JSONPatch {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventSourceSpec.
+func (in *CloudEventSourceSpec) DeepCopy() *CloudEventSourceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(JSONPatch)
+	out := new(CloudEventSourceSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
+func (in *CloudEventSourceStatus) DeepCopyInto(out *CloudEventSourceStatus) {
 	*out = *in
+	out.EventSourceStatus = in.EventSourceStatus
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectReference.
-func (in *ObjectReference) DeepCopy() *ObjectReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventSourceStatus.
+func (in *CloudEventSourceStatus) DeepCopy() *CloudEventSourceStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectReference)
+	out := new(CloudEventSourceStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceTemplate) DeepCopyInto(out *ResourceTemplate) {
+func (in *EventRecord) DeepCopyInto(out *EventRecord) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -431,18 +325,18 @@ func (in *ResourceTemplate) DeepCopyInto(out *ResourceTemplate) {
 	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceTemplate.
-func (in *ResourceTemplate) DeepCopy() *ResourceTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventRecord.
+func (in *EventRecord) DeepCopy() *EventRecord {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceTemplate)
+	out := new(EventRecord)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ResourceTemplate) DeepCopyObject() runtime.Object {
+func (in *EventRecord) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -450,31 +344,31 @@ func (in *ResourceTemplate) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceTemplateList) DeepCopyInto(out *ResourceTemplateList) {
+func (in *EventRecordList) DeepCopyInto(out *EventRecordList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ResourceTemplate, len(*in))
+		*out = make([]EventRecord, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceTemplateList.
-func (in *ResourceTemplateList) DeepCopy() *ResourceTemplateList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventRecordList.
+func (in *EventRecordList) DeepCopy() *EventRecordList {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceTemplateList)
+	out := new(EventRecordList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ResourceTemplateList) DeepCopyObject() runtime.Object {
+func (in *EventRecordList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -482,87 +376,1770 @@ func (in *ResourceTemplateList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceTemplateSpec) DeepCopyInto(out *ResourceTemplateSpec) {
+func (in *EventRecordSpec) DeepCopyInto(out *EventRecordSpec) {
 	*out = *in
-	if in.TriggerRef != nil {
-		in, out := &in.TriggerRef, &out.TriggerRef
-		*out = new(ObjectReference)
-		**out = **in
-	}
-	if in.Patches != nil {
-		in, out := &in.Patches, &out.Patches
-		*out = make([]TriggerPatch, len(*in))
+	out.SourceRef = in.SourceRef
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]EventSourceTrigger, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	in.Data.DeepCopyInto(&out.Data)
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Payload != nil {
+		in, out := &in.Payload, &out.Payload
+		*out = new(v1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceTemplateSpec.
-func (in *ResourceTemplateSpec) DeepCopy() *ResourceTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventRecordSpec.
+func (in *EventRecordSpec) DeepCopy() *EventRecordSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceTemplateSpec)
+	out := new(EventRecordSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceTemplateStatus) DeepCopyInto(out *ResourceTemplateStatus) {
+func (in *EventRecordStatus) DeepCopyInto(out *EventRecordStatus) {
 	*out = *in
-	if in.LastUpdateTime != nil {
-		in, out := &in.LastUpdateTime, &out.LastUpdateTime
-		*out = (*in).DeepCopy()
-	}
-	if in.Active != nil {
-		in, out := &in.Active, &out.Active
-		*out = make([]ObjectReference, len(*in))
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]EventRecordTriggerStatus, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceTemplateStatus.
-func (in *ResourceTemplateStatus) DeepCopy() *ResourceTemplateStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventRecordStatus.
+func (in *EventRecordStatus) DeepCopy() *EventRecordStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceTemplateStatus)
+	out := new(EventRecordStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretValue) DeepCopyInto(out *SecretValue) {
+func (in *EventRecordTriggerStatus) DeepCopyInto(out *EventRecordTriggerStatus) {
 	*out = *in
-	if in.SecretKeyRef != nil {
-		in, out := &in.SecretKeyRef, &out.SecretKeyRef
-		*out = new(corev1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
-	}
+	out.TriggerRef = in.TriggerRef
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretValue.
-func (in *SecretValue) DeepCopy() *SecretValue {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventRecordTriggerStatus.
+func (in *EventRecordTriggerStatus) DeepCopy() *EventRecordTriggerStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretValue)
+	out := new(EventRecordTriggerStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Trigger) DeepCopyInto(out *Trigger) {
+func (in *EventSourceFilter) DeepCopyInto(out *EventSourceFilter) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Status = in.Status
-	in.Spec.DeepCopyInto(&out.Spec)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Trigger.
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventSourceFilter.
+func (in *EventSourceFilter) DeepCopy() *EventSourceFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(EventSourceFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventSourceSpec) DeepCopyInto(out *EventSourceSpec) {
+	*out = *in
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]EventSourceTrigger, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventSourceSpec.
+func (in *EventSourceSpec) DeepCopy() *EventSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EventSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventSourceStatus) DeepCopyInto(out *EventSourceStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventSourceStatus.
+func (in *EventSourceStatus) DeepCopy() *EventSourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EventSourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventSourceTrigger) DeepCopyInto(out *EventSourceTrigger) {
+	*out = *in
+	if in.Transform != nil {
+		in, out := &in.Transform, &out.Transform
+		*out = new(v1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventSourceTrigger.
+func (in *EventSourceTrigger) DeepCopy() *EventSourceTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(EventSourceTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHubCheckEventFilter) DeepCopyInto(out *GitHubCheckEventFilter) {
+	*out = *in
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = new(EventSourceFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = new(EventSourceFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conclusions != nil {
+		in, out := &in.Conclusions, &out.Conclusions
+		*out = make([]GitHubCheckConclusion, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubCheckEventFilter.
+func (in *GitHubCheckEventFilter) DeepCopy() *GitHubCheckEventFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(GitHubCheckEventFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHubCommentStatus) DeepCopyInto(out *GitHubCommentStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubCommentStatus.
+func (in *GitHubCommentStatus) DeepCopy() *GitHubCommentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GitHubCommentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHubDeploymentStatus) DeepCopyInto(out *GitHubDeploymentStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubDeploymentStatus.
+func (in *GitHubDeploymentStatus) DeepCopy() *GitHubDeploymentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GitHubDeploymentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHubInstallation) DeepCopyInto(out *GitHubInstallation) {
+	*out = *in
+	if in.Push != nil {
+		in, out := &in.Push, &out.Push
+		*out = new(GitHubPushEventFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PullRequest != nil {
+		in, out := &in.PullRequest, &out.PullRequest
+		*out = new(GitHubPullRequestEventFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IssueComment != nil {
+		in, out := &in.IssueComment, &out.IssueComment
+		*out = new(GitHubIssueCommentEventFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WorkflowRun != nil {
+		in, out := &in.WorkflowRun, &out.WorkflowRun
+		*out = new(GitHubCheckEventFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CheckSuite != nil {
+		in, out := &in.CheckSuite, &out.CheckSuite
+		*out = new(GitHubCheckEventFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Release != nil {
+		in, out := &in.Release, &out.Release
+		*out = new(GitHubReleaseEventFilter)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubInstallation.
+func (in *GitHubInstallation) DeepCopy() *GitHubInstallation {
+	if in == nil {
+		return nil
+	}
+	out := new(GitHubInstallation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHubIssueCommentEventFilter) DeepCopyInto(out *GitHubIssueCommentEventFilter) {
+	*out = *in
+	if in.AuthorAssociations != nil {
+		in, out := &in.AuthorAssociations, &out.AuthorAssociations
+		*out = make([]GitHubAuthorAssociation, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubIssueCommentEventFilter.
+func (in *GitHubIssueCommentEventFilter) DeepCopy() *GitHubIssueCommentEventFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(GitHubIssueCommentEventFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHubPullRequestEventFilter) DeepCopyInto(out *GitHubPullRequestEventFilter) {
+	*out = *in
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = new(EventSourceFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = new(EventSourceFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = make([]GitHubPullRequestEventType, len(*in))
+		copy(*out, *in)
+	}
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = new(EventSourceFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(GitHubPullRequestPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubPullRequestEventFilter.
+func (in *GitHubPullRequestEventFilter) DeepCopy() *GitHubPullRequestEventFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(GitHubPullRequestEventFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHubPullRequestPolicy) DeepCopyInto(out *GitHubPullRequestPolicy) {
+	*out = *in
+	if in.AuthorAssociations != nil {
+		in, out := &in.AuthorAssociations, &out.AuthorAssociations
+		*out = make([]GitHubAuthorAssociation, len(*in))
+		copy(*out, *in)
+	}
+	if in.Logins != nil {
+		in, out := &in.Logins, &out.Logins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubPullRequestPolicy.
+func (in *GitHubPullRequestPolicy) DeepCopy() *GitHubPullRequestPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(GitHubPullRequestPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHubPushEventFilter) DeepCopyInto(out *GitHubPushEventFilter) {
+	*out = *in
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = new(EventSourceFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = new(EventSourceFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = new(EventSourceFilter)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubPushEventFilter.
+func (in *GitHubPushEventFilter) DeepCopy() *GitHubPushEventFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(GitHubPushEventFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHubReleaseEventFilter) DeepCopyInto(out *GitHubReleaseEventFilter) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = new(EventSourceFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = make([]GitHubReleaseEventType, len(*in))
+		copy(*out, *in)
+	}
+	if in.Draft != nil {
+		in, out := &in.Draft, &out.Draft
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Prerelease != nil {
+		in, out := &in.Prerelease, &out.Prerelease
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubReleaseEventFilter.
+func (in *GitHubReleaseEventFilter) DeepCopy() *GitHubReleaseEventFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(GitHubReleaseEventFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHubRepository) DeepCopyInto(out *GitHubRepository) {
+	*out = *in
+	if in.SecretToken != nil {
+		in, out := &in.SecretToken, &out.SecretToken
+		*out = new(SecretValue)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Push != nil {
+		in, out := &in.Push, &out.Push
+		*out = new(GitHubPushEventFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PullRequest != nil {
+		in, out := &in.PullRequest, &out.PullRequest
+		*out = new(GitHubPullRequestEventFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IssueComment != nil {
+		in, out := &in.IssueComment, &out.IssueComment
+		*out = new(GitHubIssueCommentEventFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WorkflowRun != nil {
+		in, out := &in.WorkflowRun, &out.WorkflowRun
+		*out = new(GitHubCheckEventFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CheckSuite != nil {
+		in, out := &in.CheckSuite, &out.CheckSuite
+		*out = new(GitHubCheckEventFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Release != nil {
+		in, out := &in.Release, &out.Release
+		*out = new(GitHubReleaseEventFilter)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubRepository.
+func (in *GitHubRepository) DeepCopy() *GitHubRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(GitHubRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHubWebhook) DeepCopyInto(out *GitHubWebhook) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubWebhook.
+func (in *GitHubWebhook) DeepCopy() *GitHubWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(GitHubWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GitHubWebhook) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHubWebhookList) DeepCopyInto(out *GitHubWebhookList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GitHubWebhook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubWebhookList.
+func (in *GitHubWebhookList) DeepCopy() *GitHubWebhookList {
+	if in == nil {
+		return nil
+	}
+	out := new(GitHubWebhookList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GitHubWebhookList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHubWebhookSpec) DeepCopyInto(out *GitHubWebhookSpec) {
+	*out = *in
+	in.EventSourceSpec.DeepCopyInto(&out.EventSourceSpec)
+	if in.SecretToken != nil {
+		in, out := &in.SecretToken, &out.SecretToken
+		*out = new(SecretValue)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
+		*out = make([]GitHubRepository, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Installations != nil {
+		in, out := &in.Installations, &out.Installations
+		*out = make([]GitHubInstallation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubWebhookSpec.
+func (in *GitHubWebhookSpec) DeepCopy() *GitHubWebhookSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GitHubWebhookSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHubWebhookStatus) DeepCopyInto(out *GitHubWebhookStatus) {
+	*out = *in
+	out.EventSourceStatus = in.EventSourceStatus
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubWebhookStatus.
+func (in *GitHubWebhookStatus) DeepCopy() *GitHubWebhookStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GitHubWebhookStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitLabMergeRequestEventFilter) DeepCopyInto(out *GitLabMergeRequestEventFilter) {
+	*out = *in
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = new(EventSourceFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = new(EventSourceFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]GitLabMergeRequestEventAction, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitLabMergeRequestEventFilter.
+func (in *GitLabMergeRequestEventFilter) DeepCopy() *GitLabMergeRequestEventFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(GitLabMergeRequestEventFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitLabProject) DeepCopyInto(out *GitLabProject) {
+	*out = *in
+	if in.Push != nil {
+		in, out := &in.Push, &out.Push
+		*out = new(GitLabPushEventFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MergeRequest != nil {
+		in, out := &in.MergeRequest, &out.MergeRequest
+		*out = new(GitLabMergeRequestEventFilter)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitLabProject.
+func (in *GitLabProject) DeepCopy() *GitLabProject {
+	if in == nil {
+		return nil
+	}
+	out := new(GitLabProject)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitLabPushEventFilter) DeepCopyInto(out *GitLabPushEventFilter) {
+	*out = *in
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = new(EventSourceFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = new(EventSourceFilter)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitLabPushEventFilter.
+func (in *GitLabPushEventFilter) DeepCopy() *GitLabPushEventFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(GitLabPushEventFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitLabWebhook) DeepCopyInto(out *GitLabWebhook) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitLabWebhook.
+func (in *GitLabWebhook) DeepCopy() *GitLabWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(GitLabWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GitLabWebhook) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitLabWebhookList) DeepCopyInto(out *GitLabWebhookList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GitLabWebhook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitLabWebhookList.
+func (in *GitLabWebhookList) DeepCopy() *GitLabWebhookList {
+	if in == nil {
+		return nil
+	}
+	out := new(GitLabWebhookList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GitLabWebhookList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitLabWebhookSpec) DeepCopyInto(out *GitLabWebhookSpec) {
+	*out = *in
+	in.EventSourceSpec.DeepCopyInto(&out.EventSourceSpec)
+	if in.SecretToken != nil {
+		in, out := &in.SecretToken, &out.SecretToken
+		*out = new(SecretValue)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Projects != nil {
+		in, out := &in.Projects, &out.Projects
+		*out = make([]GitLabProject, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitLabWebhookSpec.
+func (in *GitLabWebhookSpec) DeepCopy() *GitLabWebhookSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GitLabWebhookSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitLabWebhookStatus) DeepCopyInto(out *GitLabWebhookStatus) {
+	*out = *in
+	out.EventSourceStatus = in.EventSourceStatus
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitLabWebhookStatus.
+func (in *GitLabWebhookStatus) DeepCopy() *GitLabWebhookStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GitLabWebhookStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitPollSource) DeepCopyInto(out *GitPollSource) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitPollSource.
+func (in *GitPollSource) DeepCopy() *GitPollSource {
+	if in == nil {
+		return nil
+	}
+	out := new(GitPollSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GitPollSource) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitPollSourceList) DeepCopyInto(out *GitPollSourceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GitPollSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitPollSourceList.
+func (in *GitPollSourceList) DeepCopy() *GitPollSourceList {
+	if in == nil {
+		return nil
+	}
+	out := new(GitPollSourceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GitPollSourceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitPollSourceSpec) DeepCopyInto(out *GitPollSourceSpec) {
+	*out = *in
+	in.EventSourceSpec.DeepCopyInto(&out.EventSourceSpec)
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Password != nil {
+		in, out := &in.Password, &out.Password
+		*out = new(SecretValue)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = new(EventSourceFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = new(EventSourceFilter)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitPollSourceSpec.
+func (in *GitPollSourceSpec) DeepCopy() *GitPollSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GitPollSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitPollSourceStatus) DeepCopyInto(out *GitPollSourceStatus) {
+	*out = *in
+	out.EventSourceStatus = in.EventSourceStatus
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = new(EventSourceFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = new(EventSourceFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastPollTime != nil {
+		in, out := &in.LastPollTime, &out.LastPollTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Refs != nil {
+		in, out := &in.Refs, &out.Refs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitPollSourceStatus.
+func (in *GitPollSourceStatus) DeepCopy() *GitPollSourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GitPollSourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GiteaPullRequestEventFilter) DeepCopyInto(out *GiteaPullRequestEventFilter) {
+	*out = *in
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = new(EventSourceFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = new(EventSourceFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = make([]GiteaPullRequestEventType, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GiteaPullRequestEventFilter.
+func (in *GiteaPullRequestEventFilter) DeepCopy() *GiteaPullRequestEventFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(GiteaPullRequestEventFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GiteaPushEventFilter) DeepCopyInto(out *GiteaPushEventFilter) {
+	*out = *in
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = new(EventSourceFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = new(EventSourceFilter)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GiteaPushEventFilter.
+func (in *GiteaPushEventFilter) DeepCopy() *GiteaPushEventFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(GiteaPushEventFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GiteaRepository) DeepCopyInto(out *GiteaRepository) {
+	*out = *in
+	if in.Push != nil {
+		in, out := &in.Push, &out.Push
+		*out = new(GiteaPushEventFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PullRequest != nil {
+		in, out := &in.PullRequest, &out.PullRequest
+		*out = new(GiteaPullRequestEventFilter)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GiteaRepository.
+func (in *GiteaRepository) DeepCopy() *GiteaRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(GiteaRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GiteaWebhook) DeepCopyInto(out *GiteaWebhook) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GiteaWebhook.
+func (in *GiteaWebhook) DeepCopy() *GiteaWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(GiteaWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GiteaWebhook) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GiteaWebhookList) DeepCopyInto(out *GiteaWebhookList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GiteaWebhook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GiteaWebhookList.
+func (in *GiteaWebhookList) DeepCopy() *GiteaWebhookList {
+	if in == nil {
+		return nil
+	}
+	out := new(GiteaWebhookList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GiteaWebhookList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GiteaWebhookSpec) DeepCopyInto(out *GiteaWebhookSpec) {
+	*out = *in
+	in.EventSourceSpec.DeepCopyInto(&out.EventSourceSpec)
+	if in.SecretToken != nil {
+		in, out := &in.SecretToken, &out.SecretToken
+		*out = new(SecretValue)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
+		*out = make([]GiteaRepository, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GiteaWebhookSpec.
+func (in *GiteaWebhookSpec) DeepCopy() *GiteaWebhookSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GiteaWebhookSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GiteaWebhookStatus) DeepCopyInto(out *GiteaWebhookStatus) {
+	*out = *in
+	out.EventSourceStatus = in.EventSourceStatus
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GiteaWebhookStatus.
+func (in *GiteaWebhookStatus) DeepCopy() *GiteaWebhookStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GiteaWebhookStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPWebhook) DeepCopyInto(out *HTTPWebhook) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPWebhook.
+func (in *HTTPWebhook) DeepCopy() *HTTPWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HTTPWebhook) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPWebhookList) DeepCopyInto(out *HTTPWebhookList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HTTPWebhook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPWebhookList.
+func (in *HTTPWebhookList) DeepCopy() *HTTPWebhookList {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPWebhookList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HTTPWebhookList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPWebhookServiceAccountToken) DeepCopyInto(out *HTTPWebhookServiceAccountToken) {
+	*out = *in
+	if in.ServiceAccounts != nil {
+		in, out := &in.ServiceAccounts, &out.ServiceAccounts
+		*out = make([]ServiceAccountReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPWebhookServiceAccountToken.
+func (in *HTTPWebhookServiceAccountToken) DeepCopy() *HTTPWebhookServiceAccountToken {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPWebhookServiceAccountToken)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPWebhookSignature) DeepCopyInto(out *HTTPWebhookSignature) {
+	*out = *in
+	if in.Tolerance != nil {
+		in, out := &in.Tolerance, &out.Tolerance
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPWebhookSignature.
+func (in *HTTPWebhookSignature) DeepCopy() *HTTPWebhookSignature {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPWebhookSignature)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPWebhookSpec) DeepCopyInto(out *HTTPWebhookSpec) {
+	*out = *in
+	in.EventSourceSpec.DeepCopyInto(&out.EventSourceSpec)
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		*out = new(v1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretToken != nil {
+		in, out := &in.SecretToken, &out.SecretToken
+		*out = new(SecretValue)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Signature != nil {
+		in, out := &in.Signature, &out.Signature
+		*out = new(HTTPWebhookSignature)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccountToken != nil {
+		in, out := &in.ServiceAccountToken, &out.ServiceAccountToken
+		*out = new(HTTPWebhookServiceAccountToken)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPWebhookSpec.
+func (in *HTTPWebhookSpec) DeepCopy() *HTTPWebhookSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPWebhookSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPWebhookStatus) DeepCopyInto(out *HTTPWebhookStatus) {
+	*out = *in
+	out.EventSourceStatus = in.EventSourceStatus
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPWebhookStatus.
+func (in *HTTPWebhookStatus) DeepCopy() *HTTPWebhookStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPWebhookStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageRegistryWebhook) DeepCopyInto(out *ImageRegistryWebhook) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageRegistryWebhook.
+func (in *ImageRegistryWebhook) DeepCopy() *ImageRegistryWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageRegistryWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ImageRegistryWebhook) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageRegistryWebhookList) DeepCopyInto(out *ImageRegistryWebhookList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ImageRegistryWebhook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageRegistryWebhookList.
+func (in *ImageRegistryWebhookList) DeepCopy() *ImageRegistryWebhookList {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageRegistryWebhookList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ImageRegistryWebhookList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageRegistryWebhookSpec) DeepCopyInto(out *ImageRegistryWebhookSpec) {
+	*out = *in
+	in.EventSourceSpec.DeepCopyInto(&out.EventSourceSpec)
+	if in.SecretToken != nil {
+		in, out := &in.SecretToken, &out.SecretToken
+		*out = new(SecretValue)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
+		*out = new(EventSourceFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = new(EventSourceFilter)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageRegistryWebhookSpec.
+func (in *ImageRegistryWebhookSpec) DeepCopy() *ImageRegistryWebhookSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageRegistryWebhookSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageRegistryWebhookStatus) DeepCopyInto(out *ImageRegistryWebhookStatus) {
+	*out = *in
+	out.EventSourceStatus = in.EventSourceStatus
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageRegistryWebhookStatus.
+func (in *ImageRegistryWebhookStatus) DeepCopy() *ImageRegistryWebhookStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageRegistryWebhookStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JSONPatch) DeepCopyInto(out *JSONPatch) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(v1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JSONPatch.
+func (in *JSONPatch) DeepCopy() *JSONPatch {
+	if in == nil {
+		return nil
+	}
+	out := new(JSONPatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesEventSource) DeepCopyInto(out *KubernetesEventSource) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesEventSource.
+func (in *KubernetesEventSource) DeepCopy() *KubernetesEventSource {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesEventSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KubernetesEventSource) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesEventSourceList) DeepCopyInto(out *KubernetesEventSourceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KubernetesEventSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesEventSourceList.
+func (in *KubernetesEventSourceList) DeepCopy() *KubernetesEventSourceList {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesEventSourceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KubernetesEventSourceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesEventSourceSpec) DeepCopyInto(out *KubernetesEventSourceSpec) {
+	*out = *in
+	in.EventSourceSpec.DeepCopyInto(&out.EventSourceSpec)
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EventTypes != nil {
+		in, out := &in.EventTypes, &out.EventTypes
+		*out = make([]KubernetesEventType, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesEventSourceSpec.
+func (in *KubernetesEventSourceSpec) DeepCopy() *KubernetesEventSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesEventSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesEventSourceStatus) DeepCopyInto(out *KubernetesEventSourceStatus) {
+	*out = *in
+	out.EventSourceStatus = in.EventSourceStatus
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesEventSourceStatus.
+func (in *KubernetesEventSourceStatus) DeepCopy() *KubernetesEventSourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesEventSourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesEventType) DeepCopyInto(out *KubernetesEventType) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesEventType.
+func (in *KubernetesEventType) DeepCopy() *KubernetesEventType {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesEventType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectReference.
+func (in *ObjectReference) DeepCopy() *ObjectReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceTemplate) DeepCopyInto(out *ResourceTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceTemplate.
+func (in *ResourceTemplate) DeepCopy() *ResourceTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResourceTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceTemplateList) DeepCopyInto(out *ResourceTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ResourceTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceTemplateList.
+func (in *ResourceTemplateList) DeepCopy() *ResourceTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResourceTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceTemplateSpec) DeepCopyInto(out *ResourceTemplateSpec) {
+	*out = *in
+	if in.TriggerRef != nil {
+		in, out := &in.TriggerRef, &out.TriggerRef
+		*out = new(ObjectReference)
+		**out = **in
+	}
+	if in.Patches != nil {
+		in, out := &in.Patches, &out.Patches
+		*out = make([]TriggerPatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Data.DeepCopyInto(&out.Data)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceTemplateSpec.
+func (in *ResourceTemplateSpec) DeepCopy() *ResourceTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceTemplateStatus) DeepCopyInto(out *ResourceTemplateStatus) {
+	*out = *in
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = make([]ObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.GitHubDeployment != nil {
+		in, out := &in.GitHubDeployment, &out.GitHubDeployment
+		*out = new(GitHubDeploymentStatus)
+		**out = **in
+	}
+	if in.GitHubComment != nil {
+		in, out := &in.GitHubComment, &out.GitHubComment
+		*out = new(GitHubCommentStatus)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceTemplateStatus.
+func (in *ResourceTemplateStatus) DeepCopy() *ResourceTemplateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceTemplateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleSource) DeepCopyInto(out *ScheduleSource) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleSource.
+func (in *ScheduleSource) DeepCopy() *ScheduleSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScheduleSource) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleSourceList) DeepCopyInto(out *ScheduleSourceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ScheduleSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleSourceList.
+func (in *ScheduleSourceList) DeepCopy() *ScheduleSourceList {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleSourceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScheduleSourceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleSourceSpec) DeepCopyInto(out *ScheduleSourceSpec) {
+	*out = *in
+	in.EventSourceSpec.DeepCopyInto(&out.EventSourceSpec)
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = new(v1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleSourceSpec.
+func (in *ScheduleSourceSpec) DeepCopy() *ScheduleSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleSourceStatus) DeepCopyInto(out *ScheduleSourceStatus) {
+	*out = *in
+	out.EventSourceStatus = in.EventSourceStatus
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSkipTime != nil {
+		in, out := &in.LastSkipTime, &out.LastSkipTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleSourceStatus.
+func (in *ScheduleSourceStatus) DeepCopy() *ScheduleSourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleSourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretValue) DeepCopyInto(out *SecretValue) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretValue.
+func (in *SecretValue) DeepCopy() *SecretValue {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountReference) DeepCopyInto(out *ServiceAccountReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountReference.
+func (in *ServiceAccountReference) DeepCopy() *ServiceAccountReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Trigger) DeepCopyInto(out *Trigger) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Trigger.
 func (in *Trigger) DeepCopy() *Trigger {
 	if in == nil {
 		return nil
@@ -580,6 +2157,21 @@ func (in *Trigger) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerEnvironment) DeepCopyInto(out *TriggerEnvironment) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerEnvironment.
+func (in *TriggerEnvironment) DeepCopy() *TriggerEnvironment {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerEnvironment)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TriggerList) DeepCopyInto(out *TriggerList) {
 	*out = *in
@@ -654,6 +2246,11 @@ func (in *TriggerSpec) DeepCopyInto(out *TriggerSpec) {
 		*out = new(v1.JSON)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Environment != nil {
+		in, out := &in.Environment, &out.Environment
+		*out = new(TriggerEnvironment)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerSpec.
@@ -680,3 +2277,260 @@ func (in *TriggerStatus) DeepCopy() *TriggerStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookDelivery) DeepCopyInto(out *WebhookDelivery) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookDelivery.
+func (in *WebhookDelivery) DeepCopy() *WebhookDelivery {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookDelivery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WebhookDelivery) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookDeliveryJob) DeepCopyInto(out *WebhookDeliveryJob) {
+	*out = *in
+	out.SourceRef = in.SourceRef
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]EventSourceTrigger, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Event != nil {
+		in, out := &in.Event, &out.Event
+		*out = new(v1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookDeliveryJob.
+func (in *WebhookDeliveryJob) DeepCopy() *WebhookDeliveryJob {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookDeliveryJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookDeliveryJobStatus) DeepCopyInto(out *WebhookDeliveryJobStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookDeliveryJobStatus.
+func (in *WebhookDeliveryJobStatus) DeepCopy() *WebhookDeliveryJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookDeliveryJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookDeliveryList) DeepCopyInto(out *WebhookDeliveryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WebhookDelivery, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookDeliveryList.
+func (in *WebhookDeliveryList) DeepCopy() *WebhookDeliveryList {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookDeliveryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WebhookDeliveryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookDeliverySpec) DeepCopyInto(out *WebhookDeliverySpec) {
+	*out = *in
+	if in.Jobs != nil {
+		in, out := &in.Jobs, &out.Jobs
+		*out = make([]WebhookDeliveryJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookDeliverySpec.
+func (in *WebhookDeliverySpec) DeepCopy() *WebhookDeliverySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookDeliverySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookDeliveryStatus) DeepCopyInto(out *WebhookDeliveryStatus) {
+	*out = *in
+	if in.LastAttemptTime != nil {
+		in, out := &in.LastAttemptTime, &out.LastAttemptTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Jobs != nil {
+		in, out := &in.Jobs, &out.Jobs
+		*out = make([]WebhookDeliveryJobStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookDeliveryStatus.
+func (in *WebhookDeliveryStatus) DeepCopy() *WebhookDeliveryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookDeliveryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookReceipt) DeepCopyInto(out *WebhookReceipt) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookReceipt.
+func (in *WebhookReceipt) DeepCopy() *WebhookReceipt {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookReceipt)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WebhookReceipt) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookReceiptList) DeepCopyInto(out *WebhookReceiptList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WebhookReceipt, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookReceiptList.
+func (in *WebhookReceiptList) DeepCopy() *WebhookReceiptList {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookReceiptList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WebhookReceiptList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookReceiptSpec) DeepCopyInto(out *WebhookReceiptSpec) {
+	*out = *in
+	out.SourceRef = in.SourceRef
+	in.ExpireTime.DeepCopyInto(&out.ExpireTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookReceiptSpec.
+func (in *WebhookReceiptSpec) DeepCopy() *WebhookReceiptSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookReceiptSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookReceiptStatus) DeepCopyInto(out *WebhookReceiptStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookReceiptStatus.
+func (in *WebhookReceiptStatus) DeepCopy() *WebhookReceiptStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookReceiptStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -11,6 +11,9 @@ CRDS=(
   resourcetemplates
   httpwebhooks
   githubwebhooks
+  gitlabwebhooks
+  bitbucketwebhooks
+  giteawebhooks
 )
 
 # Create CRDs first